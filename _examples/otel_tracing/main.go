@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/zlxdbj/zllog"
+	"github.com/zlxdbj/zllog/adapter/spancontext"
+)
+
+// 本示例依赖 go.opentelemetry.io/otel/trace，需要在自己的模块中 go get 后运行
+func main() {
+	// 初始化日志系统
+	config := &zllog.LogConfig{
+		ServiceName:   "otel_example",
+		Env:           "dev",
+		LogLevel:      "DEBUG",
+		LogDir:        "./logs",
+		EnableConsole: true,
+	}
+
+	if err := zllog.InitLoggerWithConfig(config); err != nil {
+		panic(err)
+	}
+
+	// ========================================================================
+	// 1. 注册 OpenTelemetry 的 span context 提取函数
+	// ========================================================================
+
+	// trace.SpanContextFromContext 返回的 trace.SpanContext 满足 spancontext.SpanContext 约束
+	unregister := spancontext.Register(trace.SpanContextFromContext)
+	defer unregister()
+
+	// ========================================================================
+	// 2. 带有 span 的 context：日志自动带上 trace_id 和 span_id
+	// ========================================================================
+
+	// 实际项目中 span 由 tracer.Start 或 otelhttp 等中间件创建，这里手动构造一个已采样的 span context
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	fmt.Println("\n1. 带有 span 的日志")
+	zllog.Info(ctx, "api", "Request handled")
+	// 输出包含 trace_id: "4bf92f3577b34da6a3ce929d0e0e4736", span_id: "00f067aa0ba902b7"
+
+	// ========================================================================
+	// 3. 没有 span 的 context：回退到自动生成的 trace_id，不附加 span_id
+	// ========================================================================
+
+	fmt.Println("\n2. 没有 span 的日志")
+	zllog.Info(context.Background(), "job", "Background job started")
+}
@@ -0,0 +1,108 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/zlxdbj/zllog"
+	"github.com/zlxdbj/zllog/adapter/proto"
+)
+
+// ============================================================================
+// gRPC 错误适配器 - 将 gRPC status 错误展开为结构化字段
+// ============================================================================
+
+// Status gRPC status 的结构化信息
+type Status struct {
+	Code    string        // 状态码名称，如 "NotFound"
+	Message string        // 状态信息
+	Details []interface{} // 附带的 detail 消息（如 errdetails.BadRequest）
+}
+
+// FromError 从错误链中提取 gRPC status
+// zllog 不直接依赖 google.golang.org/grpc，这里识别实现了 GRPCStatus() 方法的错误
+// （status.Error 创建的错误及包装了它的错误），并读取返回值的 Code()、Message()、Details()
+// 错误链中没有 gRPC status 时返回 false
+func FromError(err error) (Status, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		method := reflect.ValueOf(err).MethodByName("GRPCStatus")
+		if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+			continue
+		}
+		st := method.Call(nil)[0]
+		if st.Kind() == reflect.Ptr && st.IsNil() {
+			continue
+		}
+		return Status{
+			Code:    fmt.Sprint(callMethod(st, "Code")),
+			Message: fmt.Sprint(callMethod(st, "Message")),
+			Details: toSlice(callMethod(st, "Details")),
+		}, true
+	}
+	return Status{}, false
+}
+
+// callMethod 调用无参数的方法并返回第一个返回值，方法不存在时返回 nil
+func callMethod(v reflect.Value, name string) interface{} {
+	method := v.MethodByName(name)
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() == 0 {
+		return nil
+	}
+	return method.Call(nil)[0].Interface()
+}
+
+// toSlice 将 []any 形式的返回值转换为 []interface{}
+func toSlice(v interface{}) []interface{} {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return nil
+	}
+	out := make([]interface{}, rv.Len())
+	for i := range out {
+		out[i] = rv.Index(i).Interface()
+	}
+	return out
+}
+
+// ErrorFields 将 gRPC status 错误展开为 grpc_code、grpc_message、grpc_details 字段
+// detail 消息通过 adapter/proto 序列化为 JSON（注册 protojson 见 proto.SetMarshaler）
+// 不是 gRPC status 错误时返回 nil
+func ErrorFields(err error) []zllog.Field {
+	st, ok := FromError(err)
+	if !ok {
+		return nil
+	}
+	fields := []zllog.Field{
+		zllog.String("grpc_code", st.Code),
+		zllog.String("grpc_message", st.Message),
+	}
+	if len(st.Details) > 0 {
+		details := make([]json.RawMessage, 0, len(st.Details))
+		for _, d := range st.Details {
+			// Details 中无法解析的 detail 以 error 形式出现
+			if derr, ok := d.(error); ok {
+				d = map[string]string{"error": derr.Error()}
+			}
+			if b, ok := proto.Proto("", d).Value.([]byte); ok {
+				details = append(details, b)
+			}
+		}
+		fields = append(fields, zllog.Any("grpc_details", details))
+	}
+	return fields
+}
+
+// Error 以 ERROR 级别记录错误，gRPC status 错误附带 grpc_code、grpc_message、grpc_details 字段
+//
+// 用法示例：
+//   import zlgrpc "github.com/zlxdbj/zllog/adapter/grpc"
+//
+//   if err != nil {
+//       zlgrpc.Error(ctx, "rpc", "call user service failed", err)
+//   }
+func Error(ctx context.Context, module, message string, err error, fields ...zllog.Field) {
+	zllog.Error(ctx, module, message, err, append(ErrorFields(err), fields...)...)
+}
@@ -0,0 +1,81 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/zlxdbj/zllog"
+)
+
+// 模拟 google.golang.org/grpc 的 codes.Code、status.Status 和 status.Error
+type code uint32
+
+func (c code) String() string {
+	if c == 5 {
+		return "NotFound"
+	}
+	return fmt.Sprintf("Code(%d)", uint32(c))
+}
+
+type status struct {
+	code    code
+	message string
+	details []any
+}
+
+func (s *status) Code() code      { return s.code }
+func (s *status) Message() string { return s.message }
+func (s *status) Details() []any  { return s.details }
+
+type statusError struct{ s *status }
+
+func (e *statusError) Error() string       { return "rpc error: " + e.s.message }
+func (e *statusError) GRPCStatus() *status { return e.s }
+
+// badRequest 模拟 errdetails.BadRequest
+type badRequest struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// TestErrorFields 测试 gRPC status 错误展开为结构化字段
+func TestErrorFields(t *testing.T) {
+	st := &status{code: 5, message: "user not found", details: []any{
+		&badRequest{Field: "user_id", Description: "unknown id"},
+	}}
+	err := fmt.Errorf("get user: %w", &statusError{s: st})
+
+	var entries []zllog.Entry
+	original := zllog.GetLogger()
+	zllog.SetLogger(zllog.NewEntryLogger(func(ctx context.Context, e zllog.Entry) {
+		entries = append(entries, e)
+	}))
+	defer zllog.SetLogger(original)
+
+	Error(context.Background(), "rpc", "call failed", err)
+
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	fields := zllog.FieldsToMap(entries[0].Fields)
+	if fields["grpc_code"] != "NotFound" || fields["grpc_message"] != "user not found" {
+		t.Errorf("unexpected status fields: %v", fields)
+	}
+	b, _ := json.Marshal(fields["grpc_details"])
+	if string(b) != `[{"field":"user_id","description":"unknown id"}]` {
+		t.Errorf("unexpected grpc_details: %s", b)
+	}
+}
+
+// TestErrorFieldsPlainError 测试非 gRPC 错误不附加字段
+func TestErrorFieldsPlainError(t *testing.T) {
+	if fields := ErrorFields(errors.New("boom")); fields != nil {
+		t.Errorf("expected no fields, got %v", fields)
+	}
+	if fields := ErrorFields(nil); fields != nil {
+		t.Errorf("expected no fields for nil error, got %v", fields)
+	}
+}
@@ -0,0 +1,289 @@
+package httplog
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/zlxdbj/zllog"
+)
+
+// ============================================================================
+// HTTP 中间件 - 为每个请求输出一条访问日志
+// ============================================================================
+
+// DefaultMaxBodyBytes 请求/响应体默认最多记录的字节数
+const DefaultMaxBodyBytes = 4096
+
+// truncatedSuffix 请求/响应体超出上限截断后追加的后缀（与 zllog 的消息截断保持一致）
+const truncatedSuffix = "…(truncated)"
+
+// Config HTTP 中间件配置
+type Config struct {
+	Module          string // 日志 module（默认 "http"）
+	RequestIDHeader string // 读取 request_id 的请求头（默认 "X-Request-ID"）
+
+	// 请求/响应体记录（默认关闭）
+	// 只记录文本类内容（JSON、XML、text/*、表单），二进制内容跳过；
+	// 请求体在 handler 读取时同步记录，handler 未读取的部分不会被记录
+	CaptureBodies bool
+	MaxBodyBytes  int // 最多记录的字节数（默认 4096，超出部分截断）
+
+	// Redact 记录前对请求/响应体脱敏，field 为 "req_body" 或 "resp_body"
+	// 返回值替换原始内容，返回 nil 时不记录该字段
+	Redact func(field, contentType string, body []byte) []byte
+
+	// 单个请求的日志级别覆盖（默认关闭，用于测试/预发环境临时查看某个请求的 DEBUG 日志）
+	// 只有客户端地址在 LevelOverrideAllowlist 中的请求才会读取请求头/查询参数，
+	// 覆盖只作用于该请求的 context（zllog.WithLevelOverride），不影响全局级别
+	LevelOverrideAllowlist []string // 允许覆盖级别的客户端 IP 或 CIDR（如 "10.0.0.0/8"、"127.0.0.1"），为空时不启用
+	LevelHeader            string   // 读取覆盖级别的请求头（默认 "X-Log-Level"）
+	LevelQuery             string   // 读取覆盖级别的查询参数（如 "log_level"，默认不读取），请求头优先
+}
+
+// DefaultLevelHeader 读取日志级别覆盖的默认请求头
+const DefaultLevelHeader = "X-Log-Level"
+
+// Middleware 创建 HTTP 访问日志中间件
+// 每个请求结束后输出一条日志（method、path、status、status_class、cost_ms），5xx 为 ERROR 级别，其余为 INFO
+// 请求的 context 会固定 trace_id 并带上 request_id，handler 中的日志与访问日志可以互相关联
+//
+// 用法示例：
+//   import "github.com/zlxdbj/zllog/adapter/httplog"
+//
+//   handler := httplog.Middleware(httplog.Config{CaptureBodies: true})(mux)
+//   http.ListenAndServe(":8080", handler)
+func Middleware(config Config) func(http.Handler) http.Handler {
+	if config.Module == "" {
+		config.Module = "http"
+	}
+	if config.RequestIDHeader == "" {
+		config.RequestIDHeader = "X-Request-ID"
+	}
+	if config.MaxBodyBytes <= 0 {
+		config.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	if config.LevelHeader == "" {
+		config.LevelHeader = DefaultLevelHeader
+	}
+	allowlist := parseAllowlist(config.LevelOverrideAllowlist)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := zllog.Now()
+
+			ctx := zllog.FromContext(r.Context()).Context()
+			if requestID := r.Header.Get(config.RequestIDHeader); requestID != "" {
+				ctx = zllog.WithRequestID(ctx, requestID)
+			}
+			if level, ok := levelOverride(r, config, allowlist); ok {
+				ctx = zllog.WithLevelOverride(ctx, level)
+			}
+			r = r.WithContext(ctx)
+
+			rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+			var reqBody *bodyCapture
+			if config.CaptureBodies {
+				rw.body = &bodyCapture{max: config.MaxBodyBytes}
+				if r.Body != nil && r.Body != http.NoBody {
+					reqBody = &bodyCapture{max: config.MaxBodyBytes}
+					r.Body = &captureReader{ReadCloser: r.Body, capture: reqBody}
+				}
+			}
+
+			next.ServeHTTP(rw, r)
+
+			fields := []zllog.Field{
+				zllog.String("method", r.Method),
+				zllog.String("path", r.URL.Path),
+			}
+			fields = append(fields, zllog.HTTPStatus(rw.status)...)
+			fields = append(fields, zllog.Int64("cost_ms", zllog.Since(start).Milliseconds()))
+			if reqBody != nil {
+				fields = appendBody(fields, config, "req_body", r.Header.Get("Content-Type"), reqBody)
+			}
+			if rw.body != nil {
+				fields = appendBody(fields, config, "resp_body", rw.Header().Get("Content-Type"), rw.body)
+			}
+
+			if rw.status >= http.StatusInternalServerError {
+				zllog.Error(ctx, config.Module, "http request", nil, fields...)
+			} else {
+				zllog.Info(ctx, config.Module, "http request", fields...)
+			}
+		})
+	}
+}
+
+// parseAllowlist 解析 IP 和 CIDR 白名单，单个 IP 视为 /32（IPv6 为 /128），无法解析的条目被忽略
+func parseAllowlist(entries []string) []*net.IPNet {
+	var nets []*net.IPNet
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if !strings.Contains(entry, "/") {
+			if ip := net.ParseIP(entry); ip != nil && ip.To4() != nil {
+				entry += "/32"
+			} else {
+				entry += "/128"
+			}
+		}
+		if _, n, err := net.ParseCIDR(entry); err == nil {
+			nets = append(nets, n)
+		}
+	}
+	return nets
+}
+
+// levelOverride 读取请求指定的日志级别，客户端不在白名单中或级别无法识别时返回 false
+// 客户端地址取 RemoteAddr（不信任 X-Forwarded-For 等可伪造的请求头）
+func levelOverride(r *http.Request, config Config, allowlist []*net.IPNet) (zllog.Level, bool) {
+	if len(allowlist) == 0 {
+		return 0, false
+	}
+	value := r.Header.Get(config.LevelHeader)
+	if value == "" && config.LevelQuery != "" {
+		value = r.URL.Query().Get(config.LevelQuery)
+	}
+	if value == "" {
+		return 0, false
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return 0, false
+	}
+	for _, n := range allowlist {
+		if n.Contains(ip) {
+			level, err := zllog.ParseLevel(value)
+			return level, err == nil
+		}
+	}
+	return 0, false
+}
+
+// appendBody 按内容类型追加请求/响应体字段
+// JSON 内容完整时以 RawJSON 输出，其余文本以字符串输出，二进制内容跳过
+func appendBody(fields []zllog.Field, config Config, key, contentType string, c *bodyCapture) []zllog.Field {
+	if len(c.buf) == 0 {
+		return fields
+	}
+	if contentType == "" {
+		contentType = http.DetectContentType(c.buf)
+	}
+	if !isTextual(contentType) {
+		return fields
+	}
+
+	body := c.buf
+	if config.Redact != nil {
+		if body = config.Redact(key, contentType, body); body == nil {
+			return fields
+		}
+	}
+	if !c.truncated && isJSON(contentType) && json.Valid(body) {
+		return append(fields, zllog.RawJSON(key, body))
+	}
+	if c.truncated {
+		return append(fields, zllog.String(key, string(body)+truncatedSuffix))
+	}
+	return append(fields, zllog.String(key, string(body)))
+}
+
+// isTextual 判断内容类型是否为可读文本
+func isTextual(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	if strings.HasPrefix(mediaType, "text/") || isJSON(mediaType) {
+		return true
+	}
+	switch {
+	case strings.HasSuffix(mediaType, "/xml"), strings.HasSuffix(mediaType, "+xml"),
+		mediaType == "application/x-www-form-urlencoded", mediaType == "application/javascript":
+		return true
+	}
+	return false
+}
+
+// isJSON 判断内容类型是否为 JSON
+func isJSON(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// ============================================================================
+// 请求/响应体记录
+// ============================================================================
+
+// bodyCapture 记录最多 max 字节的内容
+type bodyCapture struct {
+	buf       []byte
+	max       int
+	truncated bool
+}
+
+func (c *bodyCapture) write(p []byte) {
+	if room := c.max - len(c.buf); room < len(p) {
+		p = p[:room]
+		c.truncated = true
+	}
+	c.buf = append(c.buf, p...)
+}
+
+// captureReader 在 handler 读取请求体时同步记录
+type captureReader struct {
+	io.ReadCloser
+	capture *bodyCapture
+}
+
+func (r *captureReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		r.capture.write(p[:n])
+	}
+	return n, err
+}
+
+// responseWriter 记录响应状态码和响应体
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        *bodyCapture
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(p []byte) (int, error) {
+	w.wroteHeader = true
+	if w.body != nil {
+		w.body.write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush 支持流式响应
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap 供 http.ResponseController 获取底层 ResponseWriter
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
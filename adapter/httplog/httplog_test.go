@@ -0,0 +1,172 @@
+package httplog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/zlxdbj/zllog"
+)
+
+// captureEntries 将全局 Logger 替换为记录 Entry 的实现
+func captureEntries(t *testing.T) func() []zllog.Entry {
+	t.Helper()
+
+	var mu sync.Mutex
+	var entries []zllog.Entry
+	original := zllog.GetLogger()
+	zllog.SetLogger(zllog.NewEntryLogger(func(ctx context.Context, e zllog.Entry) {
+		mu.Lock()
+		defer mu.Unlock()
+		entries = append(entries, e)
+	}))
+	t.Cleanup(func() { zllog.SetLogger(original) })
+
+	return func() []zllog.Entry {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]zllog.Entry(nil), entries...)
+	}
+}
+
+// echoHandler 读取请求体并按请求的 Content-Type 原样返回
+func echoHandler(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+	w.WriteHeader(http.StatusCreated)
+	w.Write(body)
+}
+
+func serve(handler http.Handler, contentType string, body []byte) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-Request-ID", "req-1")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+// TestMiddleware 测试访问日志的基础字段，默认不记录请求/响应体
+func TestMiddleware(t *testing.T) {
+	entries := captureEntries(t)
+	handler := Middleware(Config{})(http.HandlerFunc(echoHandler))
+
+	rec := serve(handler, "application/json", []byte(`{"event":"paid"}`))
+	if rec.Body.String() != `{"event":"paid"}` {
+		t.Errorf("handler should receive the full body, got %q", rec.Body.String())
+	}
+
+	got := entries()
+	if len(got) != 1 || got[0].Module != "http" || got[0].RequestID != "req-1" {
+		t.Fatalf("unexpected entries: %+v", got)
+	}
+	fields := zllog.FieldsToMap(got[0].Fields)
+	if fields["status"] != http.StatusCreated || fields["status_class"] != "2xx" || fields["path"] != "/webhook" {
+		t.Errorf("unexpected fields: %v", fields)
+	}
+	if _, ok := fields["req_body"]; ok {
+		t.Errorf("bodies should not be captured by default: %v", fields)
+	}
+}
+
+// TestMiddlewareCaptureJSON 测试 JSON 请求/响应体以 RawJSON 记录并经过脱敏
+func TestMiddlewareCaptureJSON(t *testing.T) {
+	entries := captureEntries(t)
+	handler := Middleware(Config{
+		CaptureBodies: true,
+		Redact: func(field, contentType string, body []byte) []byte {
+			return bytes.ReplaceAll(body, []byte("s3cr3t"), []byte("***"))
+		},
+	})(http.HandlerFunc(echoHandler))
+
+	serve(handler, "application/json; charset=utf-8", []byte(`{"token":"s3cr3t"}`))
+
+	fields := zllog.FieldsToMap(entries()[0].Fields)
+	for _, key := range []string{"req_body", "resp_body"} {
+		b, _ := json.Marshal(fields[key])
+		if string(b) != `{"token":"***"}` {
+			t.Errorf("%s = %s, want redacted JSON object", key, b)
+		}
+	}
+}
+
+// TestMiddlewareCaptureLimits 测试二进制内容跳过，超长文本截断
+func TestMiddlewareCaptureLimits(t *testing.T) {
+	entries := captureEntries(t)
+	handler := Middleware(Config{CaptureBodies: true, MaxBodyBytes: 8})(http.HandlerFunc(echoHandler))
+
+	serve(handler, "application/octet-stream", []byte{0x00, 0x01, 0x02, 0xff})
+	rec := serve(handler, "text/plain", []byte(strings.Repeat("a", 20)))
+	if rec.Body.Len() != 20 {
+		t.Errorf("handler response should not be truncated, got %d bytes", rec.Body.Len())
+	}
+
+	got := entries()
+	binary := zllog.FieldsToMap(got[0].Fields)
+	if _, ok := binary["req_body"]; ok {
+		t.Errorf("binary body should be skipped: %v", binary)
+	}
+	text := zllog.FieldsToMap(got[1].Fields)
+	if text["req_body"] != "aaaaaaaa"+truncatedSuffix || text["resp_body"] != "aaaaaaaa"+truncatedSuffix {
+		t.Errorf("expected truncated text bodies, got %v", text)
+	}
+}
+
+// TestMiddlewareLevelOverride 测试白名单内的请求通过请求头/查询参数单独输出 DEBUG 日志
+func TestMiddlewareLevelOverride(t *testing.T) {
+	originalLevel := zerolog.GlobalLevel()
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+	defer zerolog.SetGlobalLevel(originalLevel)
+
+	buf := &bytes.Buffer{}
+	base := zerolog.New(buf)
+	defer zllog.SwapLogger(zllog.NewZerologLogger(&base))()
+
+	handler := Middleware(Config{
+		LevelOverrideAllowlist: []string{"192.0.2.0/24", "not-an-ip"},
+		LevelQuery:             "log_level",
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		zllog.Debug(r.Context(), "order", "debug for "+r.URL.Path)
+	}))
+
+	send := func(path, remoteAddr, level string) {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = remoteAddr
+		if level != "" {
+			req.Header.Set(DefaultLevelHeader, level)
+		}
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	send("/flagged", "192.0.2.10:5000", "debug")
+	send("/plain", "192.0.2.10:5000", "")
+	send("/outsider", "203.0.113.5:5000", "debug")
+	send("/query?log_level=debug", "192.0.2.11:5000", "")
+	send("/invalid", "192.0.2.10:5000", "verbose")
+
+	var debugLines []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &m); err != nil {
+			t.Fatalf("invalid JSON %q: %v", line, err)
+		}
+		if m["level"] == "debug" {
+			debugLines = append(debugLines, m["message"].(string))
+		}
+	}
+	want := []string{"debug for /flagged", "debug for /query"}
+	if strings.Join(debugLines, ",") != strings.Join(want, ",") {
+		t.Errorf("debug lines = %v, want %v", debugLines, want)
+	}
+
+	// 覆盖只作用于请求的 context，全局级别不变
+	if zerolog.GlobalLevel() != zerolog.InfoLevel {
+		t.Errorf("global level changed to %v", zerolog.GlobalLevel())
+	}
+}
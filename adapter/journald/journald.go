@@ -0,0 +1,233 @@
+package journald
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zlxdbj/zllog"
+)
+
+// ============================================================================
+// journald Logger 适配器 - 通过 systemd-journald 原生协议输出结构化日志
+// ============================================================================
+
+// DefaultSocket journald 原生协议的 socket 路径
+const DefaultSocket = "/run/systemd/journal/socket"
+
+// syslog 优先级（journald 的 PRIORITY 字段）
+const (
+	priCrit    = 2
+	priErr     = 3
+	priWarning = 4
+	priInfo    = 6
+	priDebug   = 7
+)
+
+// JournaldLogger 通过 journald 原生协议写日志的 Logger 实现
+// 基于 zllog.EntryLogger：静默的 context、request_id、上下文字段、*f 方法末尾的结构化字段等与其他适配器行为一致
+//
+// 用法示例：
+//   import "github.com/zlxdbj/zllog/adapter/journald"
+//
+//   zllog.SetLogger(journald.NewJournaldLogger("my_service"))
+//
+// 特性：
+//   - MESSAGE、PRIORITY、SYSLOG_IDENTIFIER 使用 journald 标准字段
+//   - trace_id、module 以 TRACE_ID、MODULE 自定义字段输出，可用 journalctl TRACE_ID=xxx 过滤
+//   - 结构化字段名转换为大写（journald 字段名只允许 A-Z、0-9、_）
+//   - journald socket 不可用时（非 systemd 环境、容器内等）回退为输出到 stderr
+type JournaldLogger struct {
+	*zllog.EntryLogger
+
+	identifier string
+
+	mu       sync.Mutex
+	conn     net.Conn
+	fallback io.Writer
+}
+
+// NewJournaldLogger 创建 journald Logger，identifier 作为 SYSLOG_IDENTIFIER（通常为服务名）
+func NewJournaldLogger(identifier string) *JournaldLogger {
+	return NewJournaldLoggerWithSocket(identifier, DefaultSocket)
+}
+
+// NewJournaldLoggerWithSocket 使用指定的 socket 路径创建 journald Logger
+func NewJournaldLoggerWithSocket(identifier, socket string) *JournaldLogger {
+	l := &JournaldLogger{identifier: identifier, fallback: os.Stderr}
+	l.EntryLogger = zllog.NewEntryLogger(l.handle)
+	if conn, err := net.Dial("unixgram", socket); err == nil {
+		l.conn = conn
+	}
+	return l
+}
+
+// Close 关闭 journald 连接
+func (l *JournaldLogger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.conn == nil {
+		return nil
+	}
+	err := l.conn.Close()
+	l.conn = nil
+	return err
+}
+
+// Field journald 字段（保持写入顺序）
+type Field struct {
+	Name  string
+	Value string
+}
+
+// EncodeFields 按 journald 原生协议编码字段
+// 普通值编码为 "NAME=value\n"；包含换行的值编码为 "NAME\n" + 8字节小端长度 + value + "\n"
+func EncodeFields(fields []Field) []byte {
+	var buf bytes.Buffer
+	for _, f := range fields {
+		if strings.ContainsRune(f.Value, '\n') {
+			buf.WriteString(f.Name)
+			buf.WriteByte('\n')
+			binary.Write(&buf, binary.LittleEndian, uint64(len(f.Value)))
+			buf.WriteString(f.Value)
+			buf.WriteByte('\n')
+			continue
+		}
+		buf.WriteString(f.Name)
+		buf.WriteByte('=')
+		buf.WriteString(f.Value)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// FieldName 将字段名转换为 journald 合法的字段名
+// 转为大写，非 A-Z、0-9 的字符替换为 _，并去掉开头的 _（以 _ 开头的是 journald 受信任字段）
+func FieldName(key string) string {
+	name := []byte(strings.ToUpper(key))
+	for i, c := range name {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			name[i] = '_'
+		}
+	}
+	s := strings.TrimLeft(string(name), "_")
+	if s == "" || (s[0] >= '0' && s[0] <= '9') {
+		s = "F_" + s
+	}
+	return s
+}
+
+// fieldValue 将 zllog 字段值转换为字符串
+func fieldValue(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case error:
+		return val.Error()
+	case time.Time:
+		return val.Format(time.RFC3339Nano)
+	case []byte:
+		return string(val)
+	case fmt.Stringer:
+		return val.String()
+	case zllog.LazyValue:
+		return fieldValue(val())
+	case []zllog.Field:
+		b, _ := json.Marshal(zllog.FieldsToMap(val))
+		return string(b)
+	case zllog.FieldArray:
+		// Array：只取各字段的值，编码为 JSON 数组
+		items := make([]interface{}, len(val))
+		for i, item := range val {
+			if lazy, ok := item.Value.(zllog.LazyValue); ok {
+				items[i] = lazy()
+				continue
+			}
+			items[i] = item.Value
+		}
+		b, _ := json.Marshal(items)
+		return string(b)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// priority 将日志级别转换为 syslog 优先级（journald 没有 TRACE 优先级，使用 debug）
+func priority(level string) int {
+	switch level {
+	case "trace", "debug":
+		return priDebug
+	case "info":
+		return priInfo
+	case "warn":
+		return priWarning
+	case "error":
+		return priErr
+	default:
+		return priCrit
+	}
+}
+
+// handle 将 Entry 转换为 journald 字段并发送
+func (l *JournaldLogger) handle(ctx context.Context, e zllog.Entry) {
+	entry := []Field{
+		{Name: "MESSAGE", Value: e.Message},
+		{Name: "PRIORITY", Value: strconv.Itoa(priority(e.Level))},
+		{Name: "SYSLOG_IDENTIFIER", Value: l.identifier},
+		{Name: "TRACE_ID", Value: e.TraceID},
+		{Name: "MODULE", Value: e.Module},
+	}
+	if e.ParentTraceID != "" {
+		entry = append(entry, Field{Name: "PARENT_TRACE_ID", Value: e.ParentTraceID})
+	}
+	if e.Error != "" {
+		entry = append(entry, Field{Name: "ERROR", Value: e.Error})
+	}
+	if e.ErrorCode != "" {
+		entry = append(entry, Field{Name: "ERROR_CODE", Value: e.ErrorCode})
+	}
+	if e.RequestID != "" {
+		entry = append(entry, Field{Name: "REQUEST_ID", Value: e.RequestID})
+	}
+	if e.CostMs > 0 {
+		entry = append(entry, Field{Name: "COST_MS", Value: strconv.FormatInt(e.CostMs, 10)})
+	}
+	for _, f := range e.Fields {
+		entry = append(entry, Field{Name: FieldName(f.Key), Value: fieldValue(f.Value)})
+	}
+
+	l.write(entry)
+}
+
+// write 发送到 journald，失败时回退到 stderr
+func (l *JournaldLogger) write(entry []Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn != nil {
+		if _, err := l.conn.Write(EncodeFields(entry)); err == nil {
+			return
+		}
+	}
+
+	// 回退格式：PRIORITY MODULE: MESSAGE KEY=value ...
+	var buf bytes.Buffer
+	buf.WriteString(entry[1].Value + " " + entry[4].Value + ": " + entry[0].Value)
+	for _, f := range entry[3:] {
+		if f.Name == "MODULE" {
+			continue
+		}
+		fmt.Fprintf(&buf, " %s=%q", f.Name, f.Value)
+	}
+	buf.WriteByte('\n')
+	l.fallback.Write(buf.Bytes())
+}
@@ -0,0 +1,92 @@
+package journald
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zlxdbj/zllog"
+)
+
+// TestEncodeFields 测试 journald 原生协议编码
+func TestEncodeFields(t *testing.T) {
+	got := EncodeFields([]Field{
+		{Name: "MESSAGE", Value: "hello"},
+		{Name: "STACK", Value: "a\nb"},
+	})
+
+	var want bytes.Buffer
+	want.WriteString("MESSAGE=hello\n")
+	want.WriteString("STACK\n")
+	binary.Write(&want, binary.LittleEndian, uint64(3))
+	want.WriteString("a\nb\n")
+
+	if !bytes.Equal(got, want.Bytes()) {
+		t.Errorf("EncodeFields = %q, want %q", got, want.Bytes())
+	}
+}
+
+// TestFieldName 测试字段名转换为 journald 合法格式
+func TestFieldName(t *testing.T) {
+	cases := map[string]string{
+		"trace_id":  "TRACE_ID",
+		"user.name": "USER_NAME",
+		"_secret":   "SECRET",
+		"1st":       "F_1ST",
+	}
+	for key, want := range cases {
+		if got := FieldName(key); got != want {
+			t.Errorf("FieldName(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+// TestFallbackToStderr 测试 socket 不可用时回退输出
+func TestFallbackToStderr(t *testing.T) {
+	logger := NewJournaldLoggerWithSocket("test", filepath.Join(t.TempDir(), "missing.sock"))
+	var buf bytes.Buffer
+	logger.fallback = &buf
+
+	logger.Warn(context.Background(), "api", "slow request")
+
+	out := buf.String()
+	if !strings.HasPrefix(out, "4 api: slow request") || !strings.Contains(out, "TRACE_ID=") {
+		t.Errorf("unexpected fallback output: %q", out)
+	}
+}
+
+// TestEntryBehavior 测试与 EntryLogger 一致的行为：*f 方法的结构化字段、context 中的 request_id、Array 字段
+func TestEntryBehavior(t *testing.T) {
+	logger := NewJournaldLoggerWithSocket("test", filepath.Join(t.TempDir(), "missing.sock"))
+	var buf bytes.Buffer
+	logger.fallback = &buf
+
+	ctx := zllog.WithRequestID(context.Background(), "req-1")
+	logger.Infof(ctx, "api", "created %d items", 3, zllog.String("user_id", "u1"), zllog.Array("tags", zllog.String("", "a"), zllog.Int("", 1)))
+
+	out := buf.String()
+	for _, want := range []string{"6 api: created 3 items", `USER_ID="u1"`, `REQUEST_ID="req-1"`, `TAGS="[\"a\",1]"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %s in %q", want, out)
+		}
+	}
+}
+
+// TestMuteLogging 测试静默的 context 不输出日志，FATAL 以下级别全部跳过
+func TestMuteLogging(t *testing.T) {
+	logger := NewJournaldLoggerWithSocket("test", filepath.Join(t.TempDir(), "missing.sock"))
+	var buf bytes.Buffer
+	logger.fallback = &buf
+
+	muted := zllog.MuteLogging(context.Background())
+	logger.Info(muted, "import", "row imported")
+	logger.Errorf(muted, "import", "row %d failed", nil, 3)
+	logger.Info(context.Background(), "import", "import started")
+
+	if out := buf.String(); strings.Count(out, "\n") != 1 || !strings.Contains(out, "import started") {
+		t.Errorf("expected only the unmuted line, got %q", out)
+	}
+}
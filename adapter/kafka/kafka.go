@@ -0,0 +1,264 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/zlxdbj/zllog"
+)
+
+// ============================================================================
+// Kafka Logger 适配器 - 将日志批量投递到 Kafka
+// ============================================================================
+
+// Message 待投递的 Kafka 消息
+type Message struct {
+	Topic string
+	Key   []byte
+	Value []byte
+}
+
+// Producer Kafka 生产者接口
+// zllog 不直接依赖具体的 Kafka 客户端，使用 sarama、kafka-go、confluent-kafka-go 等
+// 客户端时实现此接口即可（一次调用投递一批消息）
+type Producer interface {
+	Produce(ctx context.Context, messages []Message) error
+}
+
+// Config Kafka Logger 配置
+type Config struct {
+	Topic         string        // 投递的 topic（必填）
+	ServiceName   string        // 服务名称（默认使用 zllog.GetServiceName()）
+	BatchSize     int           // 每批消息条数（默认 100，达到后立即投递）
+	FlushInterval time.Duration // 定时投递间隔（默认 1s）
+	MaxPending    int           // 缓冲区最多保留的消息条数（默认 10000），超出时丢弃并计入 Dropped
+	OnError       func(error)   // 投递失败回调（默认输出到 stderr）
+}
+
+var (
+	// ErrClosed Logger 已关闭
+	ErrClosed = errors.New("kafka logger closed")
+	// ErrBufferFull 缓冲区已满（Kafka 长时间不可用），之后的日志被丢弃直到缓冲区有空位
+	ErrBufferFull = errors.New("kafka logger buffer full, dropping logs")
+)
+
+// fatalFlushTimeout Fatal 退出进程前投递缓冲日志的最长等待时间
+var fatalFlushTimeout = 5 * time.Second
+
+// KafkaLogger 将日志编码为 JSON 并批量异步投递到 Kafka 的 Logger 实现
+//
+// 用法示例：
+//   import "github.com/zlxdbj/zllog/adapter/kafka"
+//
+//   logger := kafka.NewKafkaLogger(myProducer, kafka.Config{Topic: "app-logs"})
+//   defer logger.Close(context.Background())
+//   zllog.SetLogger(logger)
+//
+// 特性：
+//   - 以 trace_id 作为消息 key，同一 trace 的日志落在同一分区，保持顺序
+//   - 攒批投递：达到 BatchSize 或 FlushInterval 到期时由后台 goroutine 投递，每次 Produce 最多 BatchSize 条
+//   - 投递失败的批次及之后的日志放回缓冲区，下次投递时重试；缓冲区超过 MaxPending 时丢弃最旧的日志，丢弃条数见 Dropped
+//   - Flush 同步投递当前缓冲的日志，Close 投递剩余日志并停止后台 goroutine
+type KafkaLogger struct {
+	*zllog.EntryLogger
+
+	producer Producer
+	config   Config
+
+	mu       sync.Mutex
+	pending  []Message
+	closed   bool
+	overflow bool // 缓冲区已满，ErrBufferFull 只在开始丢弃时报告一次
+
+	dropped uint64
+
+	sendMu  sync.Mutex // 保证批次按顺序投递
+	trigger chan struct{}
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// NewKafkaLogger 创建 Kafka Logger 并启动后台投递 goroutine
+func NewKafkaLogger(producer Producer, config Config) *KafkaLogger {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = time.Second
+	}
+	if config.MaxPending <= 0 {
+		config.MaxPending = 10000
+	}
+	if config.MaxPending < config.BatchSize {
+		config.MaxPending = config.BatchSize
+	}
+	if config.OnError == nil {
+		config.OnError = func(err error) {
+			fmt.Fprintf(os.Stderr, "zllog kafka: %v\n", err)
+		}
+	}
+
+	l := &KafkaLogger{
+		producer: producer,
+		config:   config,
+		trigger:  make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	l.EntryLogger = zllog.NewEntryLogger(l.handle)
+	// Fatal 退出进程前先投递缓冲中的日志
+	l.EntryLogger.OnFatal = l.flushBeforeExit
+	go l.run()
+	return l
+}
+
+// run 后台投递循环
+func (l *KafkaLogger) run() {
+	defer close(l.stopped)
+
+	ticker := time.NewTicker(l.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.done:
+			return
+		case <-l.trigger:
+		case <-ticker.C:
+		}
+		if err := l.send(context.Background()); err != nil {
+			l.config.OnError(err)
+		}
+	}
+}
+
+// Flush 同步投递当前缓冲的所有日志，失败时返回投递错误，日志留在缓冲区等待重试
+func (l *KafkaLogger) Flush(ctx context.Context) error {
+	return l.send(ctx)
+}
+
+// flushBeforeExit 投递缓冲中的日志，最多等待 fatalFlushTimeout
+// Producer 不响应 ctx 时也按时返回，避免 Fatal 无法退出进程
+func (l *KafkaLogger) flushBeforeExit() {
+	ctx, cancel := context.WithTimeout(context.Background(), fatalFlushTimeout)
+	defer cancel()
+
+	errc := make(chan error, 1)
+	go func() { errc <- l.Flush(ctx) }()
+	select {
+	case err := <-errc:
+		if err != nil {
+			l.config.OnError(err)
+		}
+	case <-ctx.Done():
+		l.config.OnError(fmt.Errorf("flush before exit: %w", ctx.Err()))
+	}
+}
+
+// Dropped 返回因缓冲区已满被丢弃的日志条数
+func (l *KafkaLogger) Dropped() uint64 {
+	return atomic.LoadUint64(&l.dropped)
+}
+
+// Close 投递剩余日志并停止后台 goroutine，之后的日志会被丢弃
+func (l *KafkaLogger) Close(ctx context.Context) error {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return nil
+	}
+	l.closed = true
+	l.mu.Unlock()
+
+	close(l.done)
+	<-l.stopped
+	return l.send(ctx)
+}
+
+// send 取出当前缓冲的日志，按每批最多 BatchSize 条依次投递
+// 某一批失败时停止投递，该批及之后的日志放回缓冲区头部，下次投递时重试（已投递的批次不重复投递）
+func (l *KafkaLogger) send(ctx context.Context) error {
+	l.sendMu.Lock()
+	defer l.sendMu.Unlock()
+
+	l.mu.Lock()
+	pending := l.pending
+	l.pending = nil
+	l.mu.Unlock()
+
+	for len(pending) > 0 {
+		n := l.config.BatchSize
+		if n > len(pending) {
+			n = len(pending)
+		}
+		if err := l.producer.Produce(ctx, pending[:n]); err != nil {
+			l.requeue(pending)
+			return fmt.Errorf("produce %d messages: %w", n, err)
+		}
+		pending = pending[n:]
+	}
+	return nil
+}
+
+// requeue 将投递失败的日志放回缓冲区头部（保持顺序），超出 MaxPending 时丢弃最旧的日志
+func (l *KafkaLogger) requeue(batch []Message) {
+	l.mu.Lock()
+	merged := append(batch, l.pending...)
+	report := false
+	if n := len(merged) - l.config.MaxPending; n > 0 {
+		merged = merged[n:]
+		atomic.AddUint64(&l.dropped, uint64(n))
+		report = !l.overflow
+		l.overflow = true
+	}
+	l.pending = merged
+	l.mu.Unlock()
+
+	if report {
+		l.config.OnError(ErrBufferFull)
+	}
+}
+
+// enqueue 放入缓冲区，达到批次大小时通知后台投递；缓冲区已满时丢弃
+func (l *KafkaLogger) enqueue(msg Message) {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		l.config.OnError(ErrClosed)
+		return
+	}
+	if len(l.pending) >= l.config.MaxPending {
+		atomic.AddUint64(&l.dropped, 1)
+		report := !l.overflow
+		l.overflow = true
+		l.mu.Unlock()
+		if report {
+			l.config.OnError(ErrBufferFull)
+		}
+		return
+	}
+	l.overflow = false
+	l.pending = append(l.pending, msg)
+	full := len(l.pending) >= l.config.BatchSize
+	l.mu.Unlock()
+
+	if full {
+		select {
+		case l.trigger <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// handle 将日志编码为 JSON 并放入缓冲区，以 trace_id 作为消息 key
+func (l *KafkaLogger) handle(ctx context.Context, e zllog.Entry) {
+	if l.config.ServiceName != "" {
+		e.Service = l.config.ServiceName
+	}
+	l.enqueue(Message{Topic: l.config.Topic, Key: []byte(e.TraceID), Value: zllog.EncodeJSON(e)})
+}
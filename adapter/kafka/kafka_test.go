@@ -0,0 +1,272 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zlxdbj/zllog"
+)
+
+// mockProducer 记录投递的消息
+type mockProducer struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func (p *mockProducer) Produce(ctx context.Context, messages []Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.messages = append(p.messages, messages...)
+	return nil
+}
+
+func (p *mockProducer) sent() []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Message(nil), p.messages...)
+}
+
+// testTraceIDProvider 返回固定的 trace_id
+type testTraceIDProvider struct{}
+
+func (testTraceIDProvider) GetTraceID(ctx context.Context) string {
+	return "4bf92f3577b34da6a3ce929d0e0e4736"
+}
+func (testTraceIDProvider) Name() string { return "test" }
+
+// TestKafkaLoggerFlush 测试 Flush 投递消息，key 为 trace_id，value 为 JSON 日志
+func TestKafkaLoggerFlush(t *testing.T) {
+	original := zllog.GetTraceIDProvider()
+	zllog.RegisterTraceIDProvider(testTraceIDProvider{})
+	defer zllog.RegisterTraceIDProvider(original)
+
+	producer := &mockProducer{}
+	logger := NewKafkaLogger(producer, Config{Topic: "app-logs", ServiceName: "svc", FlushInterval: time.Hour})
+	defer logger.Close(context.Background())
+
+	logger.ErrorWithCode(context.Background(), "payment", "charge failed", "PAY_001", nil,
+		zllog.String("order_id", "o-1"))
+	if len(producer.sent()) != 0 {
+		t.Fatal("messages should be buffered until flush")
+	}
+
+	if err := logger.Flush(context.Background()); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+	sent := producer.sent()
+	if len(sent) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(sent))
+	}
+	msg := sent[0]
+	if msg.Topic != "app-logs" || string(msg.Key) != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("unexpected topic/key: %s/%s", msg.Topic, msg.Key)
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(msg.Value, &payload); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if payload["level"] != "error" || payload["module"] != "payment" || payload["error_code"] != "PAY_001" {
+		t.Errorf("unexpected payload: %v", payload)
+	}
+	if fields, _ := payload["fields"].(map[string]interface{}); fields["order_id"] != "o-1" {
+		t.Errorf("unexpected fields: %v", payload["fields"])
+	}
+}
+
+// TestKafkaLoggerBatch 测试达到批次大小后由后台自动投递
+func TestKafkaLoggerBatch(t *testing.T) {
+	producer := &mockProducer{}
+	logger := NewKafkaLogger(producer, Config{Topic: "app-logs", BatchSize: 2, FlushInterval: time.Hour})
+	defer logger.Close(context.Background())
+
+	logger.Info(context.Background(), "api", "first")
+	logger.Info(context.Background(), "api", "second")
+
+	deadline := time.Now().Add(time.Second)
+	for len(producer.sent()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := len(producer.sent()); n != 2 {
+		t.Errorf("expected batch of 2 to be delivered, got %d", n)
+	}
+}
+
+// failingProducer 在 fail 为 true 时投递失败
+type failingProducer struct {
+	mockProducer
+	fail bool
+}
+
+func (p *failingProducer) Produce(ctx context.Context, messages []Message) error {
+	p.mu.Lock()
+	fail := p.fail
+	p.mu.Unlock()
+	if fail {
+		return errors.New("broker unavailable")
+	}
+	return p.mockProducer.Produce(ctx, messages)
+}
+
+func (p *failingProducer) setFail(fail bool) {
+	p.mu.Lock()
+	p.fail = fail
+	p.mu.Unlock()
+}
+
+// TestKafkaLoggerRetry 测试投递失败时返回错误、日志留在缓冲区，恢复后按原顺序投递
+func TestKafkaLoggerRetry(t *testing.T) {
+	producer := &failingProducer{fail: true}
+	logger := NewKafkaLogger(producer, Config{Topic: "app-logs", FlushInterval: time.Hour})
+	defer logger.Close(context.Background())
+
+	logger.Info(context.Background(), "api", "first")
+	if err := logger.Flush(context.Background()); err == nil {
+		t.Fatal("expected flush error while the broker is unavailable")
+	}
+	logger.Info(context.Background(), "api", "second")
+
+	producer.setFail(false)
+	if err := logger.Flush(context.Background()); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+	sent := producer.sent()
+	if len(sent) != 2 {
+		t.Fatalf("expected the failed batch to be retried, got %d messages", len(sent))
+	}
+	var first map[string]interface{}
+	json.Unmarshal(sent[0].Value, &first)
+	if first["message"] != "first" {
+		t.Errorf("expected retried messages in order, got %v", first["message"])
+	}
+}
+
+// TestKafkaLoggerMaxPending 测试缓冲区超过 MaxPending 时丢弃日志并计数，ErrBufferFull 只报告一次
+func TestKafkaLoggerMaxPending(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		full int
+	)
+	producer := &failingProducer{fail: true}
+	logger := NewKafkaLogger(producer, Config{
+		Topic:         "app-logs",
+		BatchSize:     10,
+		MaxPending:    10,
+		FlushInterval: time.Hour,
+		OnError: func(err error) {
+			if errors.Is(err, ErrBufferFull) {
+				mu.Lock()
+				full++
+				mu.Unlock()
+			}
+		},
+	})
+	defer logger.Close(context.Background())
+
+	for i := 0; i < 13; i++ {
+		logger.Infof(context.Background(), "api", "line %d", i)
+	}
+	logger.Flush(context.Background())
+
+	if got := logger.Dropped(); got != 3 {
+		t.Errorf("expected 3 dropped logs, got %d", got)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if full != 1 {
+		t.Errorf("expected a single ErrBufferFull, got %d", full)
+	}
+}
+
+// flakyProducer 记录每次投递的条数，第 failCall 次调用投递失败
+type flakyProducer struct {
+	mockProducer
+	calls    int
+	failCall int
+	sizes    []int
+}
+
+func (p *flakyProducer) Produce(ctx context.Context, messages []Message) error {
+	p.mu.Lock()
+	p.calls++
+	p.sizes = append(p.sizes, len(messages))
+	fail := p.calls == p.failCall
+	p.mu.Unlock()
+	if fail {
+		return errors.New("broker unavailable")
+	}
+	return p.mockProducer.Produce(ctx, messages)
+}
+
+// TestKafkaLoggerSendChunks 测试每次 Produce 最多 BatchSize 条，只有失败的批次及之后的日志被重试
+func TestKafkaLoggerSendChunks(t *testing.T) {
+	producer := &flakyProducer{failCall: 2}
+	logger := NewKafkaLogger(producer, Config{Topic: "app-logs", BatchSize: 2, FlushInterval: time.Hour})
+	defer logger.Close(context.Background())
+
+	// 直接放入缓冲区，避免达到 BatchSize 时触发后台投递
+	for i := 0; i < 5; i++ {
+		logger.pending = append(logger.pending, Message{Value: []byte{byte('0' + i)}})
+	}
+	if err := logger.Flush(context.Background()); err == nil {
+		t.Fatal("expected the second batch to fail")
+	}
+	if err := logger.Flush(context.Background()); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+
+	var got string
+	for _, msg := range producer.sent() {
+		got += string(msg.Value)
+	}
+	if got != "01234" {
+		t.Errorf("expected each message delivered once in order, got %q", got)
+	}
+	producer.mu.Lock()
+	defer producer.mu.Unlock()
+	for _, n := range producer.sizes {
+		if n > 2 {
+			t.Errorf("expected batches of at most 2 messages, got sizes %v", producer.sizes)
+			break
+		}
+	}
+}
+
+// blockingProducer 一直阻塞，不响应 ctx
+type blockingProducer struct {
+	release chan struct{}
+}
+
+func (p *blockingProducer) Produce(ctx context.Context, messages []Message) error {
+	<-p.release
+	return nil
+}
+
+// TestKafkaLoggerFatalFlushTimeout 测试 Producer 无响应时 Fatal 前的投递在超时后返回并报告错误
+func TestKafkaLoggerFatalFlushTimeout(t *testing.T) {
+	defer func(timeout time.Duration) { fatalFlushTimeout = timeout }(fatalFlushTimeout)
+	fatalFlushTimeout = 50 * time.Millisecond
+
+	errc := make(chan error, 1)
+	producer := &blockingProducer{release: make(chan struct{})}
+	defer close(producer.release)
+	logger := NewKafkaLogger(producer, Config{
+		Topic:         "app-logs",
+		FlushInterval: time.Hour,
+		OnError:       func(err error) { errc <- err },
+	})
+
+	logger.Info(context.Background(), "api", "before fatal")
+	start := time.Now()
+	logger.EntryLogger.OnFatal()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("flush before exit took %v, expected to return near the timeout", elapsed)
+	}
+	if err := <-errc; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
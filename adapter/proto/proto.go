@@ -0,0 +1,186 @@
+package proto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"github.com/zlxdbj/zllog"
+)
+
+// ============================================================================
+// protobuf 消息适配器 - 将 protobuf 消息以 JSON 形式记录为字段
+// ============================================================================
+
+// Marshaler 将消息序列化为 JSON
+// zllog 不直接依赖 google.golang.org/protobuf，接入时通过 SetMarshaler 注册 protojson：
+//
+//   import (
+//       "google.golang.org/protobuf/encoding/protojson"
+//       gproto "google.golang.org/protobuf/proto"
+//       zlproto "github.com/zlxdbj/zllog/adapter/proto"
+//   )
+//
+//   zlproto.SetMarshaler(func(m interface{}) ([]byte, error) {
+//       return protojson.Marshal(m.(gproto.Message))
+//   })
+type Marshaler func(m interface{}) ([]byte, error)
+
+// MaskValue 被屏蔽字段的替换值
+const MaskValue = "***"
+
+// truncatedSuffix 超长字符串截断后追加的后缀（与 zllog 的消息截断保持一致）
+const truncatedSuffix = "…(truncated)"
+
+// marshaler 当前使用的序列化函数（默认 encoding/json）
+var marshaler atomic.Value
+
+func init() {
+	marshaler.Store(Marshaler(json.Marshal))
+}
+
+// SetMarshaler 设置消息序列化函数，传入 nil 时恢复为 encoding/json
+func SetMarshaler(fn Marshaler) {
+	if fn == nil {
+		fn = json.Marshal
+	}
+	marshaler.Store(fn)
+}
+
+// Option Proto 字段选项
+type Option func(*options)
+
+type options struct {
+	mask      []string
+	omit      []string
+	maxString int
+}
+
+// Mask 将指定路径的字段值替换为 "***"（用于 token、密码等敏感字段）
+// 路径使用序列化后的 JSON 字段名，以 "." 分隔，如 "user.password"；经过数组时对每个元素生效
+func Mask(paths ...string) Option {
+	return func(o *options) {
+		o.mask = append(o.mask, paths...)
+	}
+}
+
+// Omit 删除指定路径的字段（用于体积较大且无需记录的字段），路径规则同 Mask
+func Omit(paths ...string) Option {
+	return func(o *options) {
+		o.omit = append(o.omit, paths...)
+	}
+}
+
+// MaxStringLength 截断超过 n 字节的字符串值（bytes 字段在 protojson 中为 base64 字符串，同样生效）
+func MaxStringLength(n int) Option {
+	return func(o *options) {
+		o.maxString = n
+	}
+}
+
+// Proto 创建 protobuf 消息字段，消息序列化为紧凑的 JSON 后以 RawJSON 附加
+// 序列化失败时记录为字符串形式的错误信息，不影响日志本身输出
+//
+// 用法示例：
+//   zllog.Info(ctx, "grpc", "request received",
+//       zlproto.Proto("request", req, zlproto.Mask("password"), zlproto.Omit("attachment")))
+func Proto(key string, m interface{}, opts ...Option) zllog.Field {
+	if m == nil {
+		return zllog.RawJSON(key, []byte("null"))
+	}
+	b, err := marshal(m, opts)
+	if err != nil {
+		return zllog.String(key, fmt.Sprintf("<proto marshal error: %v>", err))
+	}
+	return zllog.RawJSON(key, b)
+}
+
+// marshal 序列化消息并按选项处理字段
+func marshal(m interface{}, opts []Option) ([]byte, error) {
+	b, err := marshaler.Load().(Marshaler)(m)
+	if err != nil {
+		return nil, err
+	}
+
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	// protojson 的输出会随机插入空白，统一压缩为紧凑格式
+	if len(o.mask) == 0 && len(o.omit) == 0 && o.maxString <= 0 {
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, b); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	for _, path := range o.omit {
+		applyPath(v, strings.Split(path, "."), func(obj map[string]interface{}, key string) {
+			delete(obj, key)
+		})
+	}
+	for _, path := range o.mask {
+		applyPath(v, strings.Split(path, "."), func(obj map[string]interface{}, key string) {
+			if _, ok := obj[key]; ok {
+				obj[key] = MaskValue
+			}
+		})
+	}
+	if o.maxString > 0 {
+		v = truncateStrings(v, o.maxString)
+	}
+	return json.Marshal(v)
+}
+
+// applyPath 沿路径找到目标字段所在的对象并调用 fn，经过数组时对每个元素递归
+func applyPath(v interface{}, path []string, fn func(obj map[string]interface{}, key string)) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		if len(path) == 1 {
+			fn(val, path[0])
+			return
+		}
+		if next, ok := val[path[0]]; ok {
+			applyPath(next, path[1:], fn)
+		}
+	case []interface{}:
+		for _, item := range val {
+			applyPath(item, path, fn)
+		}
+	}
+}
+
+// truncateStrings 递归截断超长字符串（在 UTF-8 字符边界处截断）
+func truncateStrings(v interface{}, max int) interface{} {
+	switch val := v.(type) {
+	case string:
+		if len(val) <= max {
+			return val
+		}
+		cut := max
+		for cut > 0 && !utf8.RuneStart(val[cut]) {
+			cut--
+		}
+		return val[:cut] + truncatedSuffix
+	case map[string]interface{}:
+		for k, item := range val {
+			val[k] = truncateStrings(item, max)
+		}
+	case []interface{}:
+		for i, item := range val {
+			val[i] = truncateStrings(item, max)
+		}
+	}
+	return v
+}
@@ -0,0 +1,79 @@
+package proto
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// user / loginRequest 模拟生成的 protobuf 消息（JSON 字段名与 protojson 一致）
+type user struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+type loginRequest struct {
+	RequestId string `json:"requestId"`
+	Users     []user `json:"users"`
+	Avatar    string `json:"avatar"`
+}
+
+func sampleMessage() *loginRequest {
+	return &loginRequest{
+		RequestId: "req-1",
+		Users:     []user{{Name: "alice", Password: "p1"}, {Name: "bob", Password: "p2"}},
+		Avatar:    strings.Repeat("A", 64),
+	}
+}
+
+// TestProto 测试消息序列化为紧凑 JSON 并应用屏蔽、删除、截断
+func TestProto(t *testing.T) {
+	// 模拟 protojson 输出中的随机空白
+	SetMarshaler(func(m interface{}) ([]byte, error) {
+		b, err := json.MarshalIndent(m, "", "  ")
+		return b, err
+	})
+	defer SetMarshaler(nil)
+
+	f := Proto("request", sampleMessage())
+	raw, ok := f.Value.([]byte)
+	if !ok || f.Key != "request" {
+		t.Fatalf("expected RawJSON field, got %#v", f)
+	}
+	if strings.ContainsAny(string(raw), " \n") {
+		t.Errorf("expected compact JSON, got %s", raw)
+	}
+
+	f = Proto("request", sampleMessage(), Mask("users.password"), Omit("requestId"), MaxStringLength(8))
+	var got map[string]interface{}
+	if err := json.Unmarshal(f.Value.([]byte), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if _, ok := got["requestId"]; ok {
+		t.Errorf("expected requestId omitted, got %v", got)
+	}
+	for _, u := range got["users"].([]interface{}) {
+		if pw := u.(map[string]interface{})["password"]; pw != MaskValue {
+			t.Errorf("expected password masked, got %v", pw)
+		}
+	}
+	if avatar := got["avatar"]; avatar != "AAAAAAAA"+truncatedSuffix {
+		t.Errorf("expected avatar truncated, got %v", avatar)
+	}
+}
+
+// TestProtoMarshalError 测试 nil 消息和序列化失败
+func TestProtoMarshalError(t *testing.T) {
+	if f := Proto("request", nil); string(f.Value.([]byte)) != "null" {
+		t.Errorf("expected null, got %v", f.Value)
+	}
+
+	SetMarshaler(func(m interface{}) ([]byte, error) { return nil, errors.New("not a proto.Message") })
+	defer SetMarshaler(nil)
+
+	f := Proto("request", sampleMessage())
+	if s, ok := f.Value.(string); !ok || !strings.Contains(s, "not a proto.Message") {
+		t.Errorf("expected marshal error string, got %#v", f.Value)
+	}
+}
@@ -0,0 +1,43 @@
+package remote
+
+import (
+	"math/rand"
+	"time"
+)
+
+// ============================================================================
+// 重试退避策略
+// ============================================================================
+
+// BackoffFunc 返回第 attempt 次重试前的等待时间（attempt 从 0 开始）
+type BackoffFunc func(attempt int) time.Duration
+
+// DefaultBackoff 默认退避策略：从 100ms 开始指数增长，最长 5s，带随机抖动
+var DefaultBackoff = ExponentialBackoff(100*time.Millisecond, 5*time.Second)
+
+// ExponentialBackoff 指数退避 + 随机抖动
+// 第 attempt 次重试的基准等待时间为 base*2^attempt（不超过 max），
+// 实际等待时间在 [基准/2, 基准] 之间随机，避免大量实例在同一时刻重试
+func ExponentialBackoff(base, max time.Duration) BackoffFunc {
+	return func(attempt int) time.Duration {
+		d := base
+		for i := 0; i < attempt && d < max; i++ {
+			d *= 2
+		}
+		if d > max {
+			d = max
+		}
+		half := d / 2
+		if half <= 0 {
+			return d
+		}
+		return half + time.Duration(rand.Int63n(int64(d-half)+1))
+	}
+}
+
+// ConstantBackoff 固定等待时间
+func ConstantBackoff(d time.Duration) BackoffFunc {
+	return func(int) time.Duration {
+		return d
+	}
+}
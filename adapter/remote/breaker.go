@@ -0,0 +1,93 @@
+package remote
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/zlxdbj/zllog"
+)
+
+// ============================================================================
+// 熔断器（上报接口持续失败时暂停上报）
+// ============================================================================
+
+// BreakerState 熔断器状态
+type BreakerState int
+
+const (
+	// BreakerClosed 正常上报
+	BreakerClosed BreakerState = iota
+	// BreakerOpen 连续失败达到阈值，冷却期内不再上报，日志写入 Fallback
+	BreakerOpen
+	// BreakerHalfOpen 冷却期已结束，下一次上报作为试探（不重试），成功则恢复，失败则重新熔断
+	BreakerHalfOpen
+)
+
+// String 返回状态名称（closed、open、half-open）
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// ErrBreakerOpen 熔断器打开，日志已写入 Fallback 而没有上报
+var ErrBreakerOpen = errors.New("remote logger circuit open")
+
+// breaker 按连续失败次数熔断的熔断器，threshold <= 0 时不熔断
+// 时间取自 zllog.Now，测试中可通过 zllog.SetClock 控制冷却期
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	open     bool
+}
+
+// state 返回当前状态：打开且冷却期已结束时为半开
+func (b *breaker) state() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.stateLocked()
+}
+
+func (b *breaker) stateLocked() BreakerState {
+	if !b.open {
+		return BreakerClosed
+	}
+	if zllog.Since(b.openedAt) >= b.cooldown {
+		return BreakerHalfOpen
+	}
+	return BreakerOpen
+}
+
+// record 记录一次上报的结果，返回本次是否触发了熔断
+func (b *breaker) record(err error) (tripped bool) {
+	if b.threshold <= 0 {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		b.failures = 0
+		b.open = false
+		return false
+	}
+	b.failures++
+	// 半开状态的试探失败立即重新熔断
+	if b.open || b.failures >= b.threshold {
+		b.open = true
+		b.openedAt = zllog.Now()
+		return true
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zlxdbj/zllog"
+)
+
+// TestRemoteLoggerBreaker 测试连续失败后熔断、冷却期内写入 Fallback、半开试探成功后恢复
+func TestRemoteLoggerBreaker(t *testing.T) {
+	clock := zllog.NewManualClock(time.Date(2025, 1, 27, 10, 0, 0, 0, time.UTC))
+	defer zllog.SetClock(clock)()
+
+	server := newTestServer(t, http.StatusInternalServerError)
+	var fallback bytes.Buffer
+	var tripped []error
+	logger := NewRemoteLogger(Config{
+		URL:              server.URL,
+		FlushInterval:    time.Hour,
+		MaxRetries:       5,
+		Backoff:          ConstantBackoff(0),
+		BreakerThreshold: 2,
+		BreakerCooldown:  time.Minute,
+		Fallback:         &fallback,
+		OnError: func(err error) {
+			if errors.Is(err, ErrBreakerOpen) {
+				tripped = append(tripped, err)
+			}
+		},
+	})
+	defer logger.Close(context.Background())
+
+	ctx := context.Background()
+	send := func(message string) error {
+		logger.Info(ctx, "api", message)
+		return logger.Flush(ctx)
+	}
+
+	// 第一批失败（含重试）不熔断
+	if err := send("first"); err == nil || logger.BreakerState() != BreakerClosed {
+		t.Fatalf("expected failure without tripping, got err=%v state=%s", err, logger.BreakerState())
+	}
+	// 第二批失败达到阈值，熔断
+	if err := send("second"); err == nil || logger.BreakerState() != BreakerOpen {
+		t.Fatalf("expected breaker to open, got err=%v state=%s", err, logger.BreakerState())
+	}
+	if len(tripped) != 1 {
+		t.Errorf("expected one trip notification, got %v", tripped)
+	}
+	if logger.Healthy() || !errors.Is(logger.LastError(), ErrBreakerOpen) {
+		t.Errorf("expected unhealthy with ErrBreakerOpen, got %v", logger.LastError())
+	}
+	var statusErr *StatusError
+	if !errors.As(logger.LastError(), &statusErr) {
+		t.Errorf("expected LastError to keep the last status error, got %v", logger.LastError())
+	}
+
+	// 冷却期内不上报，日志写入 Fallback
+	hits := atomic.LoadInt32(&server.hits)
+	if err := send("during cooldown"); !errors.Is(err, ErrBreakerOpen) {
+		t.Fatalf("expected ErrBreakerOpen, got %v", err)
+	}
+	if atomic.LoadInt32(&server.hits) != hits {
+		t.Errorf("expected no request while open, got %d", atomic.LoadInt32(&server.hits)-hits)
+	}
+	if !strings.Contains(fallback.String(), `"message":"during cooldown"`) {
+		t.Errorf("expected fallback to contain the line, got %q", fallback.String())
+	}
+
+	// 冷却期结束半开，试探失败（不重试）立即重新熔断
+	clock.Advance(time.Minute)
+	if logger.BreakerState() != BreakerHalfOpen {
+		t.Fatalf("expected half-open after cooldown, got %s", logger.BreakerState())
+	}
+	hits = atomic.LoadInt32(&server.hits)
+	if err := send("probe fails"); err == nil || logger.BreakerState() != BreakerOpen {
+		t.Fatalf("expected failed probe to reopen, got err=%v state=%s", err, logger.BreakerState())
+	}
+	if atomic.LoadInt32(&server.hits)-hits != 1 {
+		t.Errorf("expected a single probe request, got %d", atomic.LoadInt32(&server.hits)-hits)
+	}
+
+	// 再次冷却后试探成功，恢复上报
+	clock.Advance(time.Minute)
+	server.mu.Lock()
+	server.statuses = []int{http.StatusOK}
+	server.mu.Unlock()
+	if err := send("probe succeeds"); err != nil {
+		t.Fatalf("expected probe to succeed, got %v", err)
+	}
+	if logger.BreakerState() != BreakerClosed || !logger.Healthy() {
+		t.Errorf("expected closed and healthy, got %s %v", logger.BreakerState(), logger.LastError())
+	}
+}
+
+// TestBreakerStateString 测试熔断器状态名称
+func TestBreakerStateString(t *testing.T) {
+	for state, want := range map[BreakerState]string{
+		BreakerClosed: "closed", BreakerOpen: "open", BreakerHalfOpen: "half-open", BreakerState(9): "unknown",
+	} {
+		if state.String() != want {
+			t.Errorf("expected %s, got %s", want, state.String())
+		}
+	}
+}
@@ -0,0 +1,447 @@
+package remote
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/zlxdbj/zllog"
+)
+
+// ============================================================================
+// Remote Logger 适配器 - 通过 HTTP 批量上报日志
+// ============================================================================
+
+// Config Remote Logger 配置
+type Config struct {
+	URL              string            // 上报地址（必填），以 POST 发送 JSON 数组
+	Headers          map[string]string // 额外的请求头（如鉴权信息）
+	ServiceName      string            // 服务名称（默认使用 zllog.GetServiceName()）
+	BatchSize        int               // 每批日志条数（默认 100，达到后立即上报）
+	MaxPending       int               // 缓冲区最多保留的日志条数（默认 10000），上报阻塞时超出的日志被丢弃并计入 Dropped
+	FlushInterval    time.Duration     // 定时上报间隔（默认 1s）
+	Timeout          time.Duration     // 单次请求超时（默认 5s）
+	MaxRetries       int               // 失败后的最大重试次数（默认 0，不重试）
+	Compress         bool              // 是否以 gzip 压缩请求体（Content-Encoding: gzip），压缩失败时发送原始内容
+	CompressMinBytes int               // 开启压缩时，请求体不小于该字节数才压缩（默认 0，总是压缩），小批量不值得付出压缩的 CPU 开销
+	Backoff          BackoffFunc       // 重试退避策略（默认 DefaultBackoff）
+	Client           *http.Client      // HTTP 客户端（默认使用 Timeout 创建）
+	OnError          func(error)       // 上报失败回调（默认输出到 stderr）
+	BreakerThreshold int               // 连续多少批上报失败（含重试）后熔断（默认 0，不熔断）
+	BreakerCooldown  time.Duration     // 熔断后暂停上报的时长，到期后半开试探（默认 30s）
+	Fallback         io.Writer         // 熔断期间日志的备用输出，每行一条 JSON（默认 os.Stderr）
+}
+
+// backlogBatches 缓冲的日志超过多少个批次时视为不健康（上报跟不上写入）
+const backlogBatches = 10
+
+var (
+	// ErrClosed Logger 已关闭
+	ErrClosed = errors.New("remote logger closed")
+	// ErrBufferFull 缓冲区已满（上报接口长时间无响应），之后的日志被丢弃直到缓冲区有空位
+	ErrBufferFull = errors.New("remote logger buffer full, dropping logs")
+)
+
+// fatalFlushTimeout Fatal 退出进程前上报缓冲日志的最长等待时间
+var fatalFlushTimeout = 5 * time.Second
+
+// StatusError 服务端返回了非 2xx 状态码
+type StatusError struct {
+	StatusCode int
+}
+
+// Error 实现 error 接口
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("remote log endpoint returned status %d", e.StatusCode)
+}
+
+// retryable 5xx 和 429 可以重试，其余 4xx 重试也不会成功
+func (e *StatusError) retryable() bool {
+	return e.StatusCode >= 500 || e.StatusCode == http.StatusTooManyRequests
+}
+
+// RemoteLogger 将日志编码为 JSON 并批量上报到 HTTP 接口的 Logger 实现
+//
+// 用法示例：
+//   import "github.com/zlxdbj/zllog/adapter/remote"
+//
+//   logger := remote.NewRemoteLogger(remote.Config{URL: "https://log.example.com/ingest", MaxRetries: 3})
+//   defer logger.Close(context.Background())
+//   zllog.SetLogger(logger)
+//
+// 特性：
+//   - 攒批上报：达到 BatchSize 或 FlushInterval 到期时由后台 goroutine 上报，请求体为 JSON 数组
+//   - 可选 gzip 压缩请求体（Compress），请求体小于 CompressMinBytes 时不压缩
+//   - 失败重试：按 Backoff 退避后重试 MaxRetries 次；Close 会中止后台正在进行的重试
+//   - Flush 同步上报当前缓冲的日志（重试受传入的 ctx 控制），Close 上报剩余日志并停止后台 goroutine
+//   - Flush 和 Close 的等待时间都受传入 ctx 的限制，上报接口无响应时也不会阻塞进程退出
+//   - 上报阻塞期间缓冲区最多保留 MaxPending 条日志，超出的日志被丢弃，丢弃条数见 Dropped
+//   - 熔断（BreakerThreshold）：连续失败达到阈值后冷却期内不再上报，日志写入 Fallback；
+//     冷却期结束后半开，下一批只试探一次，成功则恢复上报，失败则重新熔断
+//   - 实现 zllog.HealthChecker：最近一次上报失败、熔断中或积压超过 10 个批次时 Healthy 返回 false，
+//     熔断中 LastError 返回包装了 ErrBreakerOpen 的错误
+type RemoteLogger struct {
+	*zllog.EntryLogger
+
+	config Config
+	client *http.Client
+
+	mu       sync.Mutex
+	pending  [][]byte
+	closed   bool
+	overflow bool // 缓冲区已满，ErrBufferFull 只在开始丢弃时报告一次
+
+	dropped uint64
+
+	// 健康状态：failing 表示最近一次上报失败，lastErr 为最近一次失败的错误
+	failing bool
+	lastErr error
+	breaker breaker
+
+	// ctx 后台上报使用的 context，Close 时取消以中止正在进行的重试
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	sendSem chan struct{} // 保证批次按顺序上报（容量为 1 的信号量，获取时可被 ctx 中止）
+	trigger chan struct{}
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// NewRemoteLogger 创建 Remote Logger 并启动后台上报 goroutine
+func NewRemoteLogger(config Config) *RemoteLogger {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = time.Second
+	}
+	if config.MaxPending <= 0 {
+		config.MaxPending = 10000
+	}
+	if config.MaxPending < config.BatchSize {
+		config.MaxPending = config.BatchSize
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Second
+	}
+	if config.Backoff == nil {
+		config.Backoff = DefaultBackoff
+	}
+	if config.BreakerCooldown <= 0 {
+		config.BreakerCooldown = 30 * time.Second
+	}
+	if config.Fallback == nil {
+		config.Fallback = os.Stderr
+	}
+	if config.OnError == nil {
+		config.OnError = func(err error) {
+			fmt.Fprintf(os.Stderr, "zllog remote: %v\n", err)
+		}
+	}
+
+	client := config.Client
+	if client == nil {
+		client = &http.Client{Timeout: config.Timeout}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	l := &RemoteLogger{
+		config:  config,
+		client:  client,
+		ctx:     ctx,
+		cancel:  cancel,
+		sendSem: make(chan struct{}, 1),
+		trigger: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+		breaker: breaker{threshold: config.BreakerThreshold, cooldown: config.BreakerCooldown},
+	}
+	l.EntryLogger = zllog.NewEntryLogger(l.handle)
+	// Fatal 退出进程前先上报缓冲中的日志
+	l.EntryLogger.OnFatal = func() {
+		ctx, cancel := context.WithTimeout(context.Background(), fatalFlushTimeout)
+		defer cancel()
+		if err := l.Flush(ctx); err != nil && !errors.Is(err, ErrBreakerOpen) {
+			l.config.OnError(err)
+		}
+	}
+	go l.run()
+	return l
+}
+
+// run 后台上报循环
+func (l *RemoteLogger) run() {
+	defer close(l.stopped)
+
+	ticker := time.NewTicker(l.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.done:
+			return
+		case <-l.trigger:
+		case <-ticker.C:
+		}
+		// 熔断期间的日志已写入 Fallback，不再每次回调
+		if err := l.send(l.ctx); err != nil && !errors.Is(err, ErrBreakerOpen) {
+			l.config.OnError(err)
+		}
+	}
+}
+
+// Flush 同步上报当前缓冲的所有日志，熔断期间日志写入 Fallback 并返回 ErrBreakerOpen
+func (l *RemoteLogger) Flush(ctx context.Context) error {
+	return l.send(ctx)
+}
+
+// Dropped 返回因缓冲区已满被丢弃的日志条数
+func (l *RemoteLogger) Dropped() uint64 {
+	return atomic.LoadUint64(&l.dropped)
+}
+
+// Close 中止后台的重试，上报剩余日志并停止后台 goroutine，之后的日志会被丢弃
+// 整个过程受 ctx 限制：ctx 到期时立即返回 ctx 的错误，未上报的日志会被丢弃
+//
+//   ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+//   defer cancel()
+//   logger.Close(ctx)
+func (l *RemoteLogger) Close(ctx context.Context) error {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return nil
+	}
+	l.closed = true
+	l.mu.Unlock()
+
+	l.cancel()
+	close(l.done)
+	select {
+	case <-l.stopped:
+	case <-ctx.Done():
+		return fmt.Errorf("close: %w", ctx.Err())
+	}
+	return l.send(ctx)
+}
+
+// send 取出当前缓冲的日志并上报
+func (l *RemoteLogger) send(ctx context.Context) error {
+	// 等待其他正在进行的上报，接口无响应时不会无限期阻塞
+	select {
+	case l.sendSem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-l.sendSem }()
+
+	l.mu.Lock()
+	batch := l.pending
+	l.pending = nil
+	l.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	maxRetries := l.config.MaxRetries
+	switch l.breaker.state() {
+	case BreakerOpen:
+		return l.fallback(batch)
+	case BreakerHalfOpen:
+		// 试探上报不重试，避免对仍在故障的接口施加压力
+		maxRetries = 0
+	}
+
+	body, gzipped := encodeBatch(batch), false
+	if l.config.Compress && len(body) >= l.config.CompressMinBytes {
+		if compressed, err := gzipBody(body); err == nil {
+			body, gzipped = compressed, true
+		}
+	}
+	err := l.postWithRetry(ctx, body, gzipped, maxRetries)
+	l.setResult(err)
+	// ctx 取消（如 Close 中止重试）不是接口故障，不计入熔断
+	if ctx.Err() == nil && l.breaker.record(err) {
+		l.config.OnError(fmt.Errorf("%w for %s: %v", ErrBreakerOpen, l.config.BreakerCooldown, err))
+	}
+	return err
+}
+
+// fallback 熔断期间将日志逐行写入 Fallback
+func (l *RemoteLogger) fallback(batch [][]byte) error {
+	for _, item := range batch {
+		if _, err := l.config.Fallback.Write(append(item, '\n')); err != nil {
+			return fmt.Errorf("%w: fallback: %v", ErrBreakerOpen, err)
+		}
+	}
+	return ErrBreakerOpen
+}
+
+// setResult 记录最近一次上报的结果
+func (l *RemoteLogger) setResult(err error) {
+	l.mu.Lock()
+	l.failing = err != nil
+	if err != nil {
+		l.lastErr = err
+	}
+	l.mu.Unlock()
+}
+
+// Healthy 最近一次上报成功、未熔断且缓冲的日志不超过 10 个批次时返回 true
+func (l *RemoteLogger) Healthy() bool {
+	if l.breaker.state() != BreakerClosed {
+		return false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return !l.failing && len(l.pending) < backlogBatches*l.config.BatchSize
+}
+
+// LastError 返回最近一次上报失败的错误，从未失败时返回 nil
+// 熔断中（含半开）返回包装了 ErrBreakerOpen 和最近一次失败的错误
+func (l *RemoteLogger) LastError() error {
+	state := l.breaker.state()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if state != BreakerClosed {
+		return fmt.Errorf("%w (%s, last error: %w)", ErrBreakerOpen, state, l.lastErr)
+	}
+	return l.lastErr
+}
+
+// BreakerState 返回熔断器的当前状态，未开启熔断时总是 BreakerClosed
+func (l *RemoteLogger) BreakerState() BreakerState {
+	return l.breaker.state()
+}
+
+// gzipBody 以 gzip 压缩请求体
+func gzipBody(body []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(body); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// postWithRetry 上报一批日志，失败时按退避策略最多重试 maxRetries 次，ctx 结束时立即中止
+func (l *RemoteLogger) postWithRetry(ctx context.Context, body []byte, gzipped bool, maxRetries int) error {
+	for attempt := 0; ; attempt++ {
+		err := l.post(ctx, body, gzipped)
+		if err == nil {
+			return nil
+		}
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && !statusErr.retryable() {
+			return err
+		}
+		if attempt >= maxRetries {
+			return err
+		}
+
+		timer := time.NewTimer(l.config.Backoff(attempt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("retry aborted after %d attempts: %w (last error: %v)", attempt+1, ctx.Err(), err)
+		}
+	}
+}
+
+// post 发送一次上报请求，gzipped 表示 body 已经过 gzip 压缩
+func (l *RemoteLogger) post(ctx context.Context, body []byte, gzipped bool) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if gzipped {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	for k, v := range l.config.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// 读完响应体以便复用连接
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &StatusError{StatusCode: resp.StatusCode}
+	}
+	return nil
+}
+
+// encodeBatch 将多条 JSON 日志拼接为 JSON 数组
+func encodeBatch(batch [][]byte) []byte {
+	size := 2
+	for _, item := range batch {
+		size += len(item) + 1
+	}
+	buf := make([]byte, 0, size)
+	buf = append(buf, '[')
+	for i, item := range batch {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, item...)
+	}
+	return append(buf, ']')
+}
+
+// enqueue 放入缓冲区，达到批次大小时通知后台上报；缓冲区已满时丢弃
+func (l *RemoteLogger) enqueue(item []byte) {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		l.config.OnError(ErrClosed)
+		return
+	}
+	if len(l.pending) >= l.config.MaxPending {
+		atomic.AddUint64(&l.dropped, 1)
+		report := !l.overflow
+		l.overflow = true
+		l.mu.Unlock()
+		if report {
+			l.config.OnError(ErrBufferFull)
+		}
+		return
+	}
+	l.overflow = false
+	l.pending = append(l.pending, item)
+	full := len(l.pending) >= l.config.BatchSize
+	l.mu.Unlock()
+
+	if full {
+		select {
+		case l.trigger <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// handle 将日志编码为 JSON 并放入缓冲区
+func (l *RemoteLogger) handle(ctx context.Context, e zllog.Entry) {
+	if l.config.ServiceName != "" {
+		e.Service = l.config.ServiceName
+	}
+	l.enqueue(zllog.EncodeJSON(e))
+}
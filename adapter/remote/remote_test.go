@@ -0,0 +1,439 @@
+package remote
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zlxdbj/zllog"
+)
+
+// testServer 记录收到的请求，按 statuses 依次返回状态码（用完后返回最后一个）
+type testServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+	statuses []int
+	hits     int32
+}
+
+func newTestServer(t *testing.T, statuses ...int) *testServer {
+	t.Helper()
+
+	s := &testServer{statuses: statuses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		n := atomic.AddInt32(&s.hits, 1)
+
+		s.mu.Lock()
+		s.requests = append(s.requests, r)
+		s.bodies = append(s.bodies, body)
+		s.mu.Unlock()
+
+		status := http.StatusOK
+		if len(s.statuses) > 0 {
+			i := int(n) - 1
+			if i >= len(s.statuses) {
+				i = len(s.statuses) - 1
+			}
+			status = s.statuses[i]
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// TestRemoteLoggerFlush 测试 Flush 以 JSON 数组上报缓冲的日志
+func TestRemoteLoggerFlush(t *testing.T) {
+	server := newTestServer(t)
+	logger := NewRemoteLogger(Config{
+		URL:           server.URL,
+		Headers:       map[string]string{"Authorization": "Bearer token"},
+		ServiceName:   "svc",
+		FlushInterval: time.Hour,
+	})
+	defer logger.Close(context.Background())
+
+	ctx := context.Background()
+	logger.Info(ctx, "api", "first", zllog.String("path", "/"))
+	logger.ErrorWithCode(ctx, "payment", "charge failed", "PAY_001", errors.New("timeout"))
+
+	if err := logger.Flush(ctx); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+	if len(server.bodies) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(server.bodies))
+	}
+	if got := server.requests[0].Header.Get("Authorization"); got != "Bearer token" {
+		t.Errorf("expected custom header, got %q", got)
+	}
+
+	var entries []map[string]interface{}
+	if err := json.Unmarshal(server.bodies[0], &entries); err != nil {
+		t.Fatalf("invalid body: %v", err)
+	}
+	if len(entries) != 2 || entries[0]["message"] != "first" || entries[1]["error_code"] != "PAY_001" {
+		t.Errorf("unexpected entries: %v", entries)
+	}
+	if entries[0]["service"] != "svc" {
+		t.Errorf("expected service svc, got %v", entries[0]["service"])
+	}
+}
+
+// TestRemoteLoggerRetry 测试失败后按退避策略重试直到成功
+func TestRemoteLoggerRetry(t *testing.T) {
+	server := newTestServer(t, 500, 503, 200)
+	logger := NewRemoteLogger(Config{
+		URL:           server.URL,
+		FlushInterval: time.Hour,
+		MaxRetries:    3,
+		Backoff:       ConstantBackoff(time.Millisecond),
+	})
+	defer logger.Close(context.Background())
+
+	logger.Info(context.Background(), "api", "retry me")
+	if err := logger.Flush(context.Background()); err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if n := atomic.LoadInt32(&server.hits); n != 3 {
+		t.Errorf("expected 3 attempts, got %d", n)
+	}
+}
+
+// TestRemoteLoggerNoRetryOnClientError 测试 4xx（429 除外）不重试
+func TestRemoteLoggerNoRetryOnClientError(t *testing.T) {
+	server := newTestServer(t, http.StatusBadRequest)
+	logger := NewRemoteLogger(Config{
+		URL:           server.URL,
+		FlushInterval: time.Hour,
+		MaxRetries:    3,
+		Backoff:       ConstantBackoff(time.Millisecond),
+	})
+	defer logger.Close(context.Background())
+
+	logger.Info(context.Background(), "api", "bad request")
+	var statusErr *StatusError
+	if err := logger.Flush(context.Background()); !errors.As(err, &statusErr) || statusErr.StatusCode != 400 {
+		t.Fatalf("expected status 400 error, got %v", err)
+	}
+	if n := atomic.LoadInt32(&server.hits); n != 1 {
+		t.Errorf("expected 1 attempt, got %d", n)
+	}
+}
+
+// TestRemoteLoggerRetryCanceled 测试 ctx 取消时中止退避等待
+func TestRemoteLoggerRetryCanceled(t *testing.T) {
+	server := newTestServer(t, 500)
+	logger := NewRemoteLogger(Config{
+		URL:           server.URL,
+		FlushInterval: time.Hour,
+		MaxRetries:    5,
+		Backoff:       ConstantBackoff(time.Hour),
+	})
+	defer logger.Close(context.Background())
+
+	logger.Info(context.Background(), "api", "never delivered")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	err := logger.Flush(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("retry was not aborted promptly: %v", elapsed)
+	}
+}
+
+// TestRemoteLoggerCloseAbortsRetry 测试 Close 中止后台正在进行的重试
+func TestRemoteLoggerCloseAbortsRetry(t *testing.T) {
+	server := newTestServer(t, 500)
+	errs := make(chan error, 10)
+	logger := NewRemoteLogger(Config{
+		URL:           server.URL,
+		BatchSize:     1,
+		FlushInterval: time.Hour,
+		MaxRetries:    5,
+		Backoff:       ConstantBackoff(time.Hour),
+		OnError:       func(err error) { errs <- err },
+	})
+
+	logger.Info(context.Background(), "api", "background")
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&server.hits) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	start := time.Now()
+	if err := logger.Close(context.Background()); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("close waited for backoff: %v", elapsed)
+	}
+	select {
+	case err := <-errs:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	default:
+		t.Error("expected the aborted batch to be reported")
+	}
+}
+
+// TestExponentialBackoff 测试退避时间在 [基准/2, 基准] 之间且不超过上限
+func TestExponentialBackoff(t *testing.T) {
+	base, max := 100*time.Millisecond, time.Second
+	backoff := ExponentialBackoff(base, max)
+
+	for attempt := 0; attempt < 10; attempt++ {
+		want := base << uint(attempt)
+		if want > max {
+			want = max
+		}
+		for i := 0; i < 100; i++ {
+			if d := backoff(attempt); d < want/2 || d > want {
+				t.Fatalf("attempt %d: backoff %v out of [%v, %v]", attempt, d, want/2, want)
+			}
+		}
+	}
+}
+
+// TestRemoteLoggerCompress 测试 Compress 时请求体为 gzip 且解压后为原始 JSON
+func TestRemoteLoggerCompress(t *testing.T) {
+	server := newTestServer(t)
+	logger := NewRemoteLogger(Config{URL: server.URL, FlushInterval: time.Hour, Compress: true})
+	defer logger.Close(context.Background())
+
+	logger.Info(context.Background(), "api", "compressed", zllog.String("k", "v"))
+	if err := logger.Flush(context.Background()); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+
+	if got := server.requests[0].Header.Get("Content-Encoding"); got != "gzip" {
+		t.Errorf("expected Content-Encoding gzip, got %q", got)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(server.bodies[0]))
+	if err != nil {
+		t.Fatalf("body is not valid gzip: %v", err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("decompress failed: %v", err)
+	}
+
+	var entries []map[string]interface{}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatalf("decompressed body is not JSON: %v", err)
+	}
+	if len(entries) != 1 || entries[0]["message"] != "compressed" {
+		t.Errorf("unexpected entries: %v", entries)
+	}
+}
+
+// TestRemoteLoggerCompressMinBytes 测试请求体小于 CompressMinBytes 时不压缩，达到后压缩
+func TestRemoteLoggerCompressMinBytes(t *testing.T) {
+	server := newTestServer(t)
+	logger := NewRemoteLogger(Config{URL: server.URL, FlushInterval: time.Hour, Compress: true, CompressMinBytes: 1024})
+	defer logger.Close(context.Background())
+
+	ctx := context.Background()
+	logger.Info(ctx, "api", "small batch")
+	if err := logger.Flush(ctx); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+	logger.Info(ctx, "api", "large batch", zllog.String("payload", strings.Repeat("x", 2048)))
+	if err := logger.Flush(ctx); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+
+	if got := server.requests[0].Header.Get("Content-Encoding"); got != "" {
+		t.Errorf("small batch should be sent uncompressed, got Content-Encoding %q", got)
+	}
+	if !json.Valid(server.bodies[0]) {
+		t.Errorf("small batch body should be plain JSON: %q", server.bodies[0])
+	}
+	if got := server.requests[1].Header.Get("Content-Encoding"); got != "gzip" {
+		t.Errorf("large batch should be compressed, got Content-Encoding %q", got)
+	}
+	if _, err := gzip.NewReader(bytes.NewReader(server.bodies[1])); err != nil {
+		t.Errorf("large batch body is not valid gzip: %v", err)
+	}
+}
+
+// TestRemoteLoggerCloseDeadline 测试上报接口一直无响应时 Close 在 ctx 到期后返回
+func TestRemoteLoggerCloseDeadline(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	// 先放行挂起的请求，再关闭服务端
+	defer server.Close()
+	defer close(release)
+
+	logger := NewRemoteLogger(Config{
+		URL:           server.URL,
+		FlushInterval: time.Hour,
+		Timeout:       time.Minute,
+		MaxRetries:    5,
+		OnError:       func(error) {},
+	})
+
+	ctx := context.Background()
+	logger.Info(ctx, "api", "stuck")
+	// 另一个 goroutine 的 Flush 挂在无响应的接口上，占住上报
+	go logger.Flush(ctx)
+	logger.Info(ctx, "api", "pending")
+	time.Sleep(50 * time.Millisecond)
+
+	deadline, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := logger.Close(deadline)
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("Close took %v, expected to return near the deadline", elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+// TestRemoteLoggerFatalFlushTimeout 测试上报接口无响应时 Fatal 前的上报在超时后返回并报告错误
+func TestRemoteLoggerFatalFlushTimeout(t *testing.T) {
+	defer func(timeout time.Duration) { fatalFlushTimeout = timeout }(fatalFlushTimeout)
+	fatalFlushTimeout = 50 * time.Millisecond
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	errc := make(chan error, 1)
+	logger := NewRemoteLogger(Config{
+		URL:           server.URL,
+		FlushInterval: time.Hour,
+		Timeout:       time.Minute,
+		OnError:       func(err error) { errc <- err },
+	})
+	defer logger.Close(context.Background())
+
+	logger.Info(context.Background(), "api", "before fatal")
+	start := time.Now()
+	logger.EntryLogger.OnFatal()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("flush before exit took %v, expected to return near the timeout", elapsed)
+	}
+	if err := <-errc; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+// TestRemoteLoggerMaxPending 测试上报阻塞时缓冲区超过 MaxPending 的日志被丢弃并计数，ErrBufferFull 只报告一次
+func TestRemoteLoggerMaxPending(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	var (
+		mu   sync.Mutex
+		full int
+	)
+	logger := NewRemoteLogger(Config{
+		URL:           server.URL,
+		BatchSize:     10,
+		MaxPending:    10,
+		FlushInterval: time.Hour,
+		Timeout:       time.Minute,
+		OnError: func(err error) {
+			if errors.Is(err, ErrBufferFull) {
+				mu.Lock()
+				full++
+				mu.Unlock()
+			}
+		},
+	})
+
+	ctx := context.Background()
+	logger.Info(ctx, "api", "stuck")
+	// 另一个 goroutine 的 Flush 挂在无响应的接口上，之后的日志只能留在缓冲区
+	go logger.Flush(ctx)
+	time.Sleep(50 * time.Millisecond)
+	for i := 0; i < 15; i++ {
+		logger.Infof(ctx, "api", "line %d", i)
+	}
+
+	if got := logger.Dropped(); got != 5 {
+		t.Errorf("expected 5 dropped logs, got %d", got)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if full != 1 {
+		t.Errorf("expected a single ErrBufferFull, got %d", full)
+	}
+
+	deadline, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	logger.Close(deadline)
+}
+
+// TestRemoteLoggerHealth 测试上报失败时 Healthy 返回 false，恢复后重新变为健康
+func TestRemoteLoggerHealth(t *testing.T) {
+	server := newTestServer(t, http.StatusInternalServerError, http.StatusOK)
+	logger := NewRemoteLogger(Config{URL: server.URL, FlushInterval: time.Hour, OnError: func(error) {}})
+	defer logger.Close(context.Background())
+
+	var _ zllog.HealthChecker = logger
+	if !logger.Healthy() || logger.LastError() != nil {
+		t.Fatalf("expected healthy logger, got %v", logger.LastError())
+	}
+
+	ctx := context.Background()
+	logger.Info(ctx, "api", "first")
+	if err := logger.Flush(ctx); err == nil {
+		t.Fatal("expected flush to fail")
+	}
+	if logger.Healthy() {
+		t.Error("expected unhealthy after failed send")
+	}
+	var statusErr *StatusError
+	if !errors.As(logger.LastError(), &statusErr) || statusErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("expected 500 status error, got %v", logger.LastError())
+	}
+
+	logger.Info(ctx, "api", "second")
+	if err := logger.Flush(ctx); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+	if !logger.Healthy() {
+		t.Error("expected healthy after successful send")
+	}
+}
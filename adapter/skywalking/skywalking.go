@@ -0,0 +1,111 @@
+package skywalking
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+
+	"github.com/zlxdbj/zllog"
+)
+
+// ============================================================================
+// SkyWalking trace_id 提供者
+// ============================================================================
+
+// noTraceID SkyWalking 在没有 trace 时返回的占位值
+const noTraceID = "N/A"
+
+// TraceIDKey 默认读取 SkyWalking trace_id 的 context 键，值为 string
+// 自行解析 sw8 请求头的服务（见 ParseSW8）通过 ContextWithTraceID 写入
+type TraceIDKey struct{}
+
+// ContextWithTraceID 将 SkyWalking trace_id 存入 context（键为 TraceIDKey{}）
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, TraceIDKey{}, traceID)
+}
+
+// FromContext 从 TraceIDKey{} 读取 trace_id，是 TraceIDProvider 默认的提取函数
+func FromContext(ctx context.Context) string {
+	traceID, _ := ctx.Value(TraceIDKey{}).(string)
+	return traceID
+}
+
+// provider SkyWalking 的 TraceIDProvider 实现
+type provider struct {
+	extract func(ctx context.Context) string
+}
+
+// TraceIDProvider 创建 SkyWalking 的 TraceIDProvider
+// extract 为从 context 提取 trace_id 的函数，为 nil 时使用 FromContext：
+//   - go2sky：传入 go2sky.TraceID（读取 go2sky 放入 context 的 span）
+//   - skywalking-go agent：trace_id 保存在 goroutine 上下文中，传入
+//     func(context.Context) string { return trace.GetTraceID() }（toolkit/trace）
+// 返回 "N/A"（没有 trace）时视为空
+//
+// SkyWalking 的 trace_id 不是 32 位十六进制格式，zllog 会将其哈希为合法的 trace_id
+// （StrictTraceID 时丢弃），原始值可通过 Register 以 sw_trace_id 字段输出，便于在 SkyWalking UI 中检索
+func TraceIDProvider(extract func(ctx context.Context) string) zllog.TraceIDProvider {
+	if extract == nil {
+		extract = FromContext
+	}
+	return provider{extract: extract}
+}
+
+// GetTraceID 返回 SkyWalking trace_id，没有时返回空字符串
+func (p provider) GetTraceID(ctx context.Context) string {
+	traceID := p.extract(ctx)
+	if traceID == noTraceID {
+		return ""
+	}
+	return traceID
+}
+
+// Name 返回追踪系统的名称
+func (p provider) Name() string {
+	return "skywalking"
+}
+
+// rawTraceIDFields 输出原始的 SkyWalking trace_id（sw_trace_id 字段）
+func (p provider) rawTraceIDFields(ctx context.Context) []zllog.Field {
+	if traceID := p.GetTraceID(ctx); traceID != "" {
+		return []zllog.Field{zllog.String("sw_trace_id", traceID)}
+	}
+	return nil
+}
+
+// Register 注册 SkyWalking 的 TraceIDProvider，并为每条日志附加原始的 sw_trace_id 字段
+// 返回的函数用于注销（恢复之前的 TraceIDProvider 并停止附加 sw_trace_id）
+//
+// 用法示例：
+//   import zlsw "github.com/zlxdbj/zllog/adapter/skywalking"
+//
+//   zlsw.Register(go2sky.TraceID)
+func Register(extract func(ctx context.Context) string) (unregister func()) {
+	p := TraceIDProvider(extract).(provider)
+	previous := zllog.GetTraceIDProvider()
+	zllog.RegisterTraceIDProvider(p)
+	removeEnricher := zllog.RegisterContextEnricher(p.rawTraceIDFields)
+	return func() {
+		removeEnricher()
+		zllog.RegisterTraceIDProvider(previous)
+	}
+}
+
+// ParseSW8 从 sw8 请求头中解析 trace_id
+// sw8 格式：{sample}-{base64(traceId)}-{base64(segmentId)}-{spanId}-{base64(service)}-{base64(instance)}-{base64(endpoint)}-{base64(target)}
+//
+// 用法示例（HTTP 中间件）：
+//   if traceID, ok := zlsw.ParseSW8(r.Header.Get("sw8")); ok {
+//       r = r.WithContext(zlsw.ContextWithTraceID(r.Context(), traceID))
+//   }
+func ParseSW8(header string) (traceID string, ok bool) {
+	parts := strings.Split(header, "-")
+	if len(parts) != 8 {
+		return "", false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil || len(decoded) == 0 {
+		return "", false
+	}
+	return string(decoded), true
+}
@@ -0,0 +1,53 @@
+package skywalking
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/zlxdbj/zllog"
+)
+
+const swTraceID = "a1b2c3d4e5f6.1.16700000000000001"
+
+// TestRegister 测试从 context 中提取 SkyWalking trace_id
+func TestRegister(t *testing.T) {
+	unregister := Register(nil)
+	defer unregister()
+
+	var entries []zllog.Entry
+	defer zllog.SwapLogger(zllog.NewEntryLogger(func(ctx context.Context, e zllog.Entry) {
+		entries = append(entries, e)
+	}))()
+
+	ctx := ContextWithTraceID(context.Background(), swTraceID)
+	zllog.Info(ctx, "api", "traced")
+	zllog.Info(ContextWithTraceID(context.Background(), "N/A"), "api", "untraced")
+
+	if name := zllog.GetTraceIDProvider().Name(); name != "skywalking" {
+		t.Errorf("expected skywalking provider, got %s", name)
+	}
+	if !zllog.IsValidTraceID(entries[0].TraceID) || entries[0].TraceID != zllog.GetOrCreateTraceID(ctx) {
+		t.Errorf("expected stable normalized trace_id, got %s", entries[0].TraceID)
+	}
+	if fields := zllog.FieldsToMap(entries[0].Fields); fields["sw_trace_id"] != swTraceID {
+		t.Errorf("expected raw sw_trace_id, got %v", fields)
+	}
+	if _, ok := zllog.FieldsToMap(entries[1].Fields)["sw_trace_id"]; ok {
+		t.Errorf("N/A should be treated as no trace: %v", entries[1].Fields)
+	}
+}
+
+// TestParseSW8 测试解析 sw8 请求头
+func TestParseSW8(t *testing.T) {
+	enc := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+	header := strings.Join([]string{"1", enc(swTraceID), enc("segment"), "3", enc("svc"), enc("inst"), enc("/api"), enc("host:80")}, "-")
+
+	if traceID, ok := ParseSW8(header); !ok || traceID != swTraceID {
+		t.Errorf("ParseSW8 = %q, %v", traceID, ok)
+	}
+	if _, ok := ParseSW8("1-not-enough"); ok {
+		t.Error("expected malformed header to fail")
+	}
+}
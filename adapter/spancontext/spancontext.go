@@ -0,0 +1,78 @@
+package spancontext
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zlxdbj/zllog"
+)
+
+// ============================================================================
+// 通用 span context trace_id 提供者 - 从 context 的 span 中提取 trace_id 和 span_id
+// ============================================================================
+
+// SpanContext span context 的方法约束
+// 本包不依赖任何追踪库，只要求调用方传入的 SpanContextFromContext 函数返回满足此约束的值，
+// 例如 go.opentelemetry.io/otel/trace.SpanContext：TraceID()/SpanID() 返回的类型实现了 String()，
+// 分别输出 32 位和 16 位十六进制
+type SpanContext[T, S fmt.Stringer] interface {
+	IsValid() bool
+	TraceID() T
+	SpanID() S
+}
+
+// provider 基于 SpanContextFromContext 函数的 TraceIDProvider 实现
+type provider[C SpanContext[T, S], T, S fmt.Stringer] struct {
+	fromContext func(context.Context) C
+}
+
+// GetTraceID 返回 span 的 trace_id，context 中没有有效的 span 时返回空字符串
+func (p provider[C, T, S]) GetTraceID(ctx context.Context) string {
+	sc := p.fromContext(ctx)
+	if !sc.IsValid() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
+
+// Name 返回追踪系统的名称
+func (p provider[C, T, S]) Name() string {
+	return "spancontext"
+}
+
+// spanFields 返回 span_id 字段，context 中没有有效的 span 时返回 nil
+func (p provider[C, T, S]) spanFields(ctx context.Context) []zllog.Field {
+	sc := p.fromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return []zllog.Field{zllog.String("span_id", sc.SpanID().String())}
+}
+
+// TraceIDProvider 创建从 span context 中提取 trace_id 的 TraceIDProvider
+// 使用 OpenTelemetry 时 fromContext 传入 trace.SpanContextFromContext 即可
+func TraceIDProvider[C SpanContext[T, S], T, S fmt.Stringer](fromContext func(context.Context) C) zllog.TraceIDProvider {
+	return provider[C, T, S]{fromContext: fromContext}
+}
+
+// Register 注册基于 span context 的 TraceIDProvider，并为每条日志附加当前 span 的 span_id 字段
+// 返回的函数用于注销（恢复之前的 TraceIDProvider 并停止附加 span_id）
+//
+// 用法示例（OpenTelemetry，完整示例见 _examples/otel_tracing）：
+//   import (
+//       "go.opentelemetry.io/otel/trace"
+//       "github.com/zlxdbj/zllog/adapter/spancontext"
+//   )
+//
+//   unregister := spancontext.Register(trace.SpanContextFromContext)
+//   defer unregister()
+func Register[C SpanContext[T, S], T, S fmt.Stringer](fromContext func(context.Context) C) (unregister func()) {
+	p := provider[C, T, S]{fromContext: fromContext}
+	previous := zllog.GetTraceIDProvider()
+	zllog.RegisterTraceIDProvider(p)
+	removeEnricher := zllog.RegisterContextEnricher(p.spanFields)
+	return func() {
+		removeEnricher()
+		zllog.RegisterTraceIDProvider(previous)
+	}
+}
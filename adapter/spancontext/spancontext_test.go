@@ -0,0 +1,69 @@
+package spancontext
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/zlxdbj/zllog"
+)
+
+// 模拟 go.opentelemetry.io/otel/trace 的 TraceID、SpanID、SpanContext 和 SpanContextFromContext
+type traceID [16]byte
+
+func (t traceID) String() string { return hex.EncodeToString(t[:]) }
+
+type spanID [8]byte
+
+func (s spanID) String() string { return hex.EncodeToString(s[:]) }
+
+type spanContext struct {
+	traceID traceID
+	spanID  spanID
+	sampled bool
+}
+
+func (sc spanContext) IsValid() bool    { return sc.traceID != traceID{} && sc.spanID != spanID{} }
+func (sc spanContext) IsSampled() bool  { return sc.sampled }
+func (sc spanContext) TraceID() traceID { return sc.traceID }
+func (sc spanContext) SpanID() spanID   { return sc.spanID }
+
+type spanKey struct{}
+
+func spanContextFromContext(ctx context.Context) spanContext {
+	sc, _ := ctx.Value(spanKey{}).(spanContext)
+	return sc
+}
+
+// TestRegister 测试从带有已采样 span 的 context 中提取 trace_id 和 span_id
+func TestRegister(t *testing.T) {
+	unregister := Register(spanContextFromContext)
+	defer unregister()
+
+	var entries []zllog.Entry
+	defer zllog.SwapLogger(zllog.NewEntryLogger(func(ctx context.Context, e zllog.Entry) {
+		entries = append(entries, e)
+	}))()
+
+	sc := spanContext{
+		traceID: traceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		spanID:  spanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		sampled: true,
+	}
+	ctx := context.WithValue(context.Background(), spanKey{}, sc)
+	zllog.Info(ctx, "api", "with span")
+	zllog.Info(context.Background(), "api", "without span")
+
+	if got := zllog.GetTraceIDProvider().Name(); got != "spancontext" {
+		t.Errorf("expected spancontext provider, got %s", got)
+	}
+	if entries[0].TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("unexpected trace_id: %s", entries[0].TraceID)
+	}
+	if fields := zllog.FieldsToMap(entries[0].Fields); fields["span_id"] != "00f067aa0ba902b7" {
+		t.Errorf("unexpected span_id: %v", fields)
+	}
+	if _, ok := zllog.FieldsToMap(entries[1].Fields)["span_id"]; ok || entries[1].TraceID == "" {
+		t.Errorf("expected generated trace_id and no span_id without span: %+v", entries[1])
+	}
+}
@@ -0,0 +1,285 @@
+package zllog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// ============================================================================
+// 历史日志归档（将轮转后的文件移动到独立的归档目录）
+// ============================================================================
+
+const (
+	// backupTimeFormat lumberjack 历史文件名中的时间格式
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+	// compressSuffix lumberjack 压缩文件后缀
+	compressSuffix = ".gz"
+	// archiveScanInterval 后台扫描历史文件的间隔（按大小自动轮转的历史文件最多延迟这么久才被归档）
+	archiveScanInterval = time.Minute
+)
+
+// archiveWriter 包装 lumberjack.Logger，在轮转后把历史文件移动到归档目录
+//
+// lumberjack 没有提供轮转回调，且压缩是在后台 goroutine 中异步完成的，
+// 因此这里采用"显式轮转立即触发 + 定时扫描兜底"的方式搬运历史文件：
+//   - Rotate 后立即归档；lumberjack 在 Write 中按大小自动轮转的文件由定时扫描搬运，最多延迟 archiveScanInterval
+//   - 开启压缩时只搬运已经压缩完成的 .gz 文件（对应的未压缩文件已被删除）
+//   - 未开启压缩时直接搬运带时间戳的历史文件
+//   - 归档目录与日志目录不在同一文件系统时，使用复制 + 删除代替 rename
+//
+// 由于历史文件被移出了日志目录，lumberjack 不会再清理它们，
+// 归档目录的 MaxBackups/MaxAge 清理由 archiveWriter 负责。
+type archiveWriter struct {
+	*lumberjack.Logger
+
+	archiveDir string
+	dirMode    os.FileMode // 归档目录权限，与 LogConfig.DirMode 相同
+
+	mu      sync.Mutex
+	lastErr string // 上一次归档失败的错误，相同的错误只输出一次
+	trigger chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+// newArchiveWriter 创建归档 writer 并启动后台搬运 goroutine
+// 归档目录按 dirMode 立即创建，创建失败时在后台归档时重试并输出到 stderr
+func newArchiveWriter(logger *lumberjack.Logger, archiveDir string, dirMode os.FileMode) *archiveWriter {
+	w := &archiveWriter{
+		Logger:     logger,
+		archiveDir: archiveDir,
+		dirMode:    dirMode,
+		trigger:    make(chan struct{}, 1),
+		done:       make(chan struct{}),
+	}
+	makeLogDir(archiveDir, dirMode)
+	go w.run()
+	return w
+}
+
+// Rotate 轮转日志文件并立即触发一次归档
+func (w *archiveWriter) Rotate() error {
+	if err := w.Logger.Rotate(); err != nil {
+		return err
+	}
+	w.notify()
+	return nil
+}
+
+// Close 关闭日志文件并停止后台归档
+func (w *archiveWriter) Close() error {
+	w.once.Do(func() {
+		close(w.done)
+	})
+	return w.Logger.Close()
+}
+
+// notify 非阻塞地通知后台 goroutine 执行归档
+func (w *archiveWriter) notify() {
+	select {
+	case w.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// run 后台归档循环
+func (w *archiveWriter) run() {
+	ticker := time.NewTicker(archiveScanInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-w.trigger:
+		case <-ticker.C:
+		}
+		w.report(w.archive())
+	}
+}
+
+// report 将归档失败输出到 stderr，连续相同的错误只输出一次，归档恢复后重新计算
+func (w *archiveWriter) report(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err == nil {
+		w.lastErr = ""
+		return
+	}
+	if msg := err.Error(); msg != w.lastErr {
+		w.lastErr = msg
+		fmt.Fprintf(os.Stderr, "zllog: archive to %s: %v\n", w.archiveDir, err)
+	}
+}
+
+// archive 搬运日志目录中已完成轮转的历史文件，并清理过期归档
+func (w *archiveWriter) archive() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := makeLogDir(w.archiveDir, w.dirMode); err != nil {
+		return err
+	}
+
+	backups, err := w.backupFiles(filepath.Dir(w.Filename))
+	if err != nil {
+		return err
+	}
+	for _, name := range backups {
+		src := filepath.Join(filepath.Dir(w.Filename), name)
+		if err := moveFile(src, filepath.Join(w.archiveDir, name)); err != nil {
+			return err
+		}
+	}
+
+	return w.prune()
+}
+
+// backupFiles 列出目录中可以归档的历史文件名
+func (w *archiveWriter) backupFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		name := e.Name()
+		if _, ok := w.backupTime(name); !ok {
+			continue
+		}
+		if w.Compress {
+			// 仅搬运压缩完成的文件：未压缩的原文件仍存在说明压缩还在进行中
+			if !strings.HasSuffix(name, compressSuffix) {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(dir, strings.TrimSuffix(name, compressSuffix))); err == nil {
+				continue
+			}
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// backupTime 从历史文件名中解析轮转时间（格式：app-2006-01-02T15-04-05.000.log[.gz]）
+func (w *archiveWriter) backupTime(name string) (time.Time, bool) {
+	base := filepath.Base(w.Filename)
+	ext := filepath.Ext(base)
+	prefix := strings.TrimSuffix(base, ext) + "-"
+
+	name = strings.TrimSuffix(name, compressSuffix)
+	if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+		return time.Time{}, false
+	}
+	ts := name[len(prefix) : len(name)-len(ext)]
+	t, err := time.Parse(backupTimeFormat, ts)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// prune 按 MaxBackups 和 MaxAge 清理归档目录
+func (w *archiveWriter) prune() error {
+	if w.MaxBackups == 0 && w.MaxAge == 0 {
+		return nil
+	}
+
+	type archived struct {
+		name string
+		t    time.Time
+	}
+
+	entries, err := os.ReadDir(w.archiveDir)
+	if err != nil {
+		return err
+	}
+	var files []archived
+	for _, e := range entries {
+		if t, ok := w.backupTime(e.Name()); ok && !e.IsDir() {
+			files = append(files, archived{name: e.Name(), t: t})
+		}
+	}
+
+	// 按时间倒序，最新的在前
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].t.After(files[j].t)
+	})
+
+	cutoff := time.Now().Add(-time.Duration(w.MaxAge) * 24 * time.Hour)
+	for i, f := range files {
+		expired := w.MaxAge > 0 && f.t.Before(cutoff)
+		overflow := w.MaxBackups > 0 && i >= w.MaxBackups
+		if expired || overflow {
+			os.Remove(filepath.Join(w.archiveDir, f.name))
+		}
+	}
+	return nil
+}
+
+// moveFile 移动文件，跨文件系统时（rename 返回 EXDEV）回退为复制 + 删除，其他错误直接返回
+func moveFile(src, dst string) error {
+	err := os.Rename(src, dst)
+	if err == nil || !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+	if err := copyFile(src, dst); err != nil {
+		return err
+	}
+	return os.Remove(src)
+}
+
+// copyFile 复制文件内容并保留文件权限
+func copyFile(src, dst string) (err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := out.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(dst)
+		}
+	}()
+
+	if _, err = io.Copy(out, in); err != nil {
+		return err
+	}
+	return out.Sync()
+}
+
+// resolveArchiveDir 解析归档目录：相对路径相对于日志目录
+func resolveArchiveDir(config *LogConfig) string {
+	if config.ArchiveDir == "" || filepath.IsAbs(config.ArchiveDir) {
+		return config.ArchiveDir
+	}
+	return filepath.Join(config.LogDir, config.ArchiveDir)
+}
@@ -0,0 +1,89 @@
+package zllog
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// TestArchiveWriterMovesRotatedFile 测试轮转后的压缩文件被移动到归档目录
+func TestArchiveWriterMovesRotatedFile(t *testing.T) {
+	logDir := t.TempDir()
+	config := &LogConfig{LogDir: logDir, ArchiveDir: "archive", Compress: true}
+
+	w := newArchiveWriter(&lumberjack.Logger{
+		Filename: filepath.Join(logDir, "app.log"),
+		Compress: true,
+	}, resolveArchiveDir(config), 0)
+	defer w.Close()
+
+	if _, err := w.Write([]byte("before rotate\n")); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	if err := w.Rotate(); err != nil {
+		t.Fatalf("rotate failed: %v", err)
+	}
+
+	// 压缩在 lumberjack 后台完成，轮询等待归档结果
+	archiveDir := filepath.Join(logDir, "archive")
+	var archived []string
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		w.archive()
+		archived, _ = filepath.Glob(filepath.Join(archiveDir, "app-*.log.gz"))
+		if len(archived) > 0 {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	if len(archived) != 1 {
+		t.Fatalf("expected 1 archived file, got %v", archived)
+	}
+	if left, _ := filepath.Glob(filepath.Join(logDir, "app-*")); len(left) != 0 {
+		t.Errorf("rotated files left in log dir: %v", left)
+	}
+	if _, err := os.Stat(filepath.Join(logDir, "app.log")); err != nil {
+		t.Errorf("active log file missing: %v", err)
+	}
+}
+
+// TestCopyFile 测试跨文件系统时使用的复制回退
+func TestCopyFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.log")
+	dst := filepath.Join(dir, "dst.log")
+	if err := os.WriteFile(src, []byte("payload"), 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := copyFile(src, dst); err != nil {
+		t.Fatalf("copyFile failed: %v", err)
+	}
+	data, err := os.ReadFile(dst)
+	if err != nil || string(data) != "payload" {
+		t.Errorf("unexpected copy result: %q, %v", data, err)
+	}
+}
+
+// TestMoveFileReturnsRenameError 测试 rename 失败且不是跨文件系统时直接返回错误，不回退为复制
+func TestMoveFileReturnsRenameError(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.log")
+	if err := os.WriteFile(src, []byte("payload"), 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	err := moveFile(src, filepath.Join(dir, "missing", "dst.log"))
+	var linkErr *os.LinkError
+	if !errors.As(err, &linkErr) {
+		t.Fatalf("expected the rename error, got %v", err)
+	}
+	if _, err := os.Stat(src); err != nil {
+		t.Errorf("source should be kept after a failed move: %v", err)
+	}
+}
@@ -0,0 +1,264 @@
+package zllog
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+)
+
+// ============================================================================
+// 异步写入（环形缓冲区 + 后台写入 goroutine）
+// ============================================================================
+
+// OverflowPolicy 异步缓冲区写满时的处理策略
+type OverflowPolicy string
+
+const (
+	// OverflowBlock 阻塞写日志的调用方，直到缓冲区有空位（不丢日志）
+	OverflowBlock OverflowPolicy = "block"
+	// OverflowDropOldest 丢弃缓冲区中最旧的一条日志，为新日志腾出空间
+	OverflowDropOldest OverflowPolicy = "drop_oldest"
+	// OverflowDropNew 丢弃当前要写入的新日志
+	OverflowDropNew OverflowPolicy = "drop_new"
+)
+
+// defaultAsyncBufferSize 默认异步缓冲区大小（日志条数）
+const defaultAsyncBufferSize = 4096
+
+// ErrAsyncBufferFull 异步缓冲区已满，日志被丢弃
+var ErrAsyncBufferFull = errors.New("async log buffer full, entries dropped")
+
+// AsyncConfig 异步写入配置
+type AsyncConfig struct {
+	Enabled        bool           // 是否启用异步写入
+	BufferSize     int            // 缓冲区大小（日志条数，默认 4096）
+	OverflowPolicy OverflowPolicy // 缓冲区满时的策略：block/drop_oldest/drop_new（默认 block）
+}
+
+// asyncEntry 缓冲区中的一条日志
+type asyncEntry struct {
+	level zerolog.Level
+	data  []byte
+}
+
+// AsyncWriter 异步日志 writer
+// 日志先写入固定大小的环形缓冲区，由后台 goroutine 写入底层 writer，
+// 调用方不会被磁盘 IO 阻塞。FATAL/PANIC 级别的日志会同步落盘，避免进程退出时丢失。
+type AsyncWriter struct {
+	out    io.Writer
+	policy OverflowPolicy
+
+	mu       sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	buf      []asyncEntry
+	head     int // 最旧一条日志的位置
+	size     int // 缓冲区中的日志条数
+	writing  bool
+	closed   bool
+
+	done    chan struct{}
+	dropped uint64
+
+	// 健康状态：failing 表示最近一次写入底层 writer 失败，lastErr 为最近一次失败的错误
+	failing bool
+	lastErr error
+}
+
+// NewAsyncWriter 创建异步 writer 并启动后台写入 goroutine
+func NewAsyncWriter(out io.Writer, config AsyncConfig) *AsyncWriter {
+	size := config.BufferSize
+	if size <= 0 {
+		size = defaultAsyncBufferSize
+	}
+	policy := config.OverflowPolicy
+	switch policy {
+	case OverflowBlock, OverflowDropOldest, OverflowDropNew:
+	default:
+		policy = OverflowBlock
+	}
+
+	w := &AsyncWriter{
+		out:    out,
+		policy: policy,
+		buf:    make([]asyncEntry, size),
+		done:   make(chan struct{}),
+	}
+	w.notEmpty = sync.NewCond(&w.mu)
+	w.notFull = sync.NewCond(&w.mu)
+	go w.run()
+	return w
+}
+
+// Write 实现 io.Writer 接口
+func (w *AsyncWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel 实现 zerolog.LevelWriter 接口
+func (w *AsyncWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	// zerolog 会复用 p 的底层数组，必须复制一份再放入缓冲区
+	data := make([]byte, len(p))
+	copy(data, p)
+
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return writeLevel(w.out, level, data)
+	}
+
+	if w.size == len(w.buf) {
+		switch w.policy {
+		case OverflowDropNew:
+			w.lastErr = ErrAsyncBufferFull
+			w.mu.Unlock()
+			atomic.AddUint64(&w.dropped, 1)
+			return len(p), nil
+		case OverflowDropOldest:
+			w.buf[w.head] = asyncEntry{}
+			w.head = (w.head + 1) % len(w.buf)
+			w.size--
+			w.lastErr = ErrAsyncBufferFull
+			atomic.AddUint64(&w.dropped, 1)
+		default:
+			for w.size == len(w.buf) && !w.closed {
+				w.notFull.Wait()
+			}
+			if w.closed {
+				w.mu.Unlock()
+				return writeLevel(w.out, level, data)
+			}
+		}
+	}
+
+	w.buf[(w.head+w.size)%len(w.buf)] = asyncEntry{level: level, data: data}
+	w.size++
+	w.notEmpty.Signal()
+	w.mu.Unlock()
+
+	// FATAL/PANIC 之后进程可能立即退出，等待缓冲区全部落盘
+	if level == zerolog.FatalLevel || level == zerolog.PanicLevel {
+		w.wait()
+	}
+	return len(p), nil
+}
+
+// DroppedCount 返回因缓冲区溢出而丢弃的日志条数
+func (w *AsyncWriter) DroppedCount() uint64 {
+	return atomic.LoadUint64(&w.dropped)
+}
+
+// Len 返回缓冲区中等待写入的日志条数
+func (w *AsyncWriter) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.size
+}
+
+// Flush 等待缓冲区中的日志全部写入底层 writer，ctx 结束时立即返回 ctx 的错误（后台仍会继续写入）
+func (w *AsyncWriter) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		w.wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Healthy 最近一次写入底层 writer 成功且缓冲区未饱和（不足 90%）时返回 true
+func (w *AsyncWriter) Healthy() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return !w.failing && !w.saturated()
+}
+
+// LastError 返回最近一次写入失败或缓冲区溢出丢弃日志的错误，从未失败时返回 nil
+func (w *AsyncWriter) LastError() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.lastErr
+}
+
+// saturated 缓冲区是否饱和（达到容量的 90%），需持有 w.mu
+func (w *AsyncWriter) saturated() bool {
+	return w.size*10 >= len(w.buf)*9
+}
+
+// Close 停止接收新日志，等待缓冲区中的日志全部写入后返回
+// 关闭之后的写入会直接同步写入底层 writer
+func (w *AsyncWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	w.notEmpty.Broadcast()
+	w.notFull.Broadcast()
+	w.mu.Unlock()
+
+	<-w.done
+	if closer, ok := w.out.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// wait 等待缓冲区中的日志全部写入
+func (w *AsyncWriter) wait() {
+	w.mu.Lock()
+	for (w.size > 0 || w.writing) && !w.closed {
+		w.notFull.Wait()
+	}
+	w.mu.Unlock()
+}
+
+// run 后台写入循环
+func (w *AsyncWriter) run() {
+	defer close(w.done)
+
+	for {
+		w.mu.Lock()
+		for w.size == 0 && !w.closed {
+			w.notEmpty.Wait()
+		}
+		if w.size == 0 && w.closed {
+			w.mu.Unlock()
+			return
+		}
+		entry := w.buf[w.head]
+		w.buf[w.head] = asyncEntry{}
+		w.head = (w.head + 1) % len(w.buf)
+		w.size--
+		w.writing = true
+		w.mu.Unlock()
+
+		_, err := writeLevel(w.out, entry.level, entry.data)
+
+		w.mu.Lock()
+		w.failing = err != nil
+		if err != nil {
+			w.lastErr = err
+		}
+		w.writing = false
+		w.notFull.Broadcast()
+		w.mu.Unlock()
+	}
+}
+
+// writeLevel 优先使用 LevelWriter 写入，保留日志级别信息
+func writeLevel(out io.Writer, level zerolog.Level, p []byte) (int, error) {
+	if lw, ok := out.(zerolog.LevelWriter); ok {
+		return lw.WriteLevel(level, p)
+	}
+	return out.Write(p)
+}
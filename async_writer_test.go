@@ -0,0 +1,177 @@
+package zllog
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// gatedWriter 在 gate 关闭前阻塞所有写入，用于模拟慢速磁盘
+type gatedWriter struct {
+	gate  chan struct{}
+	mu    sync.Mutex
+	lines []string
+}
+
+func (g *gatedWriter) Write(p []byte) (int, error) {
+	<-g.gate
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.lines = append(g.lines, string(p))
+	return len(p), nil
+}
+
+func (g *gatedWriter) output() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return strings.Join(g.lines, ",")
+}
+
+// saturate 写入 1 条正在写盘的日志，并填满容量为 2 的缓冲区
+func saturate(t *testing.T, policy OverflowPolicy) (*AsyncWriter, *gatedWriter) {
+	out := &gatedWriter{gate: make(chan struct{})}
+	w := NewAsyncWriter(out, AsyncConfig{Enabled: true, BufferSize: 2, OverflowPolicy: policy})
+
+	w.Write([]byte("1"))
+	deadline := time.Now().Add(time.Second)
+	for w.Len() != 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	w.Write([]byte("2"))
+	w.Write([]byte("3"))
+	if w.Len() != 2 {
+		t.Fatalf("expected full buffer, got %d entries", w.Len())
+	}
+	return w, out
+}
+
+// TestAsyncWriterDropOldest 测试缓冲区满时丢弃最旧的日志
+func TestAsyncWriterDropOldest(t *testing.T) {
+	w, out := saturate(t, OverflowDropOldest)
+	w.Write([]byte("4"))
+
+	close(out.gate)
+	w.Close()
+
+	if got := out.output(); got != "1,3,4" {
+		t.Errorf("unexpected output: %s", got)
+	}
+	if w.DroppedCount() != 1 {
+		t.Errorf("expected 1 dropped, got %d", w.DroppedCount())
+	}
+}
+
+// TestAsyncWriterDropNew 测试缓冲区满时丢弃新日志
+func TestAsyncWriterDropNew(t *testing.T) {
+	w, out := saturate(t, OverflowDropNew)
+	w.Write([]byte("4"))
+
+	close(out.gate)
+	w.Close()
+
+	if got := out.output(); got != "1,2,3" {
+		t.Errorf("unexpected output: %s", got)
+	}
+	if w.DroppedCount() != 1 {
+		t.Errorf("expected 1 dropped, got %d", w.DroppedCount())
+	}
+}
+
+// TestAsyncWriterBlock 测试缓冲区满时阻塞调用方且不丢日志
+func TestAsyncWriterBlock(t *testing.T) {
+	w, out := saturate(t, OverflowBlock)
+
+	written := make(chan struct{})
+	go func() {
+		w.Write([]byte("4"))
+		close(written)
+	}()
+
+	select {
+	case <-written:
+		t.Fatal("write should block while buffer is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(out.gate)
+	<-written
+	w.Close()
+
+	if got := out.output(); got != "1,2,3,4" {
+		t.Errorf("unexpected output: %s", got)
+	}
+	if w.DroppedCount() != 0 {
+		t.Errorf("expected no drops, got %d", w.DroppedCount())
+	}
+}
+
+// failingWriter 在 fail 为 true 时返回写入错误
+type failingWriter struct {
+	mu   sync.Mutex
+	fail bool
+}
+
+func (f *failingWriter) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.fail {
+		return 0, errors.New("disk full")
+	}
+	return len(p), nil
+}
+
+func (f *failingWriter) setFail(fail bool) {
+	f.mu.Lock()
+	f.fail = fail
+	f.mu.Unlock()
+}
+
+// waitDrained 等待缓冲区中的日志全部写入
+func waitDrained(w *AsyncWriter) {
+	w.Write([]byte("x"))
+	w.wait()
+}
+
+// TestAsyncWriterHealth 测试写入失败和缓冲区饱和时 Healthy 返回 false，恢复后重新变为健康
+func TestAsyncWriterHealth(t *testing.T) {
+	out := &failingWriter{}
+	w := NewAsyncWriter(out, AsyncConfig{Enabled: true, BufferSize: 16})
+	defer w.Close()
+
+	waitDrained(w)
+	if !w.Healthy() || w.LastError() != nil {
+		t.Fatalf("expected healthy writer, got %v", w.LastError())
+	}
+
+	out.setFail(true)
+	waitDrained(w)
+	if w.Healthy() {
+		t.Error("expected unhealthy after failed write")
+	}
+	if err := w.LastError(); err == nil || err.Error() != "disk full" {
+		t.Errorf("expected disk full error, got %v", err)
+	}
+
+	out.setFail(false)
+	waitDrained(w)
+	if !w.Healthy() {
+		t.Error("expected healthy after successful write")
+	}
+	if w.LastError() == nil {
+		t.Error("expected LastError to keep the most recent failure")
+	}
+
+	// 缓冲区饱和
+	saturated, gate := saturate(t, OverflowDropNew)
+	if saturated.Healthy() {
+		t.Error("expected unhealthy when buffer is full")
+	}
+	saturated.Write([]byte("4"))
+	if saturated.LastError() != ErrAsyncBufferFull {
+		t.Errorf("expected ErrAsyncBufferFull, got %v", saturated.LastError())
+	}
+	close(gate.gate)
+	saturated.Close()
+}
@@ -0,0 +1,239 @@
+package zllog
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/rs/zerolog"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// ============================================================================
+// 审计日志（等保合规事件，独立文件 + 序号 + 可选 HMAC 链）
+// ============================================================================
+
+// AuditConfig 审计日志配置
+type AuditConfig struct {
+	Enabled  bool   // 是否写入独立的审计日志文件（默认关闭，关闭时审计事件写入普通日志）
+	FileName string // 审计日志文件名（默认 audit.log，位于 LogDir 下）
+	HMACKey  string // HMAC 密钥，不为空时每条审计日志带 prev_mac/mac 链，用于发现删除和篡改
+}
+
+// auditLevel 审计日志的固定级别，不受全局日志级别影响
+const auditLevel = "audit"
+
+// 审计日志的 HMAC 字段
+const (
+	auditPrevMACField = "prev_mac"
+	auditMACField     = "mac"
+)
+
+// globalAuditLogger 初始化时创建的审计日志（未启用时为 nil）
+var globalAuditLogger *AuditLogger
+
+// AuditLogger 审计日志，每条日志带单调递增的 seq，配置密钥时带 HMAC 链
+// mac = HMAC-SHA256(key, 不含 mac 字段的整行 JSON)，其中包含上一条的 mac（prev_mac），
+// 删除、插入或修改任意一行都会导致 VerifyAuditChain 校验失败
+type AuditLogger struct {
+	mu      sync.Mutex
+	out     io.Writer
+	key     []byte
+	seq     uint64
+	prevMAC string
+}
+
+// NewAuditLogger 创建写入 out 的审计日志，key 为空时不计算 HMAC
+func NewAuditLogger(out io.Writer, key []byte) *AuditLogger {
+	return &AuditLogger{out: out, key: key}
+}
+
+// newAuditFileLogger 创建写入审计日志文件的审计日志
+// 文件已存在时从最后一行恢复 seq 和 mac，使链在重启后保持连续
+func newAuditFileLogger(config *LogConfig) *AuditLogger {
+	fileName := config.Audit.FileName
+	if fileName == "" {
+		fileName = "audit.log"
+	}
+	path := filepath.Join(config.LogDir, fileName)
+	prepareLogFile(config, path)
+
+	l := NewAuditLogger(&lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    config.MaxSize,
+		MaxBackups: config.MaxBackups,
+		MaxAge:     config.MaxAge,
+		Compress:   config.Compress,
+	}, []byte(config.Audit.HMACKey))
+	if f, err := os.Open(path); err == nil {
+		l.seq, l.prevMAC = lastAuditState(f)
+		f.Close()
+	}
+	return l
+}
+
+// lastAuditState 读取最后一条审计日志的 seq 和 mac
+func lastAuditState(r io.Reader) (uint64, string) {
+	var last []byte
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+			last = append(last[:0], line...)
+		}
+	}
+
+	var state struct {
+		Seq uint64 `json:"seq"`
+		MAC string `json:"mac"`
+	}
+	if err := json.Unmarshal(last, &state); err != nil {
+		return 0, ""
+	}
+	return state.Seq, state.MAC
+}
+
+// Log 写入一条审计日志
+func (l *AuditLogger) Log(ctx context.Context, action, actor, target, result string, fields ...Field) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+	// NoLevel 不受级别过滤，级别字段固定为 audit
+	event := logger.WithLevel(zerolog.NoLevel).
+		Timestamp().
+		Str(zerolog.LevelFieldName, auditLevel).
+		Uint64("seq", l.seq+1).
+		Str("service", serviceName).
+		Str("trace_id", GetOrCreateTraceID(ctx)).
+		Str("action", action).
+		Str("actor", actor).
+		Str("target", target).
+		Str("result", result)
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		event = event.Str("request_id", requestID)
+	}
+	event = (&ZerologLogger{}).addFields(event, fields...)
+	if len(l.key) > 0 {
+		event = event.Str(auditPrevMACField, l.prevMAC)
+	}
+	event.Send()
+
+	line := bytes.TrimRight(buf.Bytes(), "\n")
+	var mac string
+	if len(l.key) > 0 {
+		mac = computeAuditMAC(l.key, line)
+		line = appendAuditMAC(line, mac)
+	}
+	if _, err := l.out.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	l.seq++
+	l.prevMAC = mac
+	return nil
+}
+
+// computeAuditMAC 计算一行（不含 mac 字段）的 HMAC
+func computeAuditMAC(key, line []byte) string {
+	h := hmac.New(sha256.New, key)
+	h.Write(line)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// appendAuditMAC 将 mac 字段追加到 JSON 对象的末尾
+func appendAuditMAC(line []byte, mac string) []byte {
+	out := make([]byte, 0, len(line)+len(mac)+10)
+	out = append(out, line[:len(line)-1]...)
+	out = append(out, `,"`+auditMACField+`":"`...)
+	out = append(out, mac...)
+	return append(out, `"}`...)
+}
+
+// VerifyAuditChain 校验审计日志的 seq 是否连续、HMAC 链是否完整
+// 返回第一处不一致的错误，全部通过时返回 nil
+func VerifyAuditChain(r io.Reader, key []byte) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var (
+		lineNo  int
+		prevSeq uint64
+		prevMAC string
+		first   = true
+	)
+	for scanner.Scan() {
+		lineNo++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var entry struct {
+			Seq     uint64 `json:"seq"`
+			PrevMAC string `json:"prev_mac"`
+			MAC     string `json:"mac"`
+		}
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return fmt.Errorf("line %d: invalid audit entry: %w", lineNo, err)
+		}
+		// 轮转后的文件从中间开始，第一行的 seq 和 prev_mac 作为起点
+		if !first {
+			if entry.Seq != prevSeq+1 {
+				return fmt.Errorf("line %d: seq %d does not follow %d", lineNo, entry.Seq, prevSeq)
+			}
+			if len(key) > 0 && entry.PrevMAC != prevMAC {
+				return fmt.Errorf("line %d: prev_mac does not match the previous entry", lineNo)
+			}
+		}
+
+		if len(key) > 0 {
+			suffix := []byte(`,"` + auditMACField + `":"` + entry.MAC + `"}`)
+			if entry.MAC == "" || !bytes.HasSuffix(line, suffix) {
+				return fmt.Errorf("line %d: missing mac", lineNo)
+			}
+			body := append(line[:len(line)-len(suffix):len(line)-len(suffix)], '}')
+			if !hmac.Equal([]byte(computeAuditMAC(key, body)), []byte(entry.MAC)) {
+				return fmt.Errorf("line %d: mac mismatch", lineNo)
+			}
+		}
+
+		prevSeq, prevMAC, first = entry.Seq, entry.MAC, false
+	}
+	return scanner.Err()
+}
+
+// Audit 记录一条审计事件（谁 actor 对什么 target 做了什么 action，结果 result）
+// 启用 LogConfig.Audit 时写入独立的审计日志文件（带 seq，配置密钥时带 HMAC 链），
+// 否则以 INFO 级别写入普通日志（module=audit）
+//
+// 用法示例：
+//   zllog.Audit(ctx, "user.delete", adminID, userID, "success", zllog.String("ip", clientIP))
+func Audit(ctx context.Context, action, actor, target, result string, fields ...Field) {
+	if globalAuditLogger == nil {
+		Info(ctx, "audit", action, append([]Field{
+			String("action", action),
+			String("actor", actor),
+			String("target", target),
+			String("result", result),
+		}, fields...)...)
+		return
+	}
+	if err := globalAuditLogger.Log(ctx, action, actor, target, result, fields...); err != nil {
+		Error(ctx, "audit", "failed to write audit log", err, String("action", action))
+	}
+}
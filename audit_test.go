@@ -0,0 +1,112 @@
+package zllog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// TestAuditSeqAndChain 测试审计日志 seq 递增且 HMAC 链可校验
+func TestAuditSeqAndChain(t *testing.T) {
+	var buf bytes.Buffer
+	key := []byte("secret")
+	audit := NewAuditLogger(&buf, key)
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if err := audit.Log(ctx, "user.delete", "admin", "user-1", "success", String("ip", "10.0.0.1")); err != nil {
+			t.Fatalf("Log: %v", err)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(lines))
+	}
+	prevMAC := ""
+	for i, line := range lines {
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &m); err != nil {
+			t.Fatalf("invalid json %q: %v", line, err)
+		}
+		if m["seq"] != float64(i+1) {
+			t.Errorf("line %d: expected seq %d, got %v", i, i+1, m["seq"])
+		}
+		if m["level"] != "audit" || m["action"] != "user.delete" || m["ip"] != "10.0.0.1" {
+			t.Errorf("line %d: unexpected fields %v", i, m)
+		}
+		if m["prev_mac"] != prevMAC {
+			t.Errorf("line %d: expected prev_mac %q, got %v", i, prevMAC, m["prev_mac"])
+		}
+		prevMAC, _ = m["mac"].(string)
+	}
+
+	if err := VerifyAuditChain(strings.NewReader(buf.String()), key); err != nil {
+		t.Errorf("expected valid chain, got %v", err)
+	}
+	if err := VerifyAuditChain(strings.NewReader(buf.String()), []byte("wrong")); err == nil {
+		t.Error("expected mac mismatch with wrong key")
+	}
+
+	// 篡改一行
+	tampered := strings.Replace(buf.String(), `"result":"success"`, `"result":"failure"`, 1)
+	if err := VerifyAuditChain(strings.NewReader(tampered), key); err == nil {
+		t.Error("expected tampered line to fail verification")
+	}
+
+	// 删除中间一行
+	deleted := lines[0] + "\n" + lines[2] + "\n"
+	if err := VerifyAuditChain(strings.NewReader(deleted), key); err == nil {
+		t.Error("expected deleted line to fail verification")
+	}
+}
+
+// TestAuditIgnoresGlobalLevel 测试审计日志不受全局级别过滤
+func TestAuditIgnoresGlobalLevel(t *testing.T) {
+	original := zerolog.GlobalLevel()
+	zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+	defer zerolog.SetGlobalLevel(original)
+
+	var buf bytes.Buffer
+	audit := NewAuditLogger(&buf, nil)
+
+	if err := audit.Log(context.Background(), "login", "alice", "console", "success"); err != nil {
+		t.Fatalf("Log: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"seq":1`) {
+		t.Errorf("expected audit line despite global level, got %q", buf.String())
+	}
+	if strings.Contains(buf.String(), `"mac"`) {
+		t.Errorf("expected no mac without key, got %q", buf.String())
+	}
+}
+
+// TestAuditFileResume 测试重新打开审计文件后 seq 和 HMAC 链保持连续
+func TestAuditFileResume(t *testing.T) {
+	config := &LogConfig{LogDir: t.TempDir(), Audit: AuditConfig{Enabled: true, HMACKey: "secret"}}
+	ctx := context.Background()
+
+	first := newAuditFileLogger(config)
+	first.Log(ctx, "a", "alice", "t", "success")
+	first.Log(ctx, "b", "alice", "t", "success")
+
+	second := newAuditFileLogger(config)
+	second.Log(ctx, "c", "alice", "t", "success")
+
+	data, err := os.ReadFile(filepath.Join(config.LogDir, "audit.log"))
+	if err != nil {
+		t.Fatalf("read audit log: %v", err)
+	}
+	if !strings.Contains(string(data), `"seq":3`) {
+		t.Errorf("expected seq to resume at 3, got %q", data)
+	}
+	if err := VerifyAuditChain(bytes.NewReader(data), []byte("secret")); err != nil {
+		t.Errorf("expected resumed chain to verify, got %v", err)
+	}
+}
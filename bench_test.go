@@ -0,0 +1,119 @@
+package zllog
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// newBenchLogger 创建输出到 io.Discard 的 ZerologLogger
+func newBenchLogger(b *testing.B, config *LogConfig) *ZerologLogger {
+	b.Helper()
+
+	originalLevel := zerolog.GlobalLevel()
+	zerolog.SetGlobalLevel(zerolog.TraceLevel)
+	b.Cleanup(func() {
+		zerolog.SetGlobalLevel(originalLevel)
+	})
+
+	logger := zerolog.New(io.Discard)
+	return newZerologLoggerWithConfig(&logger, config)
+}
+
+// BenchmarkInfoCommonFields 常用类型字段（String/Int/Int64/Bool/Float64）的日志开销
+//
+// 优化前：208 B/op  3 allocs/op（变参切片逃逸 + trace_id 的 hex 字符串）
+// 优化后： 16 B/op  1 allocs/op（仅剩生成 trace_id 时 uuid 的分配）
+func BenchmarkInfoCommonFields(b *testing.B) {
+	logger := newBenchLogger(b, &LogConfig{})
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.Info(ctx, "bench", "request handled",
+			String("user_id", "u-10086"),
+			Int("status", 200),
+			Int64("size", 4096),
+			Bool("cached", true),
+			Float64("ratio", 0.75))
+	}
+}
+
+// BenchmarkInfoDynamicFields 字段值为变量时的日志开销
+// 非常量的 int64、float64、string 装箱为 interface{} 时会产生分配（发生在调用方构造 Field 时），
+// 小整数（0-255）、bool 和常量不会分配
+func BenchmarkInfoDynamicFields(b *testing.B) {
+	logger := newBenchLogger(b, &LogConfig{})
+	ctx := context.Background()
+	userIDs := []string{"u-10086", "u-10087"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.Info(ctx, "bench", "request handled",
+			String("user_id", userIDs[i&1]),
+			Int("status", i&0x7f),
+			Int64("size", int64(i)),
+			Bool("cached", i&1 == 0),
+			Float64("ratio", float64(i)))
+	}
+}
+
+// BenchmarkInfoWithCaller 开启 caller 时的日志开销
+//
+// 优化前：608 B/op  7 allocs/op
+// 优化后：512 B/op  3 allocs/op（caller 直接写入栈上缓冲区，不再经过 fmt.Sprintf）
+func BenchmarkInfoWithCaller(b *testing.B) {
+	logger := newBenchLogger(b, &LogConfig{EnableCaller: true})
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.Info(ctx, "bench", "request handled", String("user_id", "u-10086"))
+	}
+}
+
+// BenchmarkInfoNoFields 不带字段的日志开销
+//
+// 优化前：48 B/op  2 allocs/op
+// 优化后：16 B/op  1 allocs/op
+func BenchmarkInfoNoFields(b *testing.B) {
+	logger := newBenchLogger(b, &LogConfig{})
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.Info(ctx, "bench", "request handled")
+	}
+}
+
+// BenchmarkInfoContextFields 合并 context 字段和显式字段的日志开销
+// pooled 为当前实现（池化切片），merge_fields 为每次调用 MergeFields 的对照组
+//
+// pooled:       16 B/op  1 allocs/op
+// merge_fields: 144 B/op  2 allocs/op（每次分配合并后的新切片）
+func BenchmarkInfoContextFields(b *testing.B) {
+	logger := newBenchLogger(b, &LogConfig{})
+	ctx := WithFields(context.Background(), String("user_id", "u-10086"), String("tenant", "acme"))
+
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			logger.Info(ctx, "bench", "request handled", Int("status", 200), Bool("cached", true))
+		}
+	})
+
+	b.Run("merge_fields", func(b *testing.B) {
+		background := context.Background()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			fields := MergeFields(FieldsFromContext(ctx), []Field{Int("status", 200), Bool("cached", true)})
+			logger.Info(background, "bench", "request handled", fields...)
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package zllog
+
+import "context"
+
+// ============================================================================
+// BoundLogger - 绑定 context 的日志对象
+// ============================================================================
+
+// BoundLogger 绑定了 context 的日志对象，调用时无需再传 ctx
+// 创建时确定 trace_id（没有时生成一个），之后的每条日志都使用同一个 trace_id，
+// request_id 和上下文字段（WithFields）同样来自绑定的 context
+//
+// 用法示例：
+//   func handle(ctx context.Context, req *Request) {
+//       log := zllog.FromContext(ctx).WithModule("api.order")
+//       log.Info("order received", zllog.String("order_id", req.ID))
+//       log.Error("order rejected", err)
+//   }
+//
+// BoundLogger 应在单个请求/任务内使用，不要跨请求保存
+type BoundLogger struct {
+	ctx    context.Context
+	module string
+	err    error // WithError 绑定的错误
+}
+
+// FromContext 创建绑定 ctx 的日志对象
+func FromContext(ctx context.Context) *BoundLogger {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if traceIDFromContext(ctx) == "" {
+		ctx = withTraceID(ctx, GetOrCreateTraceID(ctx))
+	}
+	return &BoundLogger{ctx: ctx}
+}
+
+// Context 返回绑定的 context（已固定 trace_id，可继续向下游传递）
+func (b *BoundLogger) Context() context.Context {
+	return b.ctx
+}
+
+// WithModule 返回使用指定 module 的日志对象
+func (b *BoundLogger) WithModule(module string) *BoundLogger {
+	return &BoundLogger{ctx: b.ctx, module: module, err: b.err}
+}
+
+// Named 返回子 module 的日志对象，module 以 "." 分隔追加
+func (b *BoundLogger) Named(child string) *BoundLogger {
+	return &BoundLogger{ctx: b.ctx, module: Named(b.module, child), err: b.err}
+}
+
+// With 返回追加了上下文字段的日志对象，不影响原对象
+func (b *BoundLogger) With(fields ...Field) *BoundLogger {
+	return &BoundLogger{ctx: WithFields(b.ctx, fields...), module: b.module, err: b.err}
+}
+
+// WithError 返回绑定了 err 的日志对象，不影响原对象
+// 之后的 Error、ErrorWithCode、Errorf、Fatal、Panic 调用传入 nil 时使用绑定的 err，传入非 nil 时以传入的为准
+//
+// 用法示例：
+//   log := zllog.FromContext(ctx).WithModule("sync").WithError(err)
+//   for _, item := range items {
+//       log.Error("item skipped", nil, zllog.String("item_id", item.ID))  // 每行都带 error=err
+//   }
+func (b *BoundLogger) WithError(err error) *BoundLogger {
+	return &BoundLogger{ctx: b.ctx, module: b.module, err: err}
+}
+
+// errOr 传入的 err 为 nil 时返回绑定的错误
+func (b *BoundLogger) errOr(err error) error {
+	if err == nil {
+		return b.err
+	}
+	return err
+}
+
+// Trace logs a message at TRACE level
+func (b *BoundLogger) Trace(message string, fields ...Field) {
+	Trace(b.ctx, b.module, message, fields...)
+}
+
+// Debug logs a message at DEBUG level
+func (b *BoundLogger) Debug(message string, fields ...Field) {
+	Debug(b.ctx, b.module, message, fields...)
+}
+
+// Info logs a message at INFO level
+func (b *BoundLogger) Info(message string, fields ...Field) {
+	Info(b.ctx, b.module, message, fields...)
+}
+
+// Warn logs a message at WARN level
+func (b *BoundLogger) Warn(message string, fields ...Field) {
+	Warn(b.ctx, b.module, message, fields...)
+}
+
+// Error logs a message at ERROR level with error info
+func (b *BoundLogger) Error(message string, err error, fields ...Field) {
+	Error(b.ctx, b.module, message, b.errOr(err), fields...)
+}
+
+// ErrorWithCode logs a message at ERROR level with error code
+func (b *BoundLogger) ErrorWithCode(message, errorCode string, err error, fields ...Field) {
+	ErrorWithCode(b.ctx, b.module, message, errorCode, b.errOr(err), fields...)
+}
+
+// Fatal logs a message at FATAL level and exits
+func (b *BoundLogger) Fatal(message string, err error, fields ...Field) {
+	Fatal(b.ctx, b.module, message, b.errOr(err), fields...)
+}
+
+// Panic logs a message at PANIC level and then panics
+func (b *BoundLogger) Panic(message string, err error, fields ...Field) {
+	Panic(b.ctx, b.module, message, b.errOr(err), fields...)
+}
+
+// Tracef logs a formatted message at TRACE level
+func (b *BoundLogger) Tracef(format string, args ...interface{}) {
+	Tracef(b.ctx, b.module, format, args...)
+}
+
+// Debugf logs a formatted message at DEBUG level
+func (b *BoundLogger) Debugf(format string, args ...interface{}) {
+	Debugf(b.ctx, b.module, format, args...)
+}
+
+// Infof logs a formatted message at INFO level
+func (b *BoundLogger) Infof(format string, args ...interface{}) {
+	Infof(b.ctx, b.module, format, args...)
+}
+
+// Warnf logs a formatted message at WARN level
+func (b *BoundLogger) Warnf(format string, args ...interface{}) {
+	Warnf(b.ctx, b.module, format, args...)
+}
+
+// Errorf logs a formatted message at ERROR level with error info
+func (b *BoundLogger) Errorf(format string, err error, args ...interface{}) {
+	Errorf(b.ctx, b.module, format, b.errOr(err), args...)
+}
@@ -0,0 +1,86 @@
+package zllog
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// TestBoundLogger 测试 BoundLogger 的日志使用创建时确定的 trace_id 和 context 信息
+func TestBoundLogger(t *testing.T) {
+	originalLogger := globalLoggerImpl
+	defer func() {
+		globalLoggerImpl = originalLogger
+	}()
+
+	logger, buf := newTestLogger(t, &LogConfig{})
+	SetLogger(logger)
+
+	ctx := WithFields(WithRequestID(context.Background(), "req-1"), String("user_id", "u-1"))
+	log := FromContext(ctx).WithModule("api").Named("order")
+	log.Info("first")
+	log.With(String("order_id", "o-1")).Warnf("second %d", 2)
+
+	lines := decodeLines(t, buf)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+	traceID, _ := lines[0]["trace_id"].(string)
+	if traceID == "" || lines[1]["trace_id"] != traceID {
+		t.Errorf("expected the same captured trace_id, got %v and %v", lines[0]["trace_id"], lines[1]["trace_id"])
+	}
+	if got := GetOrCreateTraceID(log.Context()); got != traceID {
+		t.Errorf("bound context should carry trace_id %s, got %s", traceID, got)
+	}
+	for _, line := range lines {
+		if line["module"] != "api.order" || line["request_id"] != "req-1" || line["user_id"] != "u-1" {
+			t.Errorf("missing captured context info: %v", line)
+		}
+	}
+	if lines[1]["order_id"] != "o-1" || lines[1]["message"] != "second 2" {
+		t.Errorf("unexpected second line: %v", lines[1])
+	}
+}
+
+// TestBoundLoggerProviderTraceID 测试 TraceIDProvider 提供的 trace_id 被绑定
+func TestBoundLoggerProviderTraceID(t *testing.T) {
+	withTestTraceProvider(t)
+
+	ctx := context.WithValue(context.Background(), testTraceKey{}, "4bf92f3577b34da6a3ce929d0e0e4736")
+	if got := GetOrCreateTraceID(FromContext(ctx).Context()); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected provider trace_id, got %s", got)
+	}
+}
+
+// TestBoundLoggerWithError 测试绑定的 error 出现在之后的多行 ERROR 日志中，传入的 err 优先
+func TestBoundLoggerWithError(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{})
+	defer SwapLogger(logger)()
+
+	bound := errors.New("upstream unavailable")
+	log := FromContext(context.Background()).WithModule("sync").WithError(bound)
+	log.Error("item 1 skipped", nil)
+	log.Named("batch").ErrorWithCode("item 2 skipped", "SYNC_001", nil)
+	log.Errorf("item %d skipped", nil, 3)
+	log.Error("item 4 skipped", errors.New("item corrupted"))
+	log.Info("batch finished")
+
+	lines := decodeLines(t, buf)
+	if len(lines) != 5 {
+		t.Fatalf("expected 5 lines, got %d", len(lines))
+	}
+	for _, line := range lines[:3] {
+		if line["error"] != "upstream unavailable" {
+			t.Errorf("expected bound error, got %v", line)
+		}
+	}
+	if lines[1]["module"] != "sync.batch" || lines[1]["error_code"] != "SYNC_001" {
+		t.Errorf("expected Named to keep the bound error, got %v", lines[1])
+	}
+	if lines[3]["error"] != "item corrupted" {
+		t.Errorf("expected explicit error to override the bound one, got %v", lines[3]["error"])
+	}
+	if lines[4]["error"] != nil {
+		t.Errorf("expected no error on INFO, got %v", lines[4]["error"])
+	}
+}
@@ -0,0 +1,123 @@
+package zllog
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// ============================================================================
+// 请求级日志缓冲（出错时才输出详细日志）
+// ============================================================================
+
+// maxBufferedLines 单个缓冲区最多保留的日志行数，超出后新的日志行被丢弃（计入 Stats().Dropped）
+const maxBufferedLines = 1000
+
+// logBufferKey 日志缓冲区的 context 键
+type logBufferKey struct{}
+
+// bufferedLine 缓冲的一行日志及其原本的输出目标
+type bufferedLine struct {
+	level zerolog.Level
+	data  []byte
+	out   io.Writer
+}
+
+// write 输出日志行并计数，LevelWriter（如 SplitStdStreams）按日志级别分发
+func (line bufferedLine) write() {
+	countLevel(line.level)
+	if lw, ok := line.out.(zerolog.LevelWriter); ok {
+		lw.WriteLevel(line.level, line.data)
+		return
+	}
+	line.out.Write(line.data)
+}
+
+// logBuffer 请求级日志缓冲区
+type logBuffer struct {
+	mu    sync.Mutex
+	lines []bufferedLine
+	done  bool
+}
+
+// BufferedContext 返回缓冲日志的 context：使用该 context（及其派生 context）输出的 DEBUG、INFO 日志
+// 先暂存在内存中（全局级别高于 DEBUG 时 DEBUG 日志也会被暂存），由 flush 决定输出还是丢弃；
+// WARN 及以上级别照常立即输出
+//   - flush(true)：按原顺序输出暂存的日志（时间、caller 等均为原始值），适合请求以错误结束时
+//   - flush(false)：丢弃暂存的日志
+// flush 只有第一次调用生效，之后使用该 context 的日志不再缓冲
+// 目前只有 InitLogger 创建的 ZerologLogger 支持，其他 Logger 实现照常输出；缓冲的日志不经过 Sink
+//
+// 用法示例：
+//   ctx, flush := zllog.BufferedContext(r.Context())
+//   err := handle(ctx, req)
+//   flush(err != nil)  // 只有失败的请求才输出详细的 DEBUG/INFO 日志
+func BufferedContext(ctx context.Context) (context.Context, func(emit bool)) {
+	buf := &logBuffer{}
+	return context.WithValue(contextOrBackground(ctx), logBufferKey{}, buf), buf.flush
+}
+
+// logBufferFromContext 返回 context 中仍在缓冲的日志缓冲区，没有或已 flush 时返回 nil
+func logBufferFromContext(ctx context.Context) *logBuffer {
+	buf, _ := ctx.Value(logBufferKey{}).(*logBuffer)
+	if buf == nil {
+		return nil
+	}
+	buf.mu.Lock()
+	defer buf.mu.Unlock()
+	if buf.done {
+		return nil
+	}
+	return buf
+}
+
+// flush 输出或丢弃暂存的日志
+func (b *logBuffer) flush(emit bool) {
+	b.mu.Lock()
+	lines := b.lines
+	first := !b.done
+	b.lines, b.done = nil, true
+	b.mu.Unlock()
+
+	if !first || !emit {
+		return
+	}
+	for _, line := range lines {
+		line.write()
+	}
+}
+
+// add 暂存一行日志，已 flush 时直接输出
+func (b *logBuffer) add(line bufferedLine) {
+	b.mu.Lock()
+	if b.done {
+		b.mu.Unlock()
+		line.write()
+		return
+	}
+	if len(b.lines) >= maxBufferedLines {
+		b.mu.Unlock()
+		countDropped()
+		return
+	}
+	b.lines = append(b.lines, line)
+	b.mu.Unlock()
+}
+
+// bufferWriter 将 zerolog 输出的日志行写入缓冲区
+type bufferWriter struct {
+	buf *logBuffer
+	out io.Writer
+}
+
+func (w bufferWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel 复制日志行（zerolog 会复用 p）后暂存
+func (w bufferWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	w.buf.add(bufferedLine{level: level, data: append([]byte(nil), p...), out: w.out})
+	return len(p), nil
+}
@@ -0,0 +1,96 @@
+package zllog
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// TestBufferedContext 测试缓冲的 DEBUG/INFO 日志只在 flush(true) 时输出，WARN 及以上立即输出
+func TestBufferedContext(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{})
+	logger.out = buf
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+
+	failed, flushFailed := BufferedContext(context.Background())
+	ok, flushOK := BufferedContext(context.Background())
+
+	logger.Debug(failed, "order", "cart loaded")
+	logger.Info(failed, "order", "charging")
+	logger.Info(ok, "order", "charging ok")
+	logger.Warn(failed, "order", "retrying")
+
+	lines := decodeLines(t, buf)
+	if len(lines) != 1 || lines[0]["message"] != "retrying" {
+		t.Fatalf("expected only the warning before flush, got %v", lines)
+	}
+
+	flushOK(false)
+	flushFailed(true)
+	// flush 只有第一次调用生效
+	flushFailed(true)
+	// flush 之后不再缓冲
+	logger.Info(failed, "order", "after flush")
+
+	var got []string
+	for _, line := range decodeLines(t, buf) {
+		got = append(got, fmt.Sprintf("%s:%s", line["level"], line["message"]))
+	}
+	want := []string{"warn:retrying", "debug:cart loaded", "info:charging", "info:after flush"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("lines = %v, want %v", got, want)
+	}
+}
+
+// TestBufferedContextLimit 测试缓冲区超出上限后丢弃新的日志行
+func TestBufferedContextLimit(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{})
+	logger.out = buf
+
+	before := Stats().Dropped
+	ctx, flush := BufferedContext(context.Background())
+	for i := 0; i < maxBufferedLines+5; i++ {
+		logger.Info(ctx, "batch", "row")
+	}
+	flush(true)
+
+	if n := len(decodeLines(t, buf)); n != maxBufferedLines {
+		t.Errorf("expected %d lines, got %d", maxBufferedLines, n)
+	}
+	if dropped := Stats().Dropped - before; dropped != 5 {
+		t.Errorf("expected 5 dropped lines, got %d", dropped)
+	}
+}
+
+// levelRecorder 记录每次写入的级别，Write 记为 NoLevel
+type levelRecorder struct {
+	levels []zerolog.Level
+}
+
+func (r *levelRecorder) Write(p []byte) (int, error) {
+	r.levels = append(r.levels, zerolog.NoLevel)
+	return len(p), nil
+}
+
+func (r *levelRecorder) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	r.levels = append(r.levels, level)
+	return len(p), nil
+}
+
+// TestLogBufferLevelWriter 测试 flush 输出的日志行和 flush 之后才到达的日志行都通过 WriteLevel 按级别输出
+func TestLogBufferLevelWriter(t *testing.T) {
+	rec := &levelRecorder{}
+	b := &logBuffer{}
+
+	b.add(bufferedLine{level: zerolog.InfoLevel, data: []byte("buffered\n"), out: rec})
+	b.flush(true)
+	// 与 flush 并发的日志行在 flush 之后才写入缓冲区
+	b.add(bufferedLine{level: zerolog.DebugLevel, data: []byte("late\n"), out: rec})
+
+	want := []zerolog.Level{zerolog.InfoLevel, zerolog.DebugLevel}
+	if fmt.Sprint(rec.levels) != fmt.Sprint(want) {
+		t.Errorf("levels = %v, want %v", rec.levels, want)
+	}
+}
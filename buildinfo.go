@@ -0,0 +1,63 @@
+package zllog
+
+import (
+	"os"
+	"runtime/debug"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// 构建信息和进程信息（LogConfig.IncludeBuildInfo、LogConfig.IncludePID）
+// ============================================================================
+
+// readBuildInfo 读取当前程序的构建信息（测试中可替换）
+var readBuildInfo = debug.ReadBuildInfo
+
+// buildInfoFields 从构建信息中提取 version 和 vcs_revision 字段
+//   - version：主模块版本（go install 或带 tag 构建时才有，本地 go build 为 "(devel)"，此时不输出）
+//   - vcs_revision：构建时的提交 hash（Go 1.18+ 在 VCS 仓库内构建时自动嵌入）
+// 没有构建信息时返回 nil
+func buildInfoFields() map[string]interface{} {
+	info, ok := readBuildInfo()
+	if !ok || info == nil {
+		return nil
+	}
+	fields := make(map[string]interface{}, 2)
+	if v := info.Main.Version; v != "" && v != "(devel)" {
+		fields["version"] = v
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" && setting.Value != "" {
+			fields["vcs_revision"] = setting.Value
+		}
+	}
+	return fields
+}
+
+// addBuildInfo 将构建信息合并到 GlobalFields，已配置的同名字段优先（如通过 -ldflags 注入的 version）
+func addBuildInfo(config *LogConfig) {
+	addGlobalFields(config, buildInfoFields())
+}
+
+// addProcessInfo 将 pid 和 boot_id 合并到 GlobalFields，已配置的同名字段优先
+// boot_id 在每次初始化时随机生成：容器内进程号往往固定为 1，只靠 pid 无法区分重启前后的日志
+func addProcessInfo(config *LogConfig) {
+	addGlobalFields(config, map[string]interface{}{
+		"pid":     os.Getpid(),
+		"boot_id": uuid.New().String(),
+	})
+}
+
+// addGlobalFields 将 fields 合并到 GlobalFields，已配置的同名字段优先
+func addGlobalFields(config *LogConfig, fields map[string]interface{}) {
+	for key, value := range fields {
+		if _, ok := config.GlobalFields[key]; ok {
+			continue
+		}
+		if config.GlobalFields == nil {
+			config.GlobalFields = make(map[string]interface{}, len(fields))
+		}
+		config.GlobalFields[key] = value
+	}
+}
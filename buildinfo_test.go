@@ -0,0 +1,103 @@
+package zllog
+
+import (
+	"bytes"
+	"os"
+	"runtime/debug"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// stubBuildInfo 在测试期间替换构建信息
+func stubBuildInfo(t *testing.T, info *debug.BuildInfo) {
+	original := readBuildInfo
+	readBuildInfo = func() (*debug.BuildInfo, bool) { return info, info != nil }
+	t.Cleanup(func() { readBuildInfo = original })
+}
+
+// TestIncludeBuildInfo 测试开启 IncludeBuildInfo 时 version 和 vcs_revision 加入 GlobalFields
+func TestIncludeBuildInfo(t *testing.T) {
+	stubBuildInfo(t, &debug.BuildInfo{
+		Main:     debug.Module{Path: "example.com/svc", Version: "v1.4.2"},
+		Settings: []debug.BuildSetting{{Key: "vcs", Value: "git"}, {Key: "vcs.revision", Value: "abc123"}},
+	})
+
+	config := &LogConfig{ServiceName: "svc", Env: "test", IncludeBuildInfo: true}
+	effective := newEffectiveConfig(config)
+	if effective.GlobalFields["version"] != "v1.4.2" || effective.GlobalFields["vcs_revision"] != "abc123" {
+		t.Errorf("expected build info in global fields, got %v", effective.GlobalFields)
+	}
+	if config.GlobalFields != nil {
+		t.Errorf("caller's config should not be modified: %v", config.GlobalFields)
+	}
+	buf := &bytes.Buffer{}
+	base := newBaseLogger(buf, zerolog.InfoLevel, &effective)
+	base.Info().Msg("started")
+	if line := decodeLines(t, buf)[0]; line["version"] != "v1.4.2" || line["vcs_revision"] != "abc123" {
+		t.Errorf("expected build info on every line, got %v", line)
+	}
+
+	// 已配置的同名字段优先
+	config.GlobalFields = map[string]interface{}{"version": "v2-ldflags"}
+	effective = newEffectiveConfig(config)
+	if effective.GlobalFields["version"] != "v2-ldflags" || effective.GlobalFields["vcs_revision"] != "abc123" {
+		t.Errorf("configured version should win, got %v", effective.GlobalFields)
+	}
+
+	// 未开启时不读取构建信息
+	effective = newEffectiveConfig(&LogConfig{ServiceName: "svc", Env: "test"})
+	if len(effective.GlobalFields) != 0 {
+		t.Errorf("expected no build info when disabled, got %v", effective.GlobalFields)
+	}
+}
+
+// TestBuildInfoFieldsDevel 测试本地构建（(devel)）和没有构建信息时不输出版本
+func TestBuildInfoFieldsDevel(t *testing.T) {
+	stubBuildInfo(t, &debug.BuildInfo{Main: debug.Module{Version: "(devel)"}})
+	if fields := buildInfoFields(); len(fields) != 0 {
+		t.Errorf("expected no fields for devel build, got %v", fields)
+	}
+
+	stubBuildInfo(t, nil)
+	if fields := buildInfoFields(); fields != nil {
+		t.Errorf("expected nil without build info, got %v", fields)
+	}
+}
+
+// TestIncludePID 测试开启 IncludePID 时每行日志带 pid 和 boot_id，且每次初始化的 boot_id 不同
+func TestIncludePID(t *testing.T) {
+	config := &LogConfig{ServiceName: "svc", Env: "test", IncludePID: true}
+
+	var bootIDs []interface{}
+	for i := 0; i < 2; i++ {
+		effective := newEffectiveConfig(config)
+		buf := &bytes.Buffer{}
+		base := newBaseLogger(buf, zerolog.InfoLevel, &effective)
+		base.Info().Msg("started")
+
+		line := decodeLines(t, buf)[0]
+		if line["pid"] != float64(os.Getpid()) {
+			t.Errorf("expected pid=%d, got %v", os.Getpid(), line["pid"])
+		}
+		if id, _ := line["boot_id"].(string); id == "" {
+			t.Fatalf("expected boot_id, got %v", line)
+		}
+		bootIDs = append(bootIDs, line["boot_id"])
+	}
+	if bootIDs[0] == bootIDs[1] {
+		t.Errorf("expected a new boot_id per init, got %v twice", bootIDs[0])
+	}
+	if config.GlobalFields != nil {
+		t.Errorf("caller's config should not be modified: %v", config.GlobalFields)
+	}
+
+	// 已配置的同名字段优先；未开启时不输出
+	config.GlobalFields = map[string]interface{}{"pid": "worker-1"}
+	if effective := newEffectiveConfig(config); effective.GlobalFields["pid"] != "worker-1" || effective.GlobalFields["boot_id"] == nil {
+		t.Errorf("configured pid should win, got %v", effective.GlobalFields)
+	}
+	if effective := newEffectiveConfig(&LogConfig{ServiceName: "svc", Env: "test"}); len(effective.GlobalFields) != 0 {
+		t.Errorf("expected no process info when disabled, got %v", effective.GlobalFields)
+	}
+}
@@ -0,0 +1,100 @@
+package zllog
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ============================================================================
+// Clock - 可替换的时钟（测试中固定日志时间戳和耗时）
+// ============================================================================
+
+// Clock 提供当前时间，用于日志的 time 字段、Entry.Time 和 Timer 的耗时计算
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock 系统时钟（默认）
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// clockHolder 包装 Clock 以便存入 atomic.Value（要求每次存入的具体类型一致）
+type clockHolder struct {
+	clock Clock
+}
+
+var currentClock atomic.Value // clockHolder
+
+// SetClock 替换全局时钟，返回恢复之前时钟的函数；clock 为 nil 时恢复为系统时钟
+// 日志的 time 字段在 InitLogger 时接入时钟（zerolog.TimestampFunc）
+//
+// 用法示例（测试中固定时间戳）：
+//   clock := zllog.NewManualClock(time.Date(2025, 1, 27, 10, 0, 0, 0, time.UTC))
+//   defer zllog.SetClock(clock)()
+//   timer := zllog.NewTimer()
+//   clock.Advance(150 * time.Millisecond)
+//   timer.LogInfo(ctx, "api", "done", "req-1")  // time=2025-01-27T10:00:00.15Z cost_ms=150
+func SetClock(clock Clock) (restore func()) {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	previous := getClock()
+	currentClock.Store(clockHolder{clock: clock})
+	return func() {
+		currentClock.Store(clockHolder{clock: previous})
+	}
+}
+
+// getClock 返回当前时钟
+func getClock() Clock {
+	if holder, ok := currentClock.Load().(clockHolder); ok {
+		return holder.clock
+	}
+	return systemClock{}
+}
+
+// Now 返回当前时钟的时间，适配器计算耗时时应使用 Now 和 Since 而不是 time.Now
+func Now() time.Time {
+	return getClock().Now()
+}
+
+// Since 返回从 t 到当前时钟时间经过的时长
+func Since(t time.Time) time.Duration {
+	return Now().Sub(t)
+}
+
+// ManualClock 手动控制的时钟，只有调用 Set 或 Advance 时才会变化（用于测试）
+type ManualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewManualClock 创建停在 t 的时钟
+func NewManualClock(t time.Time) *ManualClock {
+	return &ManualClock{now: t}
+}
+
+// Now 返回时钟当前的时间
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Set 将时钟设置为 t
+func (c *ManualClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+// Advance 将时钟向前拨动 d
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
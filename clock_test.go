@@ -0,0 +1,66 @@
+package zllog
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// TestManualClock 测试固定时钟下日志时间戳、Entry.Time 和 cost_ms 可预期
+func TestManualClock(t *testing.T) {
+	fixed := time.Date(2025, 1, 27, 10, 0, 0, 0, time.UTC)
+	clock := NewManualClock(fixed)
+	defer SetClock(clock)()
+
+	// InitLogger 时将 zerolog 的时间戳接入时钟，这里手动接入
+	originalTimestamp, originalFormat := zerolog.TimestampFunc, zerolog.TimeFieldFormat
+	zerolog.TimestampFunc, zerolog.TimeFieldFormat = Now, time.RFC3339Nano
+	defer func() { zerolog.TimestampFunc, zerolog.TimeFieldFormat = originalTimestamp, originalFormat }()
+
+	logger, _ := newTestLogger(t, &LogConfig{})
+	buf := &bytes.Buffer{}
+	base := newBaseLogger(buf, zerolog.TraceLevel, &LogConfig{Env: "test"})
+	logger.logger = &base
+
+	timer := NewTimer()
+	clock.Advance(150 * time.Millisecond)
+	logger.InfoWithRequest(context.Background(), "api", "done", "req-1", timer.ElapsedMs())
+
+	line := decodeLines(t, buf)[0]
+	if line["time"] != "2025-01-27T10:00:00.15Z" {
+		t.Errorf("expected fixed timestamp, got %v", line["time"])
+	}
+	if line["cost_ms"] != float64(150) {
+		t.Errorf("expected cost_ms=150, got %v", line["cost_ms"])
+	}
+
+	var entry Entry
+	NewEntryLogger(func(ctx context.Context, e Entry) { entry = e }).Info(context.Background(), "api", "done")
+	if !entry.Time.Equal(fixed.Add(150 * time.Millisecond)) {
+		t.Errorf("expected entry time from clock, got %v", entry.Time)
+	}
+}
+
+// TestSetClockRestore 测试恢复之前的时钟，nil 表示系统时钟
+func TestSetClockRestore(t *testing.T) {
+	clock := NewManualClock(time.Unix(0, 0))
+	restore := SetClock(clock)
+	if !Now().Equal(time.Unix(0, 0)) {
+		t.Errorf("expected manual clock, got %v", Now())
+	}
+	restoreSystem := SetClock(nil)
+	if Since(time.Unix(0, 0)) < time.Hour {
+		t.Errorf("expected system clock after SetClock(nil)")
+	}
+	restoreSystem()
+	if getClock() != Clock(clock) {
+		t.Errorf("expected manual clock after restoring nested SetClock")
+	}
+	restore()
+	if _, ok := getClock().(systemClock); !ok {
+		t.Errorf("expected system clock after restore, got %T", getClock())
+	}
+}
@@ -0,0 +1,56 @@
+package zllog
+
+import "context"
+
+// ============================================================================
+// 条件日志（cond 为 true 时才输出）
+// ============================================================================
+//
+// 避免在业务代码中到处用 if 包裹调试日志：
+//   zllog.DebugIf(verbose, ctx, "sync", "batch detail", zllog.Lazy("items", func() interface{} {
+//       return dumpItems(items) // cond 为 false 时不会执行
+//   }))
+//
+// 注意：普通字段的参数在调用前就已经求值，开销较大的字段请使用 Lazy
+
+// TraceIf logs a message at TRACE level when cond is true
+func TraceIf(cond bool, ctx context.Context, module, message string, fields ...Field) {
+	if cond {
+		Trace(ctx, module, message, fields...)
+	}
+}
+
+// DebugIf logs a message at DEBUG level when cond is true
+func DebugIf(cond bool, ctx context.Context, module, message string, fields ...Field) {
+	if cond {
+		Debug(ctx, module, message, fields...)
+	}
+}
+
+// InfoIf logs a message at INFO level when cond is true
+func InfoIf(cond bool, ctx context.Context, module, message string, fields ...Field) {
+	if cond {
+		Info(ctx, module, message, fields...)
+	}
+}
+
+// WarnIf logs a message at WARN level when cond is true
+func WarnIf(cond bool, ctx context.Context, module, message string, fields ...Field) {
+	if cond {
+		Warn(ctx, module, message, fields...)
+	}
+}
+
+// ErrorIf logs a message at ERROR level with error info when cond is true
+func ErrorIf(cond bool, ctx context.Context, module, message string, err error, fields ...Field) {
+	if cond {
+		Error(ctx, module, message, err, fields...)
+	}
+}
+
+// ErrorWithCodeIf logs a message at ERROR level with error code when cond is true
+func ErrorWithCodeIf(cond bool, ctx context.Context, module, message, errorCode string, err error, fields ...Field) {
+	if cond {
+		ErrorWithCode(ctx, module, message, errorCode, err, fields...)
+	}
+}
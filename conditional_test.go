@@ -0,0 +1,65 @@
+package zllog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// TestConditionalLogging 测试条件为 false 时不输出且不对 Lazy 字段求值
+func TestConditionalLogging(t *testing.T) {
+	originalLogger := globalLoggerImpl
+	defer func() {
+		globalLoggerImpl = originalLogger
+	}()
+
+	logger, buf := newTestLogger(t, &LogConfig{})
+	SetLogger(logger)
+
+	evaluated := 0
+	lazy := Lazy("expensive", func() interface{} {
+		evaluated++
+		return "computed"
+	})
+
+	ctx := context.Background()
+	TraceIf(false, ctx, "test", "trace", lazy)
+	DebugIf(false, ctx, "test", "debug", lazy)
+	InfoIf(false, ctx, "test", "info", lazy)
+	WarnIf(false, ctx, "test", "warn", lazy)
+	ErrorIf(false, ctx, "test", "error", errors.New("boom"), lazy)
+	ErrorWithCodeIf(false, ctx, "test", "error", "E001", nil, lazy)
+
+	if buf.Len() != 0 {
+		t.Errorf("expected no output, got %s", buf.String())
+	}
+	if evaluated != 0 {
+		t.Errorf("lazy field evaluated %d times with false condition", evaluated)
+	}
+
+	InfoIf(true, ctx, "test", "info", lazy)
+	lines := decodeLines(t, buf)
+	if len(lines) != 1 || lines[0]["expensive"] != "computed" {
+		t.Errorf("expected one line with the lazy field, got %v", lines)
+	}
+	if evaluated != 1 {
+		t.Errorf("expected lazy field evaluated once, got %d", evaluated)
+	}
+}
+
+// TestLazyFieldDisabledLevel 测试级别被过滤时不对 Lazy 字段求值
+func TestLazyFieldDisabledLevel(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{})
+	infoLogger := logger.logger.Level(zerolog.InfoLevel)
+	logger.logger = &infoLogger
+
+	logger.Debug(context.Background(), "test", "filtered", Lazy("expensive", func() interface{} {
+		t.Error("lazy field evaluated for a disabled level")
+		return nil
+	}))
+	if buf.Len() != 0 {
+		t.Errorf("expected no output, got %s", buf.String())
+	}
+}
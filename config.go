@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/spf13/cast"
 	"github.com/spf13/viper"
 )
 
@@ -44,16 +46,27 @@ func (l *ConfigLoader) SetEnv(env string) {
 	l.envName = env
 }
 
+// LogConfig.Source 的取值
+const (
+	ConfigSourceLogYAML = "log.yaml"         // 独立的 log.yaml
+	ConfigSourceAppYAML = "application.yaml" // application.yaml 中的 logger 配置
+	ConfigSourceEnv     = "env"              // application_{ENV}.yaml 中的 logger 配置
+	ConfigSourceDefault = "default"          // 未找到配置文件，使用默认配置
+)
+
 // LoadConfig 加载配置
 // 按优先级查找配置文件，如果都找不到则使用默认配置
+// 返回的配置中 Source/SourceFile 记录了实际使用的来源，初始化日志中以 config_source/config_file 输出
 func (l *ConfigLoader) LoadConfig() *LogConfig {
 	// 1. 尝试从 log.yaml 加载（独立配置文件）
 	if config := l.loadFromLogYAML(); config != nil {
+		config.Source, config.SourceFile = ConfigSourceLogYAML, filepath.Join(l.configDir, "log.yaml")
 		return config
 	}
 
 	// 2. 尝试从 application.yaml 加载
 	if config := l.loadFromAppYAML("application.yaml"); config != nil {
+		config.Source, config.SourceFile = ConfigSourceAppYAML, filepath.Join(l.configDir, "application.yaml")
 		return config
 	}
 
@@ -61,14 +74,16 @@ func (l *ConfigLoader) LoadConfig() *LogConfig {
 	if l.envName != "" {
 		appEnvFile := fmt.Sprintf("application_%s.yaml", l.envName)
 		if config := l.loadFromAppYAML(appEnvFile); config != nil {
+			config.Source, config.SourceFile = ConfigSourceEnv, filepath.Join(l.configDir, appEnvFile)
 			return config
 		}
 	}
 
 	// 4. 使用默认配置
 	serviceName := detectServiceName()
-	config := DefaultConfig(serviceName)
-	adjustConfigByEnv(config)
+	config := newLoaderConfig(serviceName)
+	AdjustConfigByEnv(config)
+	config.Source = ConfigSourceDefault
 
 	return config
 }
@@ -132,7 +147,7 @@ func (l *ConfigLoader) parseLogConfig(v *viper.Viper) *LogConfig {
 		serviceName = v.GetString("service_name")
 	}
 
-	config := DefaultConfig(serviceName)
+	config := newLoaderConfig(serviceName)
 
 	// 覆盖配置
 	if v.IsSet("env") {
@@ -156,6 +171,21 @@ func (l *ConfigLoader) parseLogConfig(v *viper.Viper) *LogConfig {
 	if v.IsSet("compress") {
 		config.Compress = v.GetBool("compress")
 	}
+	if v.IsSet("file_mode") {
+		config.FileMode = parseFileMode(v.Get("file_mode"))
+	}
+	if v.IsSet("dir_mode") {
+		config.DirMode = parseFileMode(v.Get("dir_mode"))
+	}
+	if v.IsSet("log_dir_retries") {
+		config.LogDirRetries = v.GetInt("log_dir_retries")
+	}
+	if v.IsSet("log_dir_retry_interval") {
+		config.LogDirRetryInterval = v.GetDuration("log_dir_retry_interval")
+	}
+	if v.IsSet("archive_dir") {
+		config.ArchiveDir = v.GetString("archive_dir")
+	}
 	if v.IsSet("daily_roll") {
 		config.EnableDailyRoll = v.GetBool("daily_roll")
 	}
@@ -165,12 +195,142 @@ func (l *ConfigLoader) parseLogConfig(v *viper.Viper) *LogConfig {
 	if v.IsSet("console_json") {
 		config.ConsoleJSONFormat = v.GetBool("console_json")
 	}
+	if v.IsSet("console_minimal") {
+		config.ConsoleMinimal = v.GetBool("console_minimal")
+	}
+	if v.IsSet("console_key_case") {
+		config.ConsoleKeyCase = v.GetString("console_key_case")
+	}
+	if v.IsSet("console_align") {
+		config.ConsoleAlign = v.GetBool("console_align")
+	}
+	if v.IsSet("split_std_streams") {
+		config.SplitStdStreams = v.GetBool("split_std_streams")
+	}
+	if v.IsSet("console_color") {
+		color := v.GetBool("console_color")
+		config.ConsoleColor = &color
+	}
+	if v.IsSet("global_fields") {
+		config.GlobalFields = v.GetStringMap("global_fields")
+	}
+	if v.IsSet("allow_global_field_override") {
+		config.AllowGlobalFieldOverride = v.GetBool("allow_global_field_override")
+	}
+	if v.IsSet("include_build_info") {
+		config.IncludeBuildInfo = v.GetBool("include_build_info")
+	}
+	if v.IsSet("include_pid") {
+		config.IncludePID = v.GetBool("include_pid")
+	}
+	if v.IsSet("output_format") {
+		config.OutputFormat = v.GetString("output_format")
+	}
+	if v.IsSet("pretty_json") {
+		config.PrettyJSON = v.GetBool("pretty_json")
+	}
+	if v.IsSet("schema_version") {
+		config.SchemaVersion = v.GetString("schema_version")
+	}
+	if v.IsSet("dedup_field_keys") {
+		config.DedupFieldKeys = v.GetBool("dedup_field_keys")
+	}
+	if v.IsSet("dedup_keep_first") {
+		config.DedupKeepFirst = v.GetBool("dedup_keep_first")
+	}
+	if v.IsSet("sort_fields") {
+		config.SortFields = v.GetBool("sort_fields")
+	}
+	if v.IsSet("max_message_length") {
+		config.MaxMessageLength = v.GetInt("max_message_length")
+	}
+	if v.IsSet("max_field_length") {
+		config.MaxFieldLength = v.GetInt("max_field_length")
+	}
+	if v.IsSet("max_fields") {
+		config.MaxFields = v.GetInt("max_fields")
+	}
+	if v.IsSet("max_line_bytes") {
+		config.MaxLineBytes = v.GetInt("max_line_bytes")
+	}
+	if v.IsSet("hash_chain") {
+		config.HashChain = v.GetBool("hash_chain")
+	}
 	if v.IsSet("enable_caller") {
 		config.EnableCaller = v.GetBool("enable_caller")
 	}
+	if v.IsSet("caller_skip") {
+		config.CallerSkip = v.GetInt("caller_skip")
+	}
+	if v.IsSet("caller_format") {
+		config.CallerFormat = v.GetString("caller_format")
+	}
+	if v.IsSet("enable_ctx_err") {
+		config.EnableCtxErr = v.GetBool("enable_ctx_err")
+	}
+	if v.IsSet("suppress_init_log") {
+		config.SuppressInitLog = v.GetBool("suppress_init_log")
+	}
+	if v.IsSet("enable_expvar") {
+		config.EnableExpvar = v.GetBool("enable_expvar")
+	}
+	if v.IsSet("enable_goroutine_id") {
+		config.EnableGoroutineID = v.GetBool("enable_goroutine_id")
+	}
+	if v.IsSet("error_code_levels") {
+		config.ErrorCodeLevels = v.GetStringMapString("error_code_levels")
+	}
+	if v.IsSet("strict_trace_id") {
+		config.StrictTraceID = v.GetBool("strict_trace_id")
+	}
+	if v.IsSet("warn_nil_context") {
+		config.WarnNilContext = v.GetBool("warn_nil_context")
+	}
+	if v.IsSet("trace_sample_rate") {
+		config.TraceSampleRate = v.GetFloat64("trace_sample_rate")
+	}
+	if v.IsSet("sampling.initial") {
+		config.Sampling.Initial = v.GetInt("sampling.initial")
+	}
+	if v.IsSet("sampling.thereafter") {
+		config.Sampling.Thereafter = v.GetInt("sampling.thereafter")
+	}
+	if v.IsSet("sampling.tick") {
+		config.Sampling.Tick = v.GetDuration("sampling.tick")
+	}
+	if v.IsSet("level_sampling") {
+		config.LevelSampling = parseLevelSampling(v, "level_sampling")
+	}
+	if v.IsSet("module_rate_limits") {
+		config.ModuleRateLimits = parseIntMap(v.GetStringMap("module_rate_limits"))
+	}
+	if v.IsSet("module_env_allowlist") {
+		config.ModuleEnvAllowlist = v.GetStringMapStringSlice("module_env_allowlist")
+	}
+	if v.IsSet("recover_repanic") {
+		config.RecoverRepanic = v.GetBool("recover_repanic")
+	}
+	if v.IsSet("async.enabled") {
+		config.Async.Enabled = v.GetBool("async.enabled")
+	}
+	if v.IsSet("async.buffer_size") {
+		config.Async.BufferSize = v.GetInt("async.buffer_size")
+	}
+	if v.IsSet("async.overflow_policy") {
+		config.Async.OverflowPolicy = OverflowPolicy(v.GetString("async.overflow_policy"))
+	}
+	if v.IsSet("audit.enabled") {
+		config.Audit.Enabled = v.GetBool("audit.enabled")
+	}
+	if v.IsSet("audit.file_name") {
+		config.Audit.FileName = v.GetString("audit.file_name")
+	}
+	if v.IsSet("audit.hmac_key") {
+		config.Audit.HMACKey = v.GetString("audit.hmac_key")
+	}
 
 	// 根据环境调整配置
-	adjustConfigByEnv(config)
+	AdjustConfigByEnv(config)
 
 	return config
 }
@@ -188,7 +348,7 @@ func (l *ConfigLoader) parseLoggerConfig(v *viper.Viper) *LogConfig {
 		serviceName = v.GetString("app.name")
 	}
 
-	config := DefaultConfig(serviceName)
+	config := newLoaderConfig(serviceName)
 
 	// 从 logger 配置项读取
 	if v.IsSet("logger.level") {
@@ -209,6 +369,21 @@ func (l *ConfigLoader) parseLoggerConfig(v *viper.Viper) *LogConfig {
 	if v.IsSet("logger.compress") {
 		config.Compress = v.GetBool("logger.compress")
 	}
+	if v.IsSet("logger.file_mode") {
+		config.FileMode = parseFileMode(v.Get("logger.file_mode"))
+	}
+	if v.IsSet("logger.dir_mode") {
+		config.DirMode = parseFileMode(v.Get("logger.dir_mode"))
+	}
+	if v.IsSet("logger.log_dir_retries") {
+		config.LogDirRetries = v.GetInt("logger.log_dir_retries")
+	}
+	if v.IsSet("logger.log_dir_retry_interval") {
+		config.LogDirRetryInterval = v.GetDuration("logger.log_dir_retry_interval")
+	}
+	if v.IsSet("logger.archive_dir") {
+		config.ArchiveDir = v.GetString("logger.archive_dir")
+	}
 	if v.IsSet("logger.daily_roll") {
 		config.EnableDailyRoll = v.GetBool("logger.daily_roll")
 	}
@@ -218,12 +393,142 @@ func (l *ConfigLoader) parseLoggerConfig(v *viper.Viper) *LogConfig {
 	if v.IsSet("logger.console_json") {
 		config.ConsoleJSONFormat = v.GetBool("logger.console_json")
 	}
+	if v.IsSet("logger.console_minimal") {
+		config.ConsoleMinimal = v.GetBool("logger.console_minimal")
+	}
+	if v.IsSet("logger.console_key_case") {
+		config.ConsoleKeyCase = v.GetString("logger.console_key_case")
+	}
+	if v.IsSet("logger.console_align") {
+		config.ConsoleAlign = v.GetBool("logger.console_align")
+	}
+	if v.IsSet("logger.split_std_streams") {
+		config.SplitStdStreams = v.GetBool("logger.split_std_streams")
+	}
+	if v.IsSet("logger.console_color") {
+		color := v.GetBool("logger.console_color")
+		config.ConsoleColor = &color
+	}
+	if v.IsSet("logger.global_fields") {
+		config.GlobalFields = v.GetStringMap("logger.global_fields")
+	}
+	if v.IsSet("logger.allow_global_field_override") {
+		config.AllowGlobalFieldOverride = v.GetBool("logger.allow_global_field_override")
+	}
+	if v.IsSet("logger.include_build_info") {
+		config.IncludeBuildInfo = v.GetBool("logger.include_build_info")
+	}
+	if v.IsSet("logger.include_pid") {
+		config.IncludePID = v.GetBool("logger.include_pid")
+	}
+	if v.IsSet("logger.output_format") {
+		config.OutputFormat = v.GetString("logger.output_format")
+	}
+	if v.IsSet("logger.pretty_json") {
+		config.PrettyJSON = v.GetBool("logger.pretty_json")
+	}
+	if v.IsSet("logger.schema_version") {
+		config.SchemaVersion = v.GetString("logger.schema_version")
+	}
+	if v.IsSet("logger.dedup_field_keys") {
+		config.DedupFieldKeys = v.GetBool("logger.dedup_field_keys")
+	}
+	if v.IsSet("logger.dedup_keep_first") {
+		config.DedupKeepFirst = v.GetBool("logger.dedup_keep_first")
+	}
+	if v.IsSet("logger.sort_fields") {
+		config.SortFields = v.GetBool("logger.sort_fields")
+	}
+	if v.IsSet("logger.max_message_length") {
+		config.MaxMessageLength = v.GetInt("logger.max_message_length")
+	}
+	if v.IsSet("logger.max_field_length") {
+		config.MaxFieldLength = v.GetInt("logger.max_field_length")
+	}
+	if v.IsSet("logger.max_fields") {
+		config.MaxFields = v.GetInt("logger.max_fields")
+	}
+	if v.IsSet("logger.max_line_bytes") {
+		config.MaxLineBytes = v.GetInt("logger.max_line_bytes")
+	}
+	if v.IsSet("logger.hash_chain") {
+		config.HashChain = v.GetBool("logger.hash_chain")
+	}
 	if v.IsSet("logger.enable_caller") {
 		config.EnableCaller = v.GetBool("logger.enable_caller")
 	}
+	if v.IsSet("logger.caller_skip") {
+		config.CallerSkip = v.GetInt("logger.caller_skip")
+	}
+	if v.IsSet("logger.caller_format") {
+		config.CallerFormat = v.GetString("logger.caller_format")
+	}
+	if v.IsSet("logger.enable_ctx_err") {
+		config.EnableCtxErr = v.GetBool("logger.enable_ctx_err")
+	}
+	if v.IsSet("logger.suppress_init_log") {
+		config.SuppressInitLog = v.GetBool("logger.suppress_init_log")
+	}
+	if v.IsSet("logger.enable_expvar") {
+		config.EnableExpvar = v.GetBool("logger.enable_expvar")
+	}
+	if v.IsSet("logger.enable_goroutine_id") {
+		config.EnableGoroutineID = v.GetBool("logger.enable_goroutine_id")
+	}
+	if v.IsSet("logger.error_code_levels") {
+		config.ErrorCodeLevels = v.GetStringMapString("logger.error_code_levels")
+	}
+	if v.IsSet("logger.strict_trace_id") {
+		config.StrictTraceID = v.GetBool("logger.strict_trace_id")
+	}
+	if v.IsSet("logger.warn_nil_context") {
+		config.WarnNilContext = v.GetBool("logger.warn_nil_context")
+	}
+	if v.IsSet("logger.trace_sample_rate") {
+		config.TraceSampleRate = v.GetFloat64("logger.trace_sample_rate")
+	}
+	if v.IsSet("logger.sampling.initial") {
+		config.Sampling.Initial = v.GetInt("logger.sampling.initial")
+	}
+	if v.IsSet("logger.sampling.thereafter") {
+		config.Sampling.Thereafter = v.GetInt("logger.sampling.thereafter")
+	}
+	if v.IsSet("logger.sampling.tick") {
+		config.Sampling.Tick = v.GetDuration("logger.sampling.tick")
+	}
+	if v.IsSet("logger.level_sampling") {
+		config.LevelSampling = parseLevelSampling(v, "logger.level_sampling")
+	}
+	if v.IsSet("logger.module_rate_limits") {
+		config.ModuleRateLimits = parseIntMap(v.GetStringMap("logger.module_rate_limits"))
+	}
+	if v.IsSet("logger.module_env_allowlist") {
+		config.ModuleEnvAllowlist = v.GetStringMapStringSlice("logger.module_env_allowlist")
+	}
+	if v.IsSet("logger.recover_repanic") {
+		config.RecoverRepanic = v.GetBool("logger.recover_repanic")
+	}
+	if v.IsSet("logger.async.enabled") {
+		config.Async.Enabled = v.GetBool("logger.async.enabled")
+	}
+	if v.IsSet("logger.async.buffer_size") {
+		config.Async.BufferSize = v.GetInt("logger.async.buffer_size")
+	}
+	if v.IsSet("logger.async.overflow_policy") {
+		config.Async.OverflowPolicy = OverflowPolicy(v.GetString("logger.async.overflow_policy"))
+	}
+	if v.IsSet("logger.audit.enabled") {
+		config.Audit.Enabled = v.GetBool("logger.audit.enabled")
+	}
+	if v.IsSet("logger.audit.file_name") {
+		config.Audit.FileName = v.GetString("logger.audit.file_name")
+	}
+	if v.IsSet("logger.audit.hmac_key") {
+		config.Audit.HMACKey = v.GetString("logger.audit.hmac_key")
+	}
 
 	// 根据环境调整配置
-	adjustConfigByEnv(config)
+	AdjustConfigByEnv(config)
 
 	return config
 }
@@ -232,6 +537,53 @@ func (l *ConfigLoader) parseLoggerConfig(v *viper.Viper) *LogConfig {
 // 辅助函数
 // ============================================================================
 
+// parseIntMap 将配置中的 map 值转换为 int（无法转换的项被忽略）
+func parseIntMap(m map[string]interface{}) map[string]int {
+	result := make(map[string]int, len(m))
+	for k, v := range m {
+		if n, err := cast.ToIntE(v); err == nil {
+			result[k] = n
+		}
+	}
+	return result
+}
+
+// parseLevelSampling 解析各级别的突发采样配置，如：
+//   level_sampling:
+//     debug: {initial: 10, thereafter: 100, tick: 1s}
+//     info:  {initial: 100, thereafter: 10}
+func parseLevelSampling(v *viper.Viper, key string) map[string]SamplingConfig {
+	levels := v.GetStringMap(key)
+	result := make(map[string]SamplingConfig, len(levels))
+	for level := range levels {
+		prefix := key + "." + level + "."
+		result[level] = SamplingConfig{
+			Initial:    v.GetInt(prefix + "initial"),
+			Thereafter: v.GetInt(prefix + "thereafter"),
+			Tick:       v.GetDuration(prefix + "tick"),
+		}
+	}
+	return result
+}
+
+// parseFileMode 解析文件权限配置
+// 字符串按八进制解析（如 "0640"、"640"），数字按原值使用（YAML 中的 0640 已被解析为八进制）
+// 无法解析时返回 0（使用默认权限）
+func parseFileMode(v interface{}) os.FileMode {
+	if s, ok := v.(string); ok {
+		n, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimSpace(s), "0o"), 8, 32)
+		if err != nil {
+			return 0
+		}
+		return os.FileMode(n)
+	}
+	n, err := cast.ToUint32E(v)
+	if err != nil {
+		return 0
+	}
+	return os.FileMode(n)
+}
+
 // detectServiceName 自动检测服务名称
 // 优先级: 环境变量 > 可执行文件名 > 当前目录名 > 默认值
 func detectServiceName() string {
@@ -268,6 +620,14 @@ func detectServiceName() string {
 // detectEnv 自动检测环境名称
 func detectEnv() string {
 	// 优先级: ENV > APP_ENV > GO_ENV > MODE > 默认 dev
+	if env := lookupEnv(); env != "" {
+		return env
+	}
+	return "dev"
+}
+
+// lookupEnv 从环境变量读取环境名称，都未设置时返回空字符串
+func lookupEnv() string {
 	if env := os.Getenv("ENV"); env != "" {
 		return env
 	}
@@ -280,14 +640,56 @@ func detectEnv() string {
 	if mode := os.Getenv("MODE"); mode != "" {
 		return mode
 	}
-	return "dev"
+	return ""
+}
+
+// 各环境的默认保留策略（未显式配置 max_backups/max_age 时使用）
+const (
+	devMaxBackups  = 1   // 开发环境只保留 1 个历史文件，避免占满本地磁盘
+	devMaxAge      = 1   // 开发环境只保留 1 天
+	prodMaxBackups = 180 // 生产环境保留 180 个历史文件（配合每日切割，可保留 180 天）
+	prodMaxAge     = 180 // 生产环境保留 180 天（等保3最低要求）
+)
+
+// newLoaderConfig 返回配置加载的初始配置：与 DefaultConfig 相同，但环境和保留策略留空，
+// 以便 AdjustConfigByEnv 区分配置文件中显式配置的值和按环境补全的默认值
+func newLoaderConfig(serviceName string) *LogConfig {
+	config := DefaultConfig(serviceName)
+	config.Env = ""
+	config.MaxBackups, config.MaxAge = 0, 0
+	return config
+}
+
+// configSourceForFile 按文件名判断 InitLoggerFromFile 指定文件的配置来源，与 LoadConfig 的查找规则一致：
+// log.yaml 为 log.yaml，application_{ENV}.yaml 为 env，其他（application.yaml 等）为 application.yaml
+func configSourceForFile(filename string) string {
+	base := filepath.Base(filename)
+	switch {
+	case strings.Contains(base, "log.yaml"):
+		return ConfigSourceLogYAML
+	case strings.HasPrefix(base, "application_"):
+		return ConfigSourceEnv
+	default:
+		return ConfigSourceAppYAML
+	}
 }
 
-// adjustConfigByEnv 根据环境智能调整配置
-func adjustConfigByEnv(config *LogConfig) {
-	// 如果没有手动配置环境，则自动检测
+// AdjustConfigByEnv 根据环境智能调整配置（配置文件加载时自动调用，直接构造的配置可在 InitLoggerWithConfig 之前调用）
+// 日志保留策略（MaxBackups/MaxAge）只补全为 0 的值，非 0 视为显式配置保持不变；只有显式指定的开发环境（dev/development/local）缩短保留期
+//
+// 用法示例：
+//   config := &zllog.LogConfig{ServiceName: "order", Env: "dev", MaxAge: 7}
+//   zllog.AdjustConfigByEnv(config) // MaxBackups 补全为 1，MaxAge 保持 7
+func AdjustConfigByEnv(config *LogConfig) {
+	// 如果没有手动配置环境，则自动检测；环境变量也未设置时回退为 dev
+	explicitEnv := true
 	if config.Env == "" || config.Env == "dev" {
-		config.Env = detectEnv()
+		if env := lookupEnv(); env != "" {
+			config.Env = env
+		} else if config.Env == "" {
+			config.Env = "dev"
+			explicitEnv = false
+		}
 	}
 
 	// 根据环境自动调整默认配置
@@ -299,15 +701,37 @@ func adjustConfigByEnv(config *LogConfig) {
 		if !config.EnableConsole {
 			config.EnableConsole = false // 生产环境默认关闭控制台
 		}
+		config.PrettyJSON = false // 生产环境始终输出单行 JSON
+		setRetentionDefaults(config, prodMaxBackups, prodMaxAge)
 	case "test", "testing":
 		if config.LogLevel == "" {
 			config.LogLevel = "INFO"
 		}
 		config.EnableConsole = true
-	case "dev", "development":
+		setRetentionDefaults(config, prodMaxBackups, prodMaxAge)
+	case "dev", "development", "local":
 		if config.LogLevel == "" {
 			config.LogLevel = "DEBUG"
 		}
 		config.EnableConsole = true
+		// 回退的 dev 保留策略仍按生产环境，避免未设置 ENV 的生产部署只保留 1 天
+		if explicitEnv {
+			setRetentionDefaults(config, devMaxBackups, devMaxAge)
+		} else {
+			setRetentionDefaults(config, prodMaxBackups, prodMaxAge)
+		}
+	default:
+		// staging、uat 等其他环境的日志可能用于排查线上问题，按生产环境保留
+		setRetentionDefaults(config, prodMaxBackups, prodMaxAge)
+	}
+}
+
+// setRetentionDefaults 设置环境对应的日志保留策略，非 0 的值视为显式配置保持不变
+func setRetentionDefaults(config *LogConfig, maxBackups, maxAge int) {
+	if config.MaxBackups == 0 {
+		config.MaxBackups = maxBackups
+	}
+	if config.MaxAge == 0 {
+		config.MaxAge = maxAge
 	}
 }
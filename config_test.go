@@ -0,0 +1,170 @@
+package zllog
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// TestAdjustConfigByEnvRetention 测试未显式配置时按环境设置 MaxBackups/MaxAge
+func TestAdjustConfigByEnvRetention(t *testing.T) {
+	tests := []struct {
+		env                string
+		maxBackups, maxAge int
+	}{
+		{env: "development", maxBackups: devMaxBackups, maxAge: devMaxAge},
+		{env: "prod", maxBackups: prodMaxBackups, maxAge: prodMaxAge},
+		{env: "docker", maxBackups: prodMaxBackups, maxAge: prodMaxAge},
+		{env: "test", maxBackups: 180, maxAge: 180},
+		{env: "local", maxBackups: devMaxBackups, maxAge: devMaxAge},
+		{env: "staging", maxBackups: prodMaxBackups, maxAge: prodMaxAge},
+		{env: "uat", maxBackups: prodMaxBackups, maxAge: prodMaxAge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.env, func(t *testing.T) {
+			config := &LogConfig{ServiceName: "svc", Env: tt.env}
+			AdjustConfigByEnv(config)
+			if config.MaxBackups != tt.maxBackups || config.MaxAge != tt.maxAge {
+				t.Errorf("expected max_backups=%d max_age=%d, got %d %d",
+					tt.maxBackups, tt.maxAge, config.MaxBackups, config.MaxAge)
+			}
+		})
+	}
+}
+
+// TestAdjustConfigByEnvKeepsExplicitRetention 测试配置文件中显式配置的保留策略不被环境默认值覆盖
+func TestAdjustConfigByEnvKeepsExplicitRetention(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	loader := NewConfigLoader()
+	loader.SetConfigDir(dir)
+
+	// log.yaml：dev 环境显式配置 max_backups，max_age 使用 dev 默认值
+	writeConfig("log.yaml", "service_name: svc\nenv: development\nmax_backups: 30\n")
+	config := loader.LoadConfig()
+	if config.MaxBackups != 30 || config.MaxAge != devMaxAge {
+		t.Errorf("dev: expected explicit max_backups=30 and max_age=%d, got %d %d", devMaxAge, config.MaxBackups, config.MaxAge)
+	}
+
+	// application.yaml（环境取自 ENV）：prod 环境显式配置 max_age（即使与 dev 默认值相同也保持不变）
+	t.Setenv("ENV", "prod")
+	os.Remove(filepath.Join(dir, "log.yaml"))
+	writeConfig("application.yaml", "logger:\n  max_age: 1\n")
+	config = loader.LoadConfig()
+	if config.MaxBackups != prodMaxBackups || config.MaxAge != 1 {
+		t.Errorf("prod: expected max_backups=%d and explicit max_age=1, got %d %d", prodMaxBackups, config.MaxBackups, config.MaxAge)
+	}
+}
+
+// TestAdjustConfigByEnvKeepsProgrammaticRetention 测试直接构造的配置中非 0 的保留策略不被环境默认值覆盖
+func TestAdjustConfigByEnvKeepsProgrammaticRetention(t *testing.T) {
+	config := &LogConfig{ServiceName: "svc", Env: "development", MaxAge: 7}
+	AdjustConfigByEnv(config)
+	if config.MaxBackups != devMaxBackups || config.MaxAge != 7 {
+		t.Errorf("dev: expected max_backups=%d and explicit max_age=7, got %d %d", devMaxBackups, config.MaxBackups, config.MaxAge)
+	}
+
+	config = &LogConfig{ServiceName: "svc", Env: "prod", MaxBackups: 30}
+	AdjustConfigByEnv(config)
+	if config.MaxBackups != 30 || config.MaxAge != prodMaxAge {
+		t.Errorf("prod: expected explicit max_backups=30 and max_age=%d, got %d %d", prodMaxAge, config.MaxBackups, config.MaxAge)
+	}
+}
+
+// TestLoadConfigFallbackEnvRetention 测试未配置环境且未设置 ENV 等环境变量时，回退的 dev 环境仍按生产环境保留
+func TestLoadConfigFallbackEnvRetention(t *testing.T) {
+	for _, key := range []string{"ENV", "APP_ENV", "GO_ENV", "MODE"} {
+		t.Setenv(key, "")
+	}
+	dir := t.TempDir()
+	loader := NewConfigLoader()
+	loader.SetConfigDir(dir)
+
+	// 没有配置文件
+	config := loader.LoadConfig()
+	if config.Env != "dev" || config.MaxBackups != prodMaxBackups || config.MaxAge != prodMaxAge {
+		t.Errorf("default: expected env=dev max_backups=%d max_age=%d, got %s %d %d",
+			prodMaxBackups, prodMaxAge, config.Env, config.MaxBackups, config.MaxAge)
+	}
+
+	// log.yaml 未配置 env 和保留策略
+	if err := os.WriteFile(filepath.Join(dir, "log.yaml"), []byte("service_name: svc\nlevel: INFO\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config = loader.LoadConfig()
+	if config.Env != "dev" || config.MaxBackups != prodMaxBackups || config.MaxAge != prodMaxAge {
+		t.Errorf("log.yaml: expected env=dev max_backups=%d max_age=%d, got %s %d %d",
+			prodMaxBackups, prodMaxAge, config.Env, config.MaxBackups, config.MaxAge)
+	}
+
+	// 显式配置 env: dev 时使用开发环境的保留策略
+	if err := os.WriteFile(filepath.Join(dir, "log.yaml"), []byte("service_name: svc\nenv: dev\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config = loader.LoadConfig()
+	if config.MaxBackups != devMaxBackups || config.MaxAge != devMaxAge {
+		t.Errorf("explicit dev: expected max_backups=%d max_age=%d, got %d %d", devMaxBackups, devMaxAge, config.MaxBackups, config.MaxAge)
+	}
+}
+
+// TestLoadConfigSource 测试 LoadConfig 记录配置来源，并在初始化日志中输出
+func TestLoadConfigSource(t *testing.T) {
+	dir := t.TempDir()
+	loader := NewConfigLoader()
+	loader.SetConfigDir(dir)
+	loader.SetEnv("staging")
+
+	tests := []struct {
+		file   string
+		source string
+	}{
+		{file: "", source: ConfigSourceDefault},
+		{file: "application_staging.yaml", source: ConfigSourceEnv},
+		{file: "application.yaml", source: ConfigSourceAppYAML},
+		{file: "log.yaml", source: ConfigSourceLogYAML},
+	}
+	for _, tt := range tests {
+		// 依次创建优先级更高的配置文件
+		wantFile := ""
+		if tt.file != "" {
+			wantFile = filepath.Join(dir, tt.file)
+			if err := os.WriteFile(wantFile, []byte("service_name: svc\nlogger:\n  level: WARN\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		config := loader.LoadConfig()
+		if config.Source != tt.source || config.SourceFile != wantFile {
+			t.Errorf("expected source=%s file=%s, got %s %s", tt.source, wantFile, config.Source, config.SourceFile)
+		}
+
+		buf := &bytes.Buffer{}
+		logger := zerolog.New(buf)
+		logInitialized(&logger, config)
+		if line := decodeLines(t, buf)[0]; line["config_source"] != tt.source || line["config_file"] != wantFile {
+			t.Errorf("expected init log to report source=%s file=%s, got %v %v", tt.source, wantFile, line["config_source"], line["config_file"])
+		}
+	}
+}
+
+// TestConfigSourceForFile 测试 InitLoggerFromFile 按文件名判断配置来源
+func TestConfigSourceForFile(t *testing.T) {
+	cases := map[string]string{
+		"resource/log.yaml":              ConfigSourceLogYAML,
+		"resource/application.yaml":      ConfigSourceAppYAML,
+		"resource/application_prod.yaml": ConfigSourceEnv,
+		"/etc/app/custom.yaml":           ConfigSourceAppYAML,
+	}
+	for filename, want := range cases {
+		if got := configSourceForFile(filename); got != want {
+			t.Errorf("configSourceForFile(%s) = %s, want %s", filename, got, want)
+		}
+	}
+}
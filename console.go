@@ -0,0 +1,468 @@
+package zllog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mattn/go-isatty"
+	"github.com/rs/zerolog"
+)
+
+// ============================================================================
+// 控制台输出（彩色文本格式）
+// ============================================================================
+
+// byteFieldsHintKey 日志行中 ByteSize 字段名列表的提示字段
+// 只在有彩色文本输出时由 ZerologLogger 写入，consoleWriter 读取后去掉，JSON 输出在写入前去掉（见 fieldHintStripWriter）
+const byteFieldsHintKey = "__bytes"
+
+// consoleWriter 在 zerolog.ConsoleWriter 之上增加字段的可读化渲染
+// JSON 输出（文件、ConsoleJSONFormat）保持原始数值，只有彩色文本格式会被改写：
+//   - ByteSize 类型的字段（Bytes()）显示为可读的大小（如 size=1.5MB）
+//   - error_code 以 [CODE] 形式紧跟在消息之后
+//   - ERROR 及以上级别的 error 字段紧跟在消息之后，并加粗标红
+type consoleWriter struct {
+	zerolog.ConsoleWriter
+}
+
+// ANSI 颜色（与 zerolog.ConsoleWriter 一致）
+const (
+	consoleColorRed  = 31
+	consoleColorBold = 1
+)
+
+// newConsoleWriter 创建彩色文本格式的控制台 writer
+func newConsoleWriter(out io.Writer, config *LogConfig) io.Writer {
+	w := consoleWriter{
+		ConsoleWriter: zerolog.ConsoleWriter{
+			Out:        out,
+			NoColor:    consoleNoColor(out, config),
+			TimeFormat: "2006-01-02 15:04:05",
+			FormatLevel: func(i interface{}) string {
+				return fmt.Sprintf("[%s]", strings.ToUpper(i.(string)))
+			},
+			FormatMessage: func(i interface{}) string {
+				if i == nil {
+					return ""
+				}
+				return fmt.Sprintf("%s", i)
+			},
+		},
+	}
+	applyConsoleLayout(&w.ConsoleWriter, config)
+	return w
+}
+
+// newPlainTextWriter 创建与控制台相同格式但不带颜色的 writer（用于文件输出）
+func newPlainTextWriter(out io.Writer, config *LogConfig) io.Writer {
+	w := newConsoleWriter(out, config).(consoleWriter)
+	w.NoColor = true
+	applyConsoleLayout(&w.ConsoleWriter, config)
+	return w
+}
+
+// ============================================================================
+// 控制台字段名大小写和列对齐（ConsoleKeyCase、ConsoleAlign）
+// ============================================================================
+
+// 控制台字段名的显示方式（LogConfig.ConsoleKeyCase），只影响彩色文本格式的显示，JSON 中的字段名不变
+const (
+	ConsoleKeyCaseAsIs  = "as-is" // 原样显示（默认）
+	ConsoleKeyCaseSnake = "snake" // snake_case，如 userID => user_id
+	ConsoleKeyCaseCamel = "camel" // camelCase，如 user_id => userId
+)
+
+// 列对齐时各部分的宽度（按字符数，超出时不截断）
+const (
+	consoleAlignLevelWidth   = len("[ERROR]")
+	consoleAlignCallerWidth  = 24
+	consoleAlignMessageWidth = 40
+)
+
+// ANSI 颜色（与 zerolog.ConsoleWriter 的字段名、caller 颜色一致）
+const consoleColorCyan = 36
+
+// applyConsoleLayout 按配置设置字段名大小写和列对齐，依赖 w.NoColor，修改 NoColor 后需要重新调用
+func applyConsoleLayout(w *zerolog.ConsoleWriter, config *LogConfig) {
+	noColor := w.NoColor
+	if convert := consoleKeyConverter(config.ConsoleKeyCase); convert != nil {
+		w.FormatFieldName = func(i interface{}) string {
+			return colorize(convert(fmt.Sprint(i))+"=", noColor, consoleColorCyan)
+		}
+	}
+	if !config.ConsoleAlign {
+		return
+	}
+	// 级别、caller、消息补齐到固定宽度，使消息和字段从同一列开始
+	w.FormatLevel = func(i interface{}) string {
+		return padRight(fmt.Sprintf("[%s]", strings.ToUpper(fmt.Sprint(i))), consoleAlignLevelWidth)
+	}
+	w.FormatCaller = func(i interface{}) string {
+		c, _ := i.(string)
+		if c == "" {
+			return padRight("", consoleAlignCallerWidth+len(" >"))
+		}
+		return colorize(padRight(c, consoleAlignCallerWidth), noColor, consoleColorBold) + colorize(" >", noColor, consoleColorCyan)
+	}
+	w.FormatMessage = func(i interface{}) string {
+		if i == nil {
+			return padRight("", consoleAlignMessageWidth)
+		}
+		return padRight(fmt.Sprintf("%s", i), consoleAlignMessageWidth)
+	}
+}
+
+// padRight 在 s 末尾补空格到 width 个字符
+func padRight(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
+
+// consoleKeyConverter 返回字段名转换函数，as-is 或未知的取值返回 nil（不转换）
+func consoleKeyConverter(keyCase string) func(string) string {
+	switch strings.ToLower(keyCase) {
+	case ConsoleKeyCaseSnake:
+		return toSnakeCase
+	case ConsoleKeyCaseCamel:
+		return toCamelCase
+	}
+	return nil
+}
+
+// toSnakeCase 转换为 snake_case：userID => user_id，HTTPStatus => http_status，user-name => user_name
+func toSnakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	b.Grow(len(s) + 4)
+	for i, r := range runes {
+		switch {
+		case r == '-' || r == ' ' || r == '.':
+			r = '_'
+		case unicode.IsUpper(r):
+			// 小写或数字后的大写、连续大写中最后一个（其后是小写）前面加下划线
+			if i > 0 && runes[i-1] != '_' && (!unicode.IsUpper(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// toCamelCase 转换为 camelCase：user_id => userId，user-name => userName，已是 camelCase 的保持不变
+func toCamelCase(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	upper := false
+	for _, r := range s {
+		if r == '_' || r == '-' || r == ' ' || r == '.' {
+			upper = b.Len() > 0
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		} else if b.Len() == 0 {
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// minimalExcludedFields 精简格式不输出的公共字段（时间、级别、消息、caller 本身不作为字段输出）
+var minimalExcludedFields = []string{"service", "env", "host", "trace_id", "module", "goroutine_id"}
+
+// minimalConsoleWriter 面向命令行工具的精简格式：只输出 "级别: 消息 key=value"，
+// 不输出时间、主机、服务名、trace_id 等公共字段和 GlobalFields；没有消息的日志（如初始化信息）不输出
+type minimalConsoleWriter struct {
+	consoleWriter
+}
+
+// newMinimalConsoleWriter 创建精简格式的控制台 writer
+func newMinimalConsoleWriter(out io.Writer, config *LogConfig) io.Writer {
+	w := newConsoleWriter(out, config).(consoleWriter)
+	w.PartsOrder = []string{zerolog.LevelFieldName, zerolog.MessageFieldName}
+	w.FormatLevel = func(i interface{}) string {
+		level, _ := i.(string)
+		return level + ":"
+	}
+	w.FieldsExclude = append([]string(nil), minimalExcludedFields...)
+	for key := range config.GlobalFields {
+		w.FieldsExclude = append(w.FieldsExclude, key)
+	}
+	return minimalConsoleWriter{consoleWriter: w}
+}
+
+// Write 跳过没有消息的日志，其余按精简格式输出
+func (w minimalConsoleWriter) Write(p []byte) (int, error) {
+	if !bytes.Contains(p, []byte(`"`+zerolog.MessageFieldName+`":`)) {
+		return len(p), nil
+	}
+	return w.consoleWriter.Write(p)
+}
+
+// isTerminal 判断文件是否为终端（测试中可替换）
+var isTerminal = func(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// consoleNoColor 判断控制台是否禁用颜色，优先级：
+//   1. LogConfig.ConsoleColor 显式配置
+//   2. 设置了 NO_COLOR 环境变量（https://no-color.org）时禁用
+//   3. 输出为文件（如 os.Stdout）且不是终端时禁用，避免颜色转义码混入管道和 CI 日志
+func consoleNoColor(out io.Writer, config *LogConfig) bool {
+	if config.ConsoleColor != nil {
+		return !*config.ConsoleColor
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return true
+	}
+	if f, ok := out.(*os.File); ok {
+		return !isTerminal(f)
+	}
+	return false
+}
+
+// Write 改写需要特殊渲染的字段后交给 zerolog.ConsoleWriter 渲染
+func (w consoleWriter) Write(p []byte) (int, error) {
+	if rendered, ok := w.rewrite(p); ok {
+		if _, err := w.ConsoleWriter.Write(rendered); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+	return w.ConsoleWriter.Write(p)
+}
+
+// rewrite 改写日志事件，没有需要改写的字段时返回 false，避免重复解析 JSON
+func (w consoleWriter) rewrite(p []byte) ([]byte, bool) {
+	hasByteHint := bytes.Contains(p, byteFieldsHintPrefix)
+	hasErrorCode := bytes.Contains(p, []byte(`"error_code":`))
+	hasError := bytes.Contains(p, []byte(`"error":`))
+	if !hasByteHint && !hasErrorCode && !hasError {
+		return nil, false
+	}
+
+	var evt map[string]interface{}
+	d := json.NewDecoder(bytes.NewReader(p))
+	d.UseNumber()
+	if err := d.Decode(&evt); err != nil {
+		return nil, false
+	}
+
+	changed := humanizeByteFields(evt)
+	if w.inlineErrorFields(evt) {
+		changed = true
+	}
+	if !changed {
+		return nil, false
+	}
+
+	rendered, err := json.Marshal(evt)
+	if err != nil {
+		return nil, false
+	}
+	return append(rendered, '\n'), true
+}
+
+// inlineErrorFields 将 error_code 和 ERROR 及以上级别的 error 移到消息之后
+func (w consoleWriter) inlineErrorFields(evt map[string]interface{}) bool {
+	message, _ := evt[zerolog.MessageFieldName].(string)
+	changed := false
+
+	if code, ok := evt["error_code"].(string); ok && code != "" {
+		message += " [" + code + "]"
+		delete(evt, "error_code")
+		changed = true
+	}
+
+	if errValue, ok := evt[zerolog.ErrorFieldName]; ok && isErrorLevel(evt[zerolog.LevelFieldName]) {
+		message += " " + colorize(fmt.Sprintf("error=%v", errValue), w.NoColor, consoleColorBold, consoleColorRed)
+		delete(evt, zerolog.ErrorFieldName)
+		changed = true
+	}
+
+	if changed {
+		evt[zerolog.MessageFieldName] = strings.TrimPrefix(message, " ")
+	}
+	return changed
+}
+
+// isErrorLevel 判断是否为 ERROR 及以上级别
+func isErrorLevel(level interface{}) bool {
+	switch level {
+	case zerolog.LevelErrorValue, zerolog.LevelFatalValue, zerolog.LevelPanicValue:
+		return true
+	}
+	return false
+}
+
+// colorize 为文本添加 ANSI 颜色，noColor 时原样返回
+func colorize(s string, noColor bool, codes ...int) string {
+	if noColor {
+		return s
+	}
+	for _, c := range codes {
+		s = fmt.Sprintf("\x1b[%dm%s", c, s)
+	}
+	return s + "\x1b[0m"
+}
+
+// humanizeByteFields 将提示字段中列出的 ByteSize 字段的数值替换为可读的大小（如 1.5MB），并去掉提示字段，返回是否有改写
+func humanizeByteFields(evt map[string]interface{}) bool {
+	keys, ok := evt[byteFieldsHintKey].([]interface{})
+	if !ok {
+		return false
+	}
+	delete(evt, byteFieldsHintKey)
+	for _, key := range keys {
+		name, _ := key.(string)
+		num, ok := evt[name].(json.Number)
+		if !ok {
+			continue
+		}
+		n, err := num.Int64()
+		if err != nil {
+			continue
+		}
+		evt[name] = humanizeBytes(n)
+	}
+	return true
+}
+
+// byteFieldsHintPrefix 提示字段在日志行中的起始内容
+var byteFieldsHintPrefix = []byte(`"` + byteFieldsHintKey + `":[`)
+
+// fieldHintsEnabled 是否有彩色文本输出需要 ByteSize 字段的提示（控制台或日志文件使用彩色文本格式）
+func fieldHintsEnabled(config *LogConfig) bool {
+	return config.EnableConsole && !config.ConsoleJSONFormat || strings.ToLower(config.OutputFormat) == OutputFormatConsole
+}
+
+// fieldHintStripWriter 写入前去掉日志行中的提示字段，用于 JSON/logfmt 输出，保证提示字段不会落盘
+type fieldHintStripWriter struct {
+	out io.Writer
+}
+
+// stripFieldHints 没有彩色文本输出时不会写入提示字段，原样返回 out
+func stripFieldHints(out io.Writer, config *LogConfig) io.Writer {
+	if !fieldHintsEnabled(config) {
+		return out
+	}
+	return fieldHintStripWriter{out: out}
+}
+
+func (w fieldHintStripWriter) Write(p []byte) (int, error) {
+	if _, err := w.out.Write(removeFieldHints(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w fieldHintStripWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	lw, ok := w.out.(zerolog.LevelWriter)
+	if !ok {
+		return w.Write(p)
+	}
+	if _, err := lw.WriteLevel(level, removeFieldHints(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// removeFieldHints 去掉 ,"__bytes":[...] 提示字段，没有时原样返回
+func removeFieldHints(p []byte) []byte {
+	start := bytes.Index(p, byteFieldsHintPrefix)
+	if start < 0 {
+		return p
+	}
+	// 找到数组的结束位置（字段名中可能含有转义的引号和方括号）
+	end := -1
+	inString := false
+	for i := start + len(byteFieldsHintPrefix); i < len(p); i++ {
+		switch c := p[i]; {
+		case inString && c == '\\':
+			i++
+		case c == '"':
+			inString = !inString
+		case !inString && c == ']':
+			end = i + 1
+		}
+		if end >= 0 {
+			break
+		}
+	}
+	if end < 0 {
+		return p
+	}
+	if start > 0 && p[start-1] == ',' {
+		start--
+	} else if end < len(p) && p[end] == ',' {
+		end++
+	}
+	out := make([]byte, 0, len(p)-(end-start))
+	out = append(out, p[:start]...)
+	return append(out, p[end:]...)
+}
+
+// humanizeBytes 将字节数格式化为可读的大小（1024 进制），如 1536 => 1.5KB
+func humanizeBytes(n int64) string {
+	const unit = 1024
+	units := []string{"B", "KB", "MB", "GB", "TB", "PB", "EB"}
+
+	abs := n
+	if abs < 0 {
+		abs = -abs
+	}
+	if abs < unit {
+		return strconv.FormatInt(n, 10) + "B"
+	}
+
+	value := float64(n)
+	i := 0
+	for (value >= unit || value <= -unit) && i < len(units)-1 {
+		value /= unit
+		i++
+	}
+	s := strconv.FormatFloat(value, 'f', 1, 64)
+	return strings.TrimSuffix(s, ".0") + units[i]
+}
+
+// ============================================================================
+// 按级别拆分输出（SplitStdStreams）
+// ============================================================================
+
+// levelSplitWriter 按级别把日志写到两个输出：ERROR 及以上级别写入 high，其余写入 low
+// 没有级别信息的写入（直接调用 Write）写入 low
+type levelSplitWriter struct {
+	low  io.Writer
+	high io.Writer
+}
+
+// newLevelSplitWriter 创建按级别拆分的 writer
+func newLevelSplitWriter(low, high io.Writer) zerolog.LevelWriter {
+	return levelSplitWriter{low: low, high: high}
+}
+
+// Write 实现 io.Writer 接口
+func (w levelSplitWriter) Write(p []byte) (int, error) {
+	return w.low.Write(p)
+}
+
+// WriteLevel 实现 zerolog.LevelWriter 接口
+func (w levelSplitWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	if level >= zerolog.ErrorLevel && level != zerolog.NoLevel {
+		return w.high.Write(p)
+	}
+	return w.low.Write(p)
+}
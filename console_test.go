@@ -0,0 +1,305 @@
+package zllog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// TestHumanizeBytes 测试字节数的可读化格式
+func TestHumanizeBytes(t *testing.T) {
+	cases := map[int64]string{
+		0:       "0B",
+		512:     "512B",
+		1024:    "1KB",
+		1536:    "1.5KB",
+		1572864: "1.5MB",
+		1 << 30: "1GB",
+	}
+	for n, want := range cases {
+		if got := humanizeBytes(n); got != want {
+			t.Errorf("humanizeBytes(%d) = %s, want %s", n, got, want)
+		}
+	}
+}
+
+// TestBytesFieldRendering 测试 Bytes 字段在控制台可读化、在 JSON 中保留数值，同名的普通字段不受影响
+func TestBytesFieldRendering(t *testing.T) {
+	config := &LogConfig{EnableConsole: true}
+	logger, _ := newTestLogger(t, config)
+
+	jsonBuf := &bytes.Buffer{}
+	jsonLogger := zerolog.New(createConsoleOutput(jsonBuf, &LogConfig{EnableConsole: true, ConsoleJSONFormat: true, OutputFormat: OutputFormatConsole}))
+	logger.logger = &jsonLogger
+	logger.Info(context.Background(), "upload", "payload received", Bytes("size", 1572864))
+
+	line := decodeLines(t, jsonBuf)[0]
+	if got := line["size"]; got != float64(1572864) {
+		t.Errorf("JSON should keep raw bytes, got %v", got)
+	}
+	if _, ok := line[byteFieldsHintKey]; ok {
+		t.Errorf("JSON should not contain the field hint: %v", line)
+	}
+
+	consoleBuf := &bytes.Buffer{}
+	consoleLogger := zerolog.New(newConsoleWriter(consoleBuf, config))
+	logger.logger = &consoleLogger
+	logger.Info(context.Background(), "upload", "payload received", Bytes("size", 1572864))
+	logger.Info(context.Background(), "upload", "batch received", Int64("size", 1572864))
+
+	lines := strings.Split(strings.TrimSpace(consoleBuf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 console lines, got %q", consoleBuf.String())
+	}
+	if !strings.Contains(lines[0], "1.5MB") || strings.Contains(lines[0], "1572864") || strings.Contains(lines[0], byteFieldsHintKey) {
+		t.Errorf("console should render humanized size, got %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "1572864") {
+		t.Errorf("plain field with the same name should keep its value, got %q", lines[1])
+	}
+}
+
+// TestRemoveFieldHints 测试去掉提示字段后其余内容不变
+func TestRemoveFieldHints(t *testing.T) {
+	cases := map[string]string{
+		`{"a":1,"__bytes":["size","we\"ird]"],"message":"m"}`: `{"a":1,"message":"m"}`,
+		`{"__bytes":["size"],"a":1}`:                          `{"a":1}`,
+		`{"a":1}`:                                             `{"a":1}`,
+	}
+	for in, want := range cases {
+		if got := string(removeFieldHints([]byte(in))); got != want {
+			t.Errorf("removeFieldHints(%s) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+// renderConsoleLine 以控制台格式渲染一条日志
+func renderConsoleLine(t *testing.T, log func(l *ZerologLogger)) string {
+	t.Helper()
+
+	logger, _ := newTestLogger(t, &LogConfig{})
+	buf := &bytes.Buffer{}
+	consoleLogger := zerolog.New(newConsoleWriter(buf, &LogConfig{}))
+	logger.logger = &consoleLogger
+	log(logger)
+	return buf.String()
+}
+
+// TestConsoleErrorHighlight 测试 ERROR 级别的 error 字段紧跟消息并加粗标红，error_code 内联显示
+func TestConsoleErrorHighlight(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+
+	out := renderConsoleLine(t, func(l *ZerologLogger) {
+		l.ErrorWithCode(context.Background(), "payment", "charge failed", "PAY_001", errors.New("timeout"), String("order_id", "o-1"))
+	})
+
+	want := "charge failed [PAY_001] \x1b[31m\x1b[1merror=timeout\x1b[0m"
+	if !strings.Contains(out, want) {
+		t.Errorf("expected %q in %q", want, out)
+	}
+	if strings.Count(out, "timeout") != 1 || strings.Contains(out, "error_code") {
+		t.Errorf("error fields should not be repeated: %q", out)
+	}
+	if !strings.Contains(out, "order_id=") {
+		t.Errorf("other fields should still be rendered: %q", out)
+	}
+}
+
+// TestConsoleErrorHighlightNoColor 测试 NO_COLOR 时不输出颜色
+func TestConsoleErrorHighlightNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	out := renderConsoleLine(t, func(l *ZerologLogger) {
+		l.Error(context.Background(), "payment", "charge failed", errors.New("timeout"))
+	})
+	if strings.Contains(out, "\x1b[") {
+		t.Errorf("unexpected escape codes with NO_COLOR: %q", out)
+	}
+	if !strings.Contains(out, "charge failed error=timeout") {
+		t.Errorf("expected inline error, got %q", out)
+	}
+}
+
+// TestConsoleWarnKeepsErrorField 测试 WARN 级别的 error 字段按普通字段渲染
+func TestConsoleWarnKeepsErrorField(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+
+	out := renderConsoleLine(t, func(l *ZerologLogger) {
+		l.Warn(context.Background(), "payment", "retrying", NamedErr("error", errors.New("timeout")))
+	})
+	if strings.Contains(out, "\x1b[1merror=timeout") {
+		t.Errorf("warn error should not be highlighted: %q", out)
+	}
+	if !strings.Contains(out, "timeout") {
+		t.Errorf("warn error should still be rendered: %q", out)
+	}
+}
+
+// TestConsoleNoColor 测试 NO_COLOR、非终端输出和 ConsoleColor 显式配置
+func TestConsoleNoColor(t *testing.T) {
+	file, err := os.CreateTemp(t.TempDir(), "console")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	enabled, disabled := true, false
+	tests := []struct {
+		name     string
+		noColor  string
+		out      io.Writer
+		terminal bool
+		color    *bool
+		want     bool
+	}{
+		{name: "buffer", out: &bytes.Buffer{}, want: false},
+		{name: "NO_COLOR", noColor: "1", out: &bytes.Buffer{}, want: true},
+		{name: "non-tty file", out: file, want: true},
+		{name: "tty file", out: file, terminal: true, want: false},
+		{name: "NO_COLOR on tty", noColor: "1", out: file, terminal: true, want: true},
+		{name: "explicit color overrides NO_COLOR", noColor: "1", out: file, color: &enabled, want: false},
+		{name: "explicit no color on tty", out: file, terminal: true, color: &disabled, want: true},
+	}
+
+	originalIsTerminal := isTerminal
+	defer func() {
+		isTerminal = originalIsTerminal
+	}()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			terminal := tt.terminal
+			isTerminal = func(*os.File) bool { return terminal }
+
+			if got := consoleNoColor(tt.out, &LogConfig{ConsoleColor: tt.color}); got != tt.want {
+				t.Errorf("consoleNoColor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestConsoleMinimal 测试精简格式只输出级别、消息和业务字段，不输出时间戳和服务名等公共字段
+func TestConsoleMinimal(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	config := &LogConfig{ServiceName: "cli", Env: "prod", GlobalFields: map[string]interface{}{"region": "cn"}}
+	logger, _ := newTestLogger(t, config)
+	buf := &bytes.Buffer{}
+	base := newBaseLogger(newMinimalConsoleWriter(buf, config), zerolog.TraceLevel, config)
+	logger.logger = &base
+
+	logger.Warn(context.Background(), "sync", "disk almost full", String("path", "/data"))
+	logger.Error(context.Background(), "sync", "upload failed", errors.New("timeout"))
+	// 没有消息的日志（如初始化信息）不输出
+	base.Info().Str("dir", "./logs").Send()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	want := []string{"warn: disk almost full path=/data", "error: upload failed error=timeout"}
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d lines, got %q", len(want), buf.String())
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d: expected %q, got %q", i, want[i], lines[i])
+		}
+	}
+	for _, noise := range []string{"service", "cli", "host", "trace_id", "region", "module", time.Now().Format("2006-01-02")} {
+		if strings.Contains(buf.String(), noise) {
+			t.Errorf("unexpected %q in minimal output: %q", noise, buf.String())
+		}
+	}
+}
+
+// TestSplitStdStreams 测试按级别拆分输出：ERROR 及以上写入 stderr，其余写入 stdout
+func TestSplitStdStreams(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	config := &LogConfig{ConsoleMinimal: true, SplitStdStreams: true}
+	logger, _ := newTestLogger(t, config)
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	split := newLevelSplitWriter(createConsoleOutput(stdout, config), createConsoleOutput(stderr, config))
+	base := newBaseLogger(zerolog.MultiLevelWriter(split), zerolog.TraceLevel, config)
+	logger.logger = &base
+
+	ctx := context.Background()
+	logger.Debug(ctx, "sync", "checking")
+	logger.Info(ctx, "sync", "started")
+	logger.Warn(ctx, "sync", "slow")
+	logger.Error(ctx, "sync", "failed", errors.New("timeout"))
+	func() {
+		defer func() { recover() }()
+		logger.Panic(ctx, "sync", "corrupted", nil)
+	}()
+
+	if want := "debug: checking\ninfo: started\nwarn: slow\n"; stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+	if want := "error: failed error=timeout\npanic: corrupted\n"; stderr.String() != want {
+		t.Errorf("stderr = %q, want %q", stderr.String(), want)
+	}
+}
+
+// TestConsoleKeyCase 测试字段名的 snake/camel 转换
+func TestConsoleKeyCase(t *testing.T) {
+	snake := map[string]string{
+		"userID":     "user_id",
+		"HTTPStatus": "http_status",
+		"user-name":  "user_name",
+		"user_id":    "user_id",
+		"costMs2":    "cost_ms2",
+	}
+	for in, want := range snake {
+		if got := toSnakeCase(in); got != want {
+			t.Errorf("toSnakeCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+	camel := map[string]string{
+		"user_id":   "userId",
+		"user-name": "userName",
+		"userId":    "userId",
+		"_private":  "private",
+		"Trace_ID":  "traceID",
+	}
+	for in, want := range camel {
+		if got := toCamelCase(in); got != want {
+			t.Errorf("toCamelCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	config := &LogConfig{ConsoleKeyCase: ConsoleKeyCaseCamel, ConsoleColor: new(bool)}
+	base := zerolog.New(newConsoleWriter(buf, config))
+	base.Info().Str("user_id", "u1").Msg("login")
+	if !strings.Contains(buf.String(), "userId=u1") {
+		t.Errorf("expected camelCase key in console output, got %q", buf.String())
+	}
+}
+
+// TestConsoleAlign 测试按列对齐时不同长度的级别和消息之后字段从同一列开始
+func TestConsoleAlign(t *testing.T) {
+	buf := &bytes.Buffer{}
+	config := &LogConfig{ConsoleAlign: true, ConsoleColor: new(bool)}
+	base := zerolog.New(newConsoleWriter(buf, config))
+	base.Info().Str("k", "v").Msg("short")
+	base.Error().Str("k", "v").Msg("a somewhat longer message")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %q", buf.String())
+	}
+	first, second := strings.Index(lines[0], "k=v"), strings.Index(lines[1], "k=v")
+	if first < 0 || first != second {
+		t.Errorf("expected fields aligned, got %q", lines)
+	}
+	if !strings.Contains(lines[0], "[INFO]  ") {
+		t.Errorf("expected padded level, got %q", lines[0])
+	}
+}
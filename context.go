@@ -0,0 +1,184 @@
+package zllog
+
+import "context"
+
+// ============================================================================
+// Context 辅助函数（在 context 中携带日志相关信息）
+// ============================================================================
+
+// contextKey context 键类型（避免与其他包的键冲突）
+type contextKey int
+
+const (
+	// requestIDKey request_id 的 context 键
+	requestIDKey contextKey = iota
+	// fieldsKey 上下文字段的 context 键
+	fieldsKey
+	// traceIDKey 已确定的 trace_id 的 context 键（见 FromContext）
+	traceIDKey
+	// muteKey 静默标记的 context 键（见 MuteLogging）
+	muteKey
+	// opKey 操作名的 context 键（见 WithOp）
+	opKey
+	// levelOverrideKey 请求级别日志级别覆盖的 context 键（见 WithLevelOverride）
+	levelOverrideKey
+	// parentTraceKey 父 trace_id 的 context 键（见 WithParentTrace）
+	parentTraceKey
+)
+
+// contextOrBackground 将 nil context 替换为 context.Background()
+// 开启 LogConfig.WarnNilContext 时首次遇到 nil context 输出一次 WARN，便于定位误用
+func contextOrBackground(ctx context.Context) context.Context {
+	if ctx != nil {
+		return ctx
+	}
+	if warnNilContext {
+		if _, loaded := onceKeys.LoadOrStore("nil_context", struct{}{}); !loaded {
+			Warn(context.Background(), "zllog", "nil context passed to logger, using context.Background()")
+		}
+	}
+	return context.Background()
+}
+
+// WithRequestID 将 request_id 存入 context
+// request_id 面向用户（如返回给前端排查问题），与 trace_id 相互独立
+// ZerologLogger 会自动从 context 中读取并输出 request_id 字段
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(contextOrBackground(ctx), requestIDKey, requestID)
+}
+
+// RequestIDFromContext 从 context 中获取 request_id，不存在时返回空字符串
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+// WithFields 将字段存入 context，之后使用该 context 的每条日志都会带上这些字段
+// 多次调用会合并字段，同名字段以后设置的值为准；日志调用时显式传入的同名字段优先
+//
+// 用法示例：
+//   ctx = zllog.WithFields(ctx, zllog.String("user_id", uid), zllog.String("tenant", tenant))
+//   zllog.Info(ctx, "api", "login")  // 自动带上 user_id 和 tenant
+func WithFields(ctx context.Context, fields ...Field) context.Context {
+	ctx = contextOrBackground(ctx)
+	if len(fields) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, fieldsKey, MergeFields(FieldsFromContext(ctx), fields))
+}
+
+// FieldsFromContext 从 context 中获取上下文字段，不存在时返回 nil
+// 返回的切片与 context 共享，调用方不应修改
+func FieldsFromContext(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsKey).([]Field)
+	return fields
+}
+
+// withTraceID 将 trace_id 固定在 context 中
+// TraceIDProvider 取不到 trace_id 时使用该值，保证同一个 context 的日志 trace_id 一致
+func withTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey, traceID)
+}
+
+// traceIDFromContext 获取 withTraceID 固定的 trace_id
+func traceIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	traceID, _ := ctx.Value(traceIDKey).(string)
+	return traceID
+}
+
+// MuteLogging 返回静默日志的 context，使用该 context（及其派生 context）的日志不再输出
+// 适用于批量导入等已知会产生大量无用日志的热点路径；FATAL 和 PANIC 级别不受影响
+//
+// 用法示例：
+//   importCtx := zllog.MuteLogging(ctx)
+//   for _, row := range rows {
+//       importRow(importCtx, row)  // 内部的 Info/Debug 等日志全部跳过
+//   }
+func MuteLogging(ctx context.Context) context.Context {
+	return context.WithValue(contextOrBackground(ctx), muteKey, true)
+}
+
+// isMuted 判断 context 是否被 MuteLogging 静默
+func isMuted(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	muted, _ := ctx.Value(muteKey).(bool)
+	return muted
+}
+
+// WithOp 在 context 中设置操作名，之后使用该 context 的日志都会带上 op 字段
+// 嵌套调用时以 "." 拼接外层的操作名，如 checkout 中的 payment 输出 op=checkout.payment
+// 不依赖 OpenTelemetry，适合为一段代码中的所有日志打上轻量的操作标签
+//
+// 用法示例：
+//   ctx = zllog.WithOp(ctx, "checkout")
+//   zllog.Info(ctx, "order", "cart validated")  // op=checkout
+//   payCtx := zllog.WithOp(ctx, "payment")
+//   zllog.Info(payCtx, "order", "charged")      // op=checkout.payment
+func WithOp(ctx context.Context, name string) context.Context {
+	ctx = contextOrBackground(ctx)
+	return context.WithValue(ctx, opKey, Named(OpFromContext(ctx), name))
+}
+
+// OpFromContext 从 context 中获取操作名，不存在时返回空字符串
+func OpFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	op, _ := ctx.Value(opKey).(string)
+	return op
+}
+
+// WithLevelOverride 返回降低了日志级别门槛的 context：使用该 context（及其派生 context）的日志
+// 只要不低于 level 就会输出，即使全局级别更高（如全局 INFO 时单个请求输出 DEBUG）
+// 只能放宽不能收紧：level 高于全局级别时按全局级别过滤；目前只有 ZerologLogger 支持
+//
+// 用法示例：
+//   ctx = zllog.WithLevelOverride(ctx, zllog.LevelDebug)
+//   zllog.Debug(ctx, "order", "cart detail", ...)  // 全局级别为 INFO 时仍然输出
+func WithLevelOverride(ctx context.Context, level Level) context.Context {
+	return context.WithValue(contextOrBackground(ctx), levelOverrideKey, level)
+}
+
+// LevelOverrideFromContext 返回 context 中的日志级别覆盖，未设置时第二个返回值为 false
+func LevelOverrideFromContext(ctx context.Context) (Level, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	level, ok := ctx.Value(levelOverrideKey).(Level)
+	return level, ok
+}
+
+// WithParentTrace 在 context 中记录父任务的 trace_id，之后使用该 context 的日志都会带上 parent_trace_id 字段
+// 适用于任务派生子任务时子任务使用新的 trace_id、但仍需关联到父任务的场景；parentID 为空时原样返回 ctx
+//
+// 用法示例：
+//   parentID := zllog.GetOrCreateTraceID(ctx)
+//   childCtx := zllog.WithParentTrace(newTraceCtx, parentID)
+//   zllog.Info(childCtx, "job", "sub-job started")  // trace_id=<子任务> parent_trace_id=<父任务>
+func WithParentTrace(ctx context.Context, parentID string) context.Context {
+	ctx = contextOrBackground(ctx)
+	if parentID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, parentTraceKey, parentID)
+}
+
+// ParentTraceFromContext 从 context 中获取父 trace_id，不存在时返回空字符串
+func ParentTraceFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	parentID, _ := ctx.Value(parentTraceKey).(string)
+	return parentID
+}
@@ -0,0 +1,211 @@
+package zllog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// TestRequestIDFromContext 测试 request_id 的存取
+func TestRequestIDFromContext(t *testing.T) {
+	ctx := context.Background()
+	if id := RequestIDFromContext(ctx); id != "" {
+		t.Errorf("expected empty request_id, got %q", id)
+	}
+
+	ctx = WithRequestID(ctx, "req-1")
+	if id := RequestIDFromContext(ctx); id != "req-1" {
+		t.Errorf("expected req-1, got %q", id)
+	}
+}
+
+// TestRequestIDField 测试 ZerologLogger 自动输出 context 中的 request_id
+func TestRequestIDField(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{})
+
+	logger.Info(context.Background(), "api", "without request id")
+	ctx := WithRequestID(context.Background(), "req-1")
+	logger.Info(ctx, "api", "with request id")
+	logger.InfoWithRequest(ctx, "api", "explicit request id", "req-2", 10)
+
+	lines := decodeLines(t, buf)
+	if _, ok := lines[0]["request_id"]; ok {
+		t.Errorf("request_id should be absent: %v", lines[0])
+	}
+	if lines[1]["request_id"] != "req-1" {
+		t.Errorf("expected request_id from context, got %v", lines[1]["request_id"])
+	}
+	if lines[2]["request_id"] != "req-2" {
+		t.Errorf("explicit request_id should win, got %v", lines[2]["request_id"])
+	}
+}
+
+// TestWithFields 测试多次 WithFields 合并字段且不影响父 context
+func TestWithFields(t *testing.T) {
+	parent := WithFields(context.Background(), String("a", "1"))
+	child := WithFields(parent, String("a", "2"), String("b", "3"))
+
+	if fields := FieldsFromContext(parent); len(fields) != 1 || fields[0].Value != "1" {
+		t.Errorf("parent fields modified: %v", fields)
+	}
+	fields := FieldsFromContext(child)
+	if len(fields) != 2 || fields[0].Value != "2" || fields[1].Value != "3" {
+		t.Errorf("unexpected child fields: %v", fields)
+	}
+	if FieldsFromContext(nil) != nil {
+		t.Error("expected nil fields for nil context")
+	}
+}
+
+// TestMuteLogging 测试静默 context 不输出日志，兄弟 context 正常输出
+func TestMuteLogging(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{})
+
+	parent := context.Background()
+	muted := WithRequestID(MuteLogging(parent), "req-muted")
+	sibling := WithRequestID(parent, "req-sibling")
+
+	logger.Info(muted, "import", "row imported")
+	logger.Error(muted, "import", "row failed", nil)
+	logger.Info(sibling, "import", "import started")
+
+	lines := decodeLines(t, buf)
+	if len(lines) != 1 || lines[0]["request_id"] != "req-sibling" {
+		t.Errorf("expected only sibling log, got %v", lines)
+	}
+}
+
+// TestNilContext 测试传入 nil context 时不 panic，开启 WarnNilContext 时只提示一次
+func TestNilContext(t *testing.T) {
+	withTestTraceProvider(t)
+	originalLogger := globalLoggerImpl
+	defer func() {
+		globalLoggerImpl = originalLogger
+		warnNilContext = false
+	}()
+	ResetOnce()
+	defer ResetOnce()
+
+	warnNilContext = true
+	logger, buf := newTestLogger(t, &LogConfig{EnableCtxErr: true})
+	SetLogger(logger)
+
+	var ctx context.Context
+	logger.Info(ctx, "api", "first")
+	logger.Errorf(ctx, "api", "failed: %s", errors.New("boom"), "x")
+	Info(ctx, "api", "package level")
+	if traceID := GetOrCreateTraceID(ctx); !IsValidTraceID(traceID) {
+		t.Errorf("expected generated trace_id, got %q", traceID)
+	}
+
+	lines := decodeLines(t, buf)
+	if len(lines) != 4 {
+		t.Fatalf("expected 1 warning and 3 logs, got %d: %v", len(lines), lines)
+	}
+	if lines[0]["level"] != "warn" || lines[1]["message"] != "first" {
+		t.Errorf("expected a single warning before the first log, got %v", lines)
+	}
+}
+
+// TestContextHelpersNilContext 测试 WithRequestID、WithFields、MuteLogging 传入 nil context 时不 panic
+func TestContextHelpersNilContext(t *testing.T) {
+	var ctx context.Context
+	if got := RequestIDFromContext(WithRequestID(ctx, "req-1")); got != "req-1" {
+		t.Errorf("expected request_id req-1, got %q", got)
+	}
+	if fields := FieldsFromContext(WithFields(ctx, String("a", "1"))); len(fields) != 1 {
+		t.Errorf("expected 1 field, got %v", fields)
+	}
+	if WithFields(ctx) == nil {
+		t.Error("expected non-nil context without fields")
+	}
+	if !isMuted(MuteLogging(ctx)) {
+		t.Error("expected muted context")
+	}
+}
+
+// TestWithOp 测试操作名输出为 op 字段，嵌套时以 "." 拼接
+func TestWithOp(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{})
+
+	checkout := WithOp(context.Background(), "checkout")
+	payment := WithOp(checkout, "payment")
+	refund := WithOp(WithOp(payment, ""), "refund")
+
+	logger.Info(context.Background(), "order", "no op")
+	logger.Info(checkout, "order", "cart validated")
+	logger.Info(payment, "order", "charged")
+	logger.Info(refund, "order", "refunded")
+
+	lines := decodeLines(t, buf)
+	want := []interface{}{nil, "checkout", "checkout.payment", "checkout.payment.refund"}
+	for i, op := range want {
+		if lines[i]["op"] != op {
+			t.Errorf("line %d: expected op=%v, got %v", i, op, lines[i]["op"])
+		}
+	}
+	if OpFromContext(checkout) != "checkout" {
+		t.Errorf("parent context should keep its own op, got %q", OpFromContext(checkout))
+	}
+}
+
+// TestWithLevelOverride 测试 context 中的级别覆盖只放宽当前 context 的级别门槛
+func TestWithLevelOverride(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{})
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+
+	ctx := context.Background()
+	verbose := WithLevelOverride(ctx, LevelDebug)
+	logger.Debug(ctx, "order", "hidden")
+	logger.Debug(verbose, "order", "shown")
+	logger.Trace(verbose, "order", "below override")
+	logger.Info(WithLevelOverride(ctx, LevelError), "order", "override cannot raise the threshold")
+
+	lines := decodeLines(t, buf)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %v", lines)
+	}
+	if lines[0]["message"] != "shown" || lines[0]["level"] != "debug" || lines[0]["module"] != "order" {
+		t.Errorf("unexpected override line: %v", lines[0])
+	}
+	if lines[1]["level"] != "info" {
+		t.Errorf("unexpected line: %v", lines[1])
+	}
+	if level, ok := LevelOverrideFromContext(verbose); !ok || level != LevelDebug {
+		t.Errorf("LevelOverrideFromContext = %v, %v", level, ok)
+	}
+}
+
+// TestWithParentTrace 测试子任务的日志同时带有自己的 trace_id 和父任务的 parent_trace_id
+func TestWithParentTrace(t *testing.T) {
+	withTestTraceProvider(t)
+	const parentID, childID = "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7a3ce929d0e0e4736"
+	logger, buf := newTestLogger(t, &LogConfig{})
+
+	var entry Entry
+	entryLogger := NewEntryLogger(func(ctx context.Context, e Entry) { entry = e })
+
+	parent := context.WithValue(context.Background(), testTraceKey{}, parentID)
+	child := context.WithValue(parent, testTraceKey{}, childID)
+	child = WithParentTrace(child, GetOrCreateTraceID(parent))
+
+	logger.Info(parent, "job", "spawning sub-job")
+	logger.Info(child, "job", "sub-job started")
+	entryLogger.Info(child, "job", "sub-job started")
+
+	lines := decodeLines(t, buf)
+	if lines[0]["trace_id"] != parentID || lines[0]["parent_trace_id"] != nil {
+		t.Errorf("parent operation should not have parent_trace_id, got %v", lines[0])
+	}
+	if lines[1]["trace_id"] != childID || lines[1]["parent_trace_id"] != parentID {
+		t.Errorf("expected child and parent trace ids, got %v", lines[1])
+	}
+	if entry.TraceID != childID || entry.ParentTraceID != parentID {
+		t.Errorf("expected entry to carry both trace ids, got %+v", entry)
+	}
+	if WithParentTrace(parent, "") != parent {
+		t.Errorf("empty parent id should return ctx unchanged")
+	}
+}
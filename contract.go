@@ -0,0 +1,124 @@
+package zllog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ============================================================================
+// LogContract - 日志输出格式约定校验
+// ============================================================================
+
+// DefaultContractKeys 每条日志都必须包含的字段
+var DefaultContractKeys = []string{"time", "level", "service", "trace_id", "module"}
+
+// ContractViolation 一条不符合约定的日志
+type ContractViolation struct {
+	Line    string   // 原始日志行
+	Missing []string // 缺失的字段（无法解析为 JSON 时为空）
+	Err     error    // JSON 解析错误
+}
+
+// Error 实现 error 接口
+func (v ContractViolation) Error() string {
+	if v.Err != nil {
+		return fmt.Sprintf("log line is not valid JSON (%v): %s", v.Err, v.Line)
+	}
+	return fmt.Sprintf("log line missing %s: %s", strings.Join(v.Missing, ", "), v.Line)
+}
+
+// LogContract 校验每条 JSON 日志是否包含约定的字段
+// 作为 io.Writer 挂到 zerolog 的输出上（如通过 zerolog.MultiLevelWriter 与其他输出并列），
+// 用于在测试中防止重构 ZerologLogger 时遗漏 trace_id、module 等字段
+//
+// 用法示例：
+//   contract := zllog.NewLogContract()
+//   logger := zerolog.New(contract).With().Timestamp().Str("service", "svc").Logger()
+//   zllog.SetLogger(zllog.NewZerologLogger(&logger))
+//   // ... 执行业务代码 ...
+//   if err := contract.Err(); err != nil {
+//       t.Fatal(err)
+//   }
+//
+// Write 不会返回校验错误，避免影响 MultiLevelWriter 中的其他输出；违反约定的日志通过 Err 获取
+type LogContract struct {
+	keys []string
+
+	mu         sync.Mutex
+	violations []ContractViolation
+}
+
+// NewLogContract 创建日志约定校验器，不传 keys 时使用 DefaultContractKeys
+func NewLogContract(keys ...string) *LogContract {
+	if len(keys) == 0 {
+		keys = DefaultContractKeys
+	}
+	return &LogContract{keys: append([]string(nil), keys...)}
+}
+
+// Write 校验写入的每一行日志
+func (c *LogContract) Write(p []byte) (int, error) {
+	for _, line := range bytes.Split(p, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		if err := c.Check(line); err != nil {
+			c.mu.Lock()
+			c.violations = append(c.violations, err.(ContractViolation))
+			c.mu.Unlock()
+		}
+	}
+	return len(p), nil
+}
+
+// Check 校验单行日志，不符合约定时返回 ContractViolation
+func (c *LogContract) Check(line []byte) error {
+	var entry map[string]json.RawMessage
+	if err := json.Unmarshal(line, &entry); err != nil {
+		return ContractViolation{Line: string(line), Err: err}
+	}
+
+	var missing []string
+	for _, key := range c.keys {
+		if _, ok := entry[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return ContractViolation{Line: string(line), Missing: missing}
+	}
+	return nil
+}
+
+// Violations 返回目前为止所有不符合约定的日志
+func (c *LogContract) Violations() []ContractViolation {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]ContractViolation(nil), c.violations...)
+}
+
+// Err 返回所有违反约定的错误（合并为一个 error），全部符合时返回 nil
+func (c *LogContract) Err() error {
+	violations := c.Violations()
+	if len(violations) == 0 {
+		return nil
+	}
+	errs := make([]error, len(violations))
+	for i, v := range violations {
+		errs[i] = v
+	}
+	return errors.Join(errs...)
+}
+
+// Reset 清空已记录的违反约定的日志
+func (c *LogContract) Reset() {
+	c.mu.Lock()
+	c.violations = nil
+	c.mu.Unlock()
+}
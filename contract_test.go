@@ -0,0 +1,75 @@
+package zllog
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// newContractLogger 创建输出到 LogContract 的 ZerologLogger（与 InitLoggerWithConfig 相同的公共字段）
+func newContractLogger(t *testing.T, contract *LogContract) *ZerologLogger {
+	t.Helper()
+
+	originalLevel := zerolog.GlobalLevel()
+	zerolog.SetGlobalLevel(zerolog.TraceLevel)
+	t.Cleanup(func() {
+		zerolog.SetGlobalLevel(originalLevel)
+	})
+
+	logger := zerolog.New(contract).With().Timestamp().Str("service", "svc").Logger()
+	return newZerologLoggerWithConfig(&logger, &LogConfig{})
+}
+
+// TestLogContract 测试 ZerologLogger 的输出满足默认约定
+func TestLogContract(t *testing.T) {
+	contract := NewLogContract()
+	logger := newContractLogger(t, contract)
+	ctx := context.Background()
+
+	logger.Info(ctx, "api", "request handled", String("path", "/"))
+	logger.ErrorWithCode(ctx, "payment", "charge failed", "PAY_001", errors.New("timeout"))
+	logger.InfoWithRequestf(ctx, "api", "done in %dms", "req-1", 10, 10)
+
+	if err := contract.Err(); err != nil {
+		t.Errorf("unexpected contract violation: %v", err)
+	}
+}
+
+// TestLogContractMissingModule 测试缺少 module 时违反约定
+func TestLogContractMissingModule(t *testing.T) {
+	contract := NewLogContract()
+	logger := newContractLogger(t, contract)
+
+	// 模拟重构时遗漏了 module 字段
+	logger.logger.Info().Str("trace_id", "trace-1").Msg("no module")
+
+	violations := contract.Violations()
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d", len(violations))
+	}
+	if !reflect.DeepEqual(violations[0].Missing, []string{"module"}) {
+		t.Errorf("expected missing [module], got %v", violations[0].Missing)
+	}
+	if contract.Err() == nil {
+		t.Error("expected contract error")
+	}
+
+	contract.Reset()
+	if err := contract.Err(); err != nil {
+		t.Errorf("expected no error after reset, got %v", err)
+	}
+}
+
+// TestLogContractInvalidJSON 测试非 JSON 日志行违反约定
+func TestLogContractInvalidJSON(t *testing.T) {
+	contract := NewLogContract("module")
+	contract.Write([]byte("{\"module\":\"api\"}\nplain text\n"))
+
+	violations := contract.Violations()
+	if len(violations) != 1 || violations[0].Err == nil {
+		t.Errorf("expected 1 JSON violation, got %v", violations)
+	}
+}
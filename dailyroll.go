@@ -0,0 +1,287 @@
+package zllog
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// ============================================================================
+// 日期滚动（每天一个日志文件，app.log 软链接指向当天的文件）
+// ============================================================================
+
+// dailyFileDateFormat 日期滚动文件名中的日期格式（app-2006-01-02.log）
+const dailyFileDateFormat = "2006-01-02"
+
+// dailyRollWriter 按日期滚动的日志 writer
+//
+// 每天写入 app-2006-01-02.log，当天文件超过 MaxSize 时仍由 lumberjack 按大小轮转；
+// 日期变化后的第一次写入切换到新文件，并：
+//   - 更新 app.log 软链接指向当天的文件（tail -f app.log 跨天不中断需配合 tail -F）
+//   - 开启 Compress 时在后台压缩前一天的文件为 .gz
+//   - 配置了 MaxAge 时清理超过保留天数的日期文件
+//   - 配置了 MaxBackups 时只保留最新的 MaxBackups 个历史文件（跨所有日期统计，不含当天正在写入的文件）
+//
+// 启动后的第一次写入同样在后台处理上次运行遗留的文件（如进程在压缩完成前退出），
+// 压缩之前日期未压缩的文件并按 MaxAge、MaxBackups 清理。
+//
+// 不支持软链接的平台（或没有权限）上跳过软链接，日志照常写入日期文件。
+type dailyRollWriter struct {
+	config LogConfig
+	link   string // 软链接路径（LogDir/app.log）
+	now    func() time.Time
+
+	mu      sync.Mutex
+	day     string
+	current io.WriteCloser
+}
+
+// newDailyRollWriter 创建日期滚动 writer
+// 已存在的 app.log 是普通文件时（未启用日期滚动时写入的），先将其改名为按修改日期命名的历史文件
+func newDailyRollWriter(config *LogConfig, link string) *dailyRollWriter {
+	w := &dailyRollWriter{config: *config, link: link, now: time.Now}
+	if info, err := os.Lstat(link); err == nil && info.Mode().IsRegular() {
+		os.Rename(link, w.legacyName(info.ModTime()))
+	}
+	return w
+}
+
+// Write 写入当天的日志文件，日期变化时先切换文件
+func (w *dailyRollWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if day := w.now().Format(dailyFileDateFormat); day != w.day {
+		w.roll(day)
+	}
+	return w.current.Write(p)
+}
+
+// Close 关闭当前日志文件
+func (w *dailyRollWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.current == nil {
+		return nil
+	}
+	err := w.current.Close()
+	w.current = nil
+	w.day = ""
+	return err
+}
+
+// roll 切换到指定日期的日志文件
+func (w *dailyRollWriter) roll(day string) {
+	previous := w.day
+	if w.current != nil {
+		w.current.Close()
+	}
+
+	path := w.dailyName(day)
+	prepareLogFile(&w.config, path)
+	rotator := &lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    w.config.MaxSize,
+		MaxBackups: w.config.MaxBackups,
+		MaxAge:     w.config.MaxAge,
+		Compress:   w.config.Compress,
+	}
+	w.current = rotator
+	if archiveDir := resolveArchiveDir(&w.config); archiveDir != "" {
+		w.current = newArchiveWriter(rotator, archiveDir, w.config.DirMode)
+	}
+	w.day = day
+
+	updateSymlink(w.link, filepath.Base(path))
+
+	if previous == "" {
+		go w.cleanupLeftovers(day)
+	} else {
+		go w.cleanup(w.dailyName(previous), day)
+	}
+}
+
+// cleanup 压缩前一天的日志文件并清理过期的日期文件
+func (w *dailyRollWriter) cleanup(previous, today string) {
+	if w.config.Compress {
+		if err := compressFile(previous); err != nil {
+			fmt.Fprintf(os.Stderr, "zllog: compress %s: %v\n", previous, err)
+		}
+	}
+	w.removeExpired(today)
+}
+
+// cleanupLeftovers 启动时压缩之前日期遗留的未压缩文件并清理过期的日期文件
+func (w *dailyRollWriter) cleanupLeftovers(today string) {
+	if w.config.Compress {
+		for _, f := range w.dailyFiles() {
+			if f.day < today && !strings.HasSuffix(f.path, compressSuffix) {
+				if err := compressFile(f.path); err != nil {
+					fmt.Fprintf(os.Stderr, "zllog: compress %s: %v\n", f.path, err)
+				}
+			}
+		}
+	}
+	w.removeExpired(today)
+}
+
+// removeExpired 删除超过 MaxAge 天的日期文件，再按日期和修改时间只保留最新的 MaxBackups 个历史文件
+// 当天正在写入的文件不参与清理
+func (w *dailyRollWriter) removeExpired(today string) {
+	if w.config.MaxAge <= 0 && w.config.MaxBackups <= 0 {
+		return
+	}
+
+	current := w.dailyName(today)
+	var cutoff string
+	if w.config.MaxAge > 0 {
+		cutoff = w.now().AddDate(0, 0, -w.config.MaxAge).Format(dailyFileDateFormat)
+	}
+	var backups []dailyFile
+	for _, f := range w.dailyFiles() {
+		if f.path == current {
+			continue
+		}
+		if f.day < cutoff {
+			os.Remove(f.path)
+			continue
+		}
+		backups = append(backups, f)
+	}
+
+	if w.config.MaxBackups <= 0 || len(backups) <= w.config.MaxBackups {
+		return
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		if backups[i].day != backups[j].day {
+			return backups[i].day > backups[j].day
+		}
+		return backups[i].modTime.After(backups[j].modTime)
+	})
+	for _, f := range backups[w.config.MaxBackups:] {
+		os.Remove(f.path)
+	}
+}
+
+// dailyFile 日志目录中的一个日期文件（含按大小轮转的历史文件和 .gz）
+type dailyFile struct {
+	path    string
+	day     string
+	modTime time.Time
+}
+
+// dailyFiles 列出日志目录中的日期文件
+func (w *dailyRollWriter) dailyFiles() []dailyFile {
+	dir := filepath.Dir(w.link)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var files []dailyFile
+	for _, e := range entries {
+		day, ok := w.fileDay(e.Name())
+		if !ok || !e.Type().IsRegular() {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, dailyFile{path: filepath.Join(dir, e.Name()), day: day, modTime: info.ModTime()})
+	}
+	return files
+}
+
+// dailyName 返回指定日期的日志文件路径（LogDir/app-2006-01-02.log）
+func (w *dailyRollWriter) dailyName(day string) string {
+	ext := filepath.Ext(w.link)
+	return strings.TrimSuffix(w.link, ext) + "-" + day + ext
+}
+
+// legacyName 返回旧 app.log 改名后的路径
+// 对应日期的文件不存在时直接使用日期文件名，否则使用该日期文件的 lumberjack 历史文件名
+func (w *dailyRollWriter) legacyName(modTime time.Time) string {
+	path := w.dailyName(modTime.Format(dailyFileDateFormat))
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return path
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + modTime.Format(backupTimeFormat) + ext
+}
+
+// fileDay 从日期文件名（含按大小轮转的历史文件和 .gz）中解析日期
+func (w *dailyRollWriter) fileDay(name string) (string, bool) {
+	ext := filepath.Ext(w.link)
+	prefix := strings.TrimSuffix(filepath.Base(w.link), ext) + "-"
+	if !strings.HasPrefix(name, prefix) || len(name) < len(prefix)+len(dailyFileDateFormat) {
+		return "", false
+	}
+	day := name[len(prefix) : len(prefix)+len(dailyFileDateFormat)]
+	if _, err := time.Parse(dailyFileDateFormat, day); err != nil {
+		return "", false
+	}
+	return day, true
+}
+
+// updateSymlink 将 link 原子地指向 target（相对路径）
+// 先创建临时软链接再 rename 覆盖，读取方不会看到软链接不存在的中间状态
+// 创建失败时（如 Windows 没有权限）静默跳过
+func updateSymlink(link, target string) {
+	if current, err := os.Readlink(link); err == nil && current == target {
+		return
+	}
+	tmp := link + ".tmp"
+	os.Remove(tmp)
+	if err := os.Symlink(target, tmp); err != nil {
+		return
+	}
+	if err := os.Rename(tmp, link); err != nil {
+		os.Remove(tmp)
+	}
+}
+
+// compressFile 将文件压缩为 .gz 并删除原文件
+func compressFile(path string) (err error) {
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(path+compressSuffix, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := out.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(path + compressSuffix)
+		}
+	}()
+
+	gz := gzip.NewWriter(out)
+	if _, err = io.Copy(gz, in); err != nil {
+		return err
+	}
+	if err = gz.Close(); err != nil {
+		return err
+	}
+	// 压缩文件沿用原文件的修改时间，便于按时间清理
+	os.Chtimes(path+compressSuffix, info.ModTime(), info.ModTime())
+	return os.Remove(path)
+}
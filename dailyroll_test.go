@@ -0,0 +1,89 @@
+package zllog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestDailyRollSymlink 测试日期变化后切换文件，app.log 软链接指向最新的日期文件
+func TestDailyRollSymlink(t *testing.T) {
+	dir := t.TempDir()
+	link := filepath.Join(dir, "app.log")
+
+	// 未启用日期滚动时留下的普通文件
+	if err := os.WriteFile(link, []byte("legacy\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	legacyTime := time.Date(2025, 1, 25, 12, 0, 0, 0, time.Local)
+	os.Chtimes(link, legacyTime, legacyTime)
+
+	now := time.Date(2025, 1, 26, 23, 59, 0, 0, time.Local)
+	w := newDailyRollWriter(&LogConfig{LogDir: dir, MaxSize: 1, Compress: true}, link)
+	w.now = func() time.Time { return now }
+	defer w.Close()
+	// 第一次写入后遗留的文件会在后台被压缩，改名结果在写入前检查
+	if data, err := os.ReadFile(filepath.Join(dir, "app-2025-01-25.log")); err != nil || string(data) != "legacy\n" {
+		t.Errorf("legacy app.log should be renamed by its date, got %q (err: %v)", data, err)
+	}
+
+	w.Write([]byte("day1\n"))
+	if target, err := os.Readlink(link); err != nil || target != "app-2025-01-26.log" {
+		t.Fatalf("expected symlink to day1 file, got %q (err: %v)", target, err)
+	}
+
+	// 模拟跨天
+	now = now.Add(2 * time.Minute)
+	w.Write([]byte("day2\n"))
+	if target, err := os.Readlink(link); err != nil || target != "app-2025-01-27.log" {
+		t.Fatalf("expected symlink to day2 file, got %q (err: %v)", target, err)
+	}
+	if data, err := os.ReadFile(link); err != nil || string(data) != "day2\n" {
+		t.Errorf("reading through symlink = %q (err: %v)", data, err)
+	}
+
+	// 前一天的文件在后台压缩
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "app-2025-01-26.log.gz")); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected previous day file to be compressed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// TestDailyRollLeftovers 测试启动时压缩之前日期遗留的未压缩文件，MaxBackups 跨日期生效
+func TestDailyRollLeftovers(t *testing.T) {
+	dir := t.TempDir()
+	link := filepath.Join(dir, "app.log")
+	for i, name := range []string{"app-2025-01-20.log", "app-2025-01-21.log", "app-2025-01-22.log.gz", "app-2025-01-23.log"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("old\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		modTime := time.Date(2025, 1, 20+i, 23, 0, 0, 0, time.Local)
+		os.Chtimes(path, modTime, modTime)
+	}
+
+	w := newDailyRollWriter(&LogConfig{LogDir: dir, MaxSize: 1, MaxBackups: 2, Compress: true}, link)
+	w.now = func() time.Time { return time.Date(2025, 1, 26, 8, 0, 0, 0, time.Local) }
+	defer w.Close()
+	w.cleanupLeftovers("2025-01-26")
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	want := []string{"app-2025-01-22.log.gz", "app-2025-01-23.log.gz"}
+	if len(names) != len(want) || names[0] != want[0] || names[1] != want[1] {
+		t.Errorf("expected %v to remain, got %v", want, names)
+	}
+}
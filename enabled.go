@@ -0,0 +1,40 @@
+package zllog
+
+import "context"
+
+// ============================================================================
+// 级别判断（构造开销较大的字段之前提前判断日志是否会输出）
+// ============================================================================
+
+// Enabled 判断 level 级别（如 "debug"，不区分大小写）的日志是否可能输出，无法识别的级别返回 false
+// 与 Lazy 字段不同，适合一次构造很多字段的场景：
+//   if zllog.Enabled("debug") {
+//       zllog.Debug(ctx, "sync", "batch detail", heavyFields()...)
+//   }
+//
+// 不区分 module 和 context，需要考虑 module 过滤、级别覆盖和采样时使用 EnabledFor
+func Enabled(level string) bool {
+	return EnabledFor(context.Background(), "", level)
+}
+
+// EnabledFor 与 Enabled 相同，同时考虑 ctx 和 module：
+//   - MuteLogging 静默、WithLevelOverride 级别覆盖、BufferedContext 缓冲
+//   - FilteredLogger 的级别和 module 白名单、MultiLogger 中任一 Logger 输出即为 true
+//   - ModuleEnvAllowlist、TraceSampleRate（context 中有 trace_id 时）
+//
+// 突发采样和限流是有状态的，判断时不消耗配额，因此返回 true 的日志仍可能被丢弃
+func EnabledFor(ctx context.Context, module, level string) bool {
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return false
+	}
+	return loggerEnabled(getLogger(), ctx, module, lvl)
+}
+
+// loggerEnabled 判断 Logger 是否会输出该日志，未实现 LevelEnabler 时只比较全局级别
+func loggerEnabled(l Logger, ctx context.Context, module string, level Level) bool {
+	if le, ok := l.(LevelEnabler); ok {
+		return le.Enabled(ctx, module, level)
+	}
+	return level >= GetLevel()
+}
@@ -0,0 +1,97 @@
+package zllog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// TestEnabled 测试按全局级别判断各级别是否输出
+func TestEnabled(t *testing.T) {
+	logger, _ := newTestLogger(t, &LogConfig{})
+	defer SwapLogger(logger)()
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+
+	for level, want := range map[string]bool{
+		"trace": false, "DEBUG": false, "info": true, "warning": true, "error": true, "fatal": true, "bogus": false,
+	} {
+		if got := Enabled(level); got != want {
+			t.Errorf("Enabled(%q) = %v, want %v", level, got, want)
+		}
+	}
+
+	SetLevel(LevelTrace)
+	if !Enabled("trace") {
+		t.Error("expected trace enabled after SetLevel(LevelTrace)")
+	}
+
+	// 未实现 LevelEnabler 的 Logger 只比较全局级别
+	SwapLogger(&MockLogger{})
+	SetLevel(LevelWarn)
+	if Enabled("info") || !Enabled("warn") {
+		t.Error("expected fallback to compare with the global level")
+	}
+}
+
+// TestEnabledFor 测试 module 过滤、context 级别覆盖、静默和采样
+func TestEnabledFor(t *testing.T) {
+	withTestTraceProvider(t)
+	logger, _ := newTestLogger(t, &LogConfig{
+		Env:                "dev",
+		ModuleEnvAllowlist: map[string][]string{"payment.card": {"prod"}},
+		TraceSampleRate:    0.5,
+	})
+	defer SwapLogger(logger)()
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+
+	ctx := context.Background()
+	if !EnabledFor(ctx, "order", "info") || EnabledFor(ctx, "order", "debug") {
+		t.Error("expected global level to apply to order")
+	}
+	if EnabledFor(ctx, "payment.card", "error") {
+		t.Error("expected module suppressed by ModuleEnvAllowlist")
+	}
+	if !EnabledFor(WithLevelOverride(ctx, LevelDebug), "order", "debug") {
+		t.Error("expected WithLevelOverride to enable debug")
+	}
+	if EnabledFor(MuteLogging(ctx), "order", "error") || !EnabledFor(MuteLogging(ctx), "order", "fatal") {
+		t.Error("expected muted context to disable all but fatal")
+	}
+	if !EnabledFor(nil, "order", "info") {
+		t.Error("expected nil context to be treated as background")
+	}
+
+	// 同一 trace 的判断结果与实际采样一致，ERROR 不参与采样
+	var kept, dropped int
+	for i := 0; i < 50; i++ {
+		traceCtx := context.WithValue(ctx, testTraceKey{}, newTraceID())
+		if EnabledFor(traceCtx, "order", "info") {
+			kept++
+		} else {
+			dropped++
+		}
+		if !EnabledFor(traceCtx, "order", "error") {
+			t.Fatal("expected error to bypass trace sampling")
+		}
+	}
+	if kept == 0 || dropped == 0 {
+		t.Errorf("expected trace sampling to drop some traces, kept=%d dropped=%d", kept, dropped)
+	}
+
+	// FilteredLogger 的级别和 module 白名单
+	remote := NewFilteredLogger(logger, "ERROR", "payment")
+	SwapLogger(remote)
+	if EnabledFor(ctx, "order", "error") || EnabledFor(ctx, "payment", "warn") || !EnabledFor(ctx, "payment.refund", "error") {
+		t.Error("expected FilteredLogger level and module filters to apply")
+	}
+	if !(&ScopedLogger{module: "payment"}).Enabled(ctx, "error") {
+		t.Error("expected ScopedLogger to check its module")
+	}
+
+	// MultiLogger 中任一 Logger 输出即可
+	SwapLogger(NewMultiLogger(remote, NewFilteredLogger(logger, "INFO", "order")))
+	if !EnabledFor(ctx, "order", "info") || EnabledFor(ctx, "payment", "info") || !EnabledFor(ctx, "payment", "error") {
+		t.Error("expected MultiLogger to enable when any logger is enabled")
+	}
+}
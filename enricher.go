@@ -0,0 +1,95 @@
+package zllog
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// ============================================================================
+// ContextEnricher - 从 context 中提取任意字段附加到每条日志
+// ============================================================================
+
+// ContextEnricher 从 context 中提取需要附加到日志的字段（如认证主体、租户）
+// 每条日志都会调用，应尽量快且不修改 ctx；没有可提取的内容时返回 nil
+type ContextEnricher func(ctx context.Context) []Field
+
+// enricherEntry 包装 ContextEnricher 以便按指针注销
+type enricherEntry struct {
+	fn ContextEnricher
+}
+
+var (
+	enrichersMu sync.Mutex
+	enrichers   atomic.Value // []*enricherEntry，写时复制，日志热路径无锁读取
+)
+
+// RegisterContextEnricher 注册 context 字段提取函数，ZerologLogger 和 EntryLogger 输出每条日志时调用
+// 多个 enricher 按注册顺序执行，提取的字段优先级低于 WithFields 设置的字段和调用时显式传入的字段；
+// 后注册的 enricher 与先注册的同名时，以后注册的为准
+// 返回的函数用于注销
+//
+// 用法示例：
+//   zllog.RegisterContextEnricher(func(ctx context.Context) []zllog.Field {
+//       if subject, ok := auth.SubjectFromContext(ctx); ok {
+//           return []zllog.Field{zllog.String("subject", subject)}
+//       }
+//       return nil
+//   })
+func RegisterContextEnricher(fn ContextEnricher) (unregister func()) {
+	entry := &enricherEntry{fn: fn}
+
+	enrichersMu.Lock()
+	current := loadEnrichers()
+	next := make([]*enricherEntry, 0, len(current)+1)
+	next = append(next, current...)
+	next = append(next, entry)
+	enrichers.Store(next)
+	enrichersMu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { removeEnricher(entry) })
+	}
+}
+
+// removeEnricher 注销 enricher
+func removeEnricher(entry *enricherEntry) {
+	enrichersMu.Lock()
+	defer enrichersMu.Unlock()
+
+	current := loadEnrichers()
+	next := make([]*enricherEntry, 0, len(current))
+	for _, item := range current {
+		if item != entry {
+			next = append(next, item)
+		}
+	}
+	enrichers.Store(next)
+}
+
+// loadEnrichers 返回当前注册的 enricher 列表（只读）
+func loadEnrichers() []*enricherEntry {
+	current, _ := enrichers.Load().([]*enricherEntry)
+	return current
+}
+
+// contextFields 返回 context 中需要附加到日志的字段：enricher 提取的字段与 WithFields 设置的字段合并，
+// 同名时 WithFields 的为准；没有注册 enricher 时直接返回 FieldsFromContext 的结果，不产生额外分配
+func contextFields(ctx context.Context) []Field {
+	ctxFields := FieldsFromContext(ctx)
+	list := loadEnrichers()
+	if len(list) == 0 {
+		return ctxFields
+	}
+
+	var enriched []Field
+	for _, item := range list {
+		enriched = append(enriched, item.fn(ctx)...)
+	}
+	if len(enriched) == 0 {
+		return ctxFields
+	}
+	// MergeFields 同时处理 enricher 之间的同名字段（后注册的为准）
+	return MergeFields(enriched, ctxFields)
+}
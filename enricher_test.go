@@ -0,0 +1,42 @@
+package zllog
+
+import (
+	"context"
+	"testing"
+)
+
+type subjectKey struct{}
+
+// TestContextEnricher 测试多个 enricher 按注册顺序附加字段，WithFields 和显式字段优先
+func TestContextEnricher(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{})
+
+	removeSubject := RegisterContextEnricher(func(ctx context.Context) []Field {
+		if subject, ok := ctx.Value(subjectKey{}).(string); ok {
+			return []Field{String("subject", subject), String("tenant", "from-subject")}
+		}
+		return nil
+	})
+	removeTenant := RegisterContextEnricher(func(ctx context.Context) []Field {
+		return []Field{String("tenant", "acme"), String("region", "cn")}
+	})
+
+	ctx := context.WithValue(context.Background(), subjectKey{}, "user-1")
+	logger.Info(ctx, "api", "enriched")
+	logger.Info(WithFields(ctx, String("region", "us")), "api", "overridden", String("tenant", "explicit"))
+
+	removeSubject()
+	removeTenant()
+	logger.Info(ctx, "api", "unregistered")
+
+	lines := decodeLines(t, buf)
+	if lines[0]["subject"] != "user-1" || lines[0]["tenant"] != "acme" || lines[0]["region"] != "cn" {
+		t.Errorf("unexpected enriched fields: %v", lines[0])
+	}
+	if lines[1]["tenant"] != "explicit" || lines[1]["region"] != "us" {
+		t.Errorf("context and explicit fields should win: %v", lines[1])
+	}
+	if _, ok := lines[2]["subject"]; ok {
+		t.Errorf("expected no enrichment after unregister: %v", lines[2])
+	}
+}
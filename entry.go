@@ -0,0 +1,293 @@
+package zllog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ============================================================================
+// Entry - 日志条目（网络类适配器共用的序列化格式）
+// ============================================================================
+
+// Entry 一条日志的完整内容
+// Kafka、HTTP 等需要自行序列化日志的适配器统一使用此结构和 EncodeJSON，
+// 保证各个通道输出的 JSON 格式一致
+type Entry struct {
+	Time          time.Time `json:"time"`
+	Level         string    `json:"level"`
+	Service       string    `json:"service,omitempty"`
+	TraceID       string    `json:"trace_id"`
+	ParentTraceID string    `json:"parent_trace_id,omitempty"`
+	Module        string    `json:"module"`
+	Message       string    `json:"message"`
+	Error         string    `json:"error,omitempty"`
+	ErrorCode     string    `json:"error_code,omitempty"`
+	RequestID     string    `json:"request_id,omitempty"`
+	CostMs        int64     `json:"cost_ms,omitempty"`
+	Fields        []Field   `json:"-"`
+}
+
+// encodedEntry Entry 的 JSON 结构（Fields 转换为对象）
+type encodedEntry struct {
+	Entry
+	Fields map[string]interface{} `json:"fields,omitempty"`
+}
+
+// EncodeJSON 将日志条目编码为 JSON（不含换行符）
+// 结构化字段放在 fields 对象中，避免与 time、level 等保留字段冲突
+func EncodeJSON(e Entry) []byte {
+	data, err := json.Marshal(encodedEntry{Entry: e, Fields: FieldsToMap(e.Fields)})
+	if err != nil {
+		// 字段中有无法序列化的值（如 channel、func），降级为字符串形式
+		data, _ = json.Marshal(encodedEntry{Entry: e, Fields: stringifyFields(e.Fields)})
+	}
+	return data
+}
+
+// FieldsToMap 将字段转换为可 JSON 序列化的 map
+// error 转为错误信息，[]byte 作为原始 JSON，Dict 转为嵌套对象，Array 转为数组，Lazy 字段在此时求值
+func FieldsToMap(fields []Field) map[string]interface{} {
+	if len(fields) == 0 {
+		return nil
+	}
+	m := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		m[f.Key] = fieldValue(f.Value)
+	}
+	return m
+}
+
+// fieldValue 将单个字段值转换为可 JSON 序列化的值
+func fieldValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case error:
+		return val.Error()
+	case []byte:
+		if !json.Valid(val) {
+			return string(val)
+		}
+		return json.RawMessage(val)
+	case time.Duration:
+		// 与 zerolog 一致，以毫秒数输出
+		return float64(val) / float64(time.Millisecond)
+	case []Field:
+		m := FieldsToMap(val)
+		if m == nil {
+			m = map[string]interface{}{}
+		}
+		return m
+	case LazyValue:
+		return fieldValue(val())
+	case FieldArray:
+		arr := make([]interface{}, len(val))
+		for i, item := range val {
+			arr[i] = fieldValue(item.Value)
+		}
+		return arr
+	default:
+		return val
+	}
+}
+
+// stringifyFields 将所有字段值转换为字符串
+func stringifyFields(fields []Field) map[string]interface{} {
+	if len(fields) == 0 {
+		return nil
+	}
+	m := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		m[f.Key] = fmt.Sprint(f.Value)
+	}
+	return m
+}
+
+// ============================================================================
+// EntryLogger - 基于 Entry 的 Logger 实现
+// ============================================================================
+
+// EntryLogger 将每次日志调用组装为 Entry 后交给 handler 处理的 Logger 实现
+// 自行序列化日志的适配器（Kafka、HTTP 等）只需实现 handler，
+// trace_id、request_id、服务名等公共字段由 EntryLogger 统一填充，低于全局级别的日志不会交给 handler
+type EntryLogger struct {
+	handle func(ctx context.Context, e Entry)
+
+	// OnFatal 在 Fatal 退出进程之前调用（如投递缓冲中的日志）
+	OnFatal func()
+}
+
+// NewEntryLogger 创建基于 Entry 的 Logger
+func NewEntryLogger(handle func(ctx context.Context, e Entry)) *EntryLogger {
+	return &EntryLogger{handle: handle}
+}
+
+// log 组装 Entry 并交给 handler
+func (l *EntryLogger) log(ctx context.Context, level Level, module, message string, err error, e Entry, fields []Field) {
+	ctx = contextOrBackground(ctx)
+	// 未启用的级别在组装 Entry 之前跳过，避免网络类适配器序列化和投递被过滤的日志
+	if !l.Enabled(ctx, module, level) {
+		return
+	}
+
+	e.Time = Now()
+	e.Level = level.zerologLevel().String()
+	e.Service = serviceName
+	e.TraceID = GetOrCreateTraceID(ctx)
+	e.ParentTraceID = ParentTraceFromContext(ctx)
+	e.Module = module
+	e.Message = message
+	if err != nil {
+		e.Error = err.Error()
+		// 结构化错误展开为 err_detail 对象（复制切片，不修改调用方的字段）
+		if detail, ok := errorDetail(err); ok {
+			fields = append(fields[:len(fields):len(fields)], detail)
+		}
+	}
+	if e.RequestID == "" {
+		e.RequestID = RequestIDFromContext(ctx)
+	}
+	e.Fields = fields
+	if ctxFields := contextFields(ctx); len(ctxFields) > 0 {
+		// Entry 可能被 handler 异步持有，这里不使用字段切片池
+		e.Fields = MergeFields(ctxFields, fields)
+	}
+	l.handle(ctx, e)
+}
+
+// Enabled 实现 LevelEnabler：静默的 context 只输出 FATAL 和 PANIC，其余按全局级别判断（WithLevelOverride 可放宽门槛）
+func (l *EntryLogger) Enabled(ctx context.Context, module string, level Level) bool {
+	ctx = contextOrBackground(ctx)
+	if level < LevelFatal && isMuted(ctx) {
+		return false
+	}
+	if level >= GetLevel() {
+		return true
+	}
+	override, ok := LevelOverrideFromContext(ctx)
+	return ok && level >= override
+}
+
+// fatal 处理 Fatal 的退出流程
+func (l *EntryLogger) fatal() {
+	if l.OnFatal != nil {
+		l.OnFatal()
+	}
+	exitFunc(1)
+}
+
+// Trace logs a message at TRACE level
+func (l *EntryLogger) Trace(ctx context.Context, module, message string, fields ...Field) {
+	l.log(ctx, LevelTrace, module, message, nil, Entry{}, fields)
+}
+
+// Debug logs a message at DEBUG level
+func (l *EntryLogger) Debug(ctx context.Context, module, message string, fields ...Field) {
+	l.log(ctx, LevelDebug, module, message, nil, Entry{}, fields)
+}
+
+// Info logs a message at INFO level
+func (l *EntryLogger) Info(ctx context.Context, module, message string, fields ...Field) {
+	l.log(ctx, LevelInfo, module, message, nil, Entry{}, fields)
+}
+
+// Warn logs a message at WARN level
+func (l *EntryLogger) Warn(ctx context.Context, module, message string, fields ...Field) {
+	l.log(ctx, LevelWarn, module, message, nil, Entry{}, fields)
+}
+
+// Error logs a message at ERROR level with error info
+func (l *EntryLogger) Error(ctx context.Context, module, message string, err error, fields ...Field) {
+	l.log(ctx, LevelError, module, message, err, Entry{}, fields)
+}
+
+// ErrorWithCode logs a message at ERROR level with error code
+func (l *EntryLogger) ErrorWithCode(ctx context.Context, module, message, errorCode string, err error, fields ...Field) {
+	l.log(ctx, LevelError, module, message, err, Entry{ErrorCode: errorCode}, fields)
+}
+
+// Fatal logs a message at FATAL level and exits
+func (l *EntryLogger) Fatal(ctx context.Context, module, message string, err error, fields ...Field) {
+	l.log(ctx, LevelFatal, module, message, err, Entry{}, fields)
+	l.fatal()
+}
+
+// FatalNoExit logs a message at FATAL level without exiting
+// 与 Fatal 一样会调用 OnFatal（如投递缓冲中的日志），只是不退出进程
+func (l *EntryLogger) FatalNoExit(ctx context.Context, module, message string, err error, fields ...Field) {
+	l.log(ctx, LevelFatal, module, message, err, Entry{}, fields)
+	if l.OnFatal != nil {
+		l.OnFatal()
+	}
+}
+
+// Panic logs a message at PANIC level and then panics
+func (l *EntryLogger) Panic(ctx context.Context, module, message string, err error, fields ...Field) {
+	l.log(ctx, LevelPanic, module, message, err, Entry{}, fields)
+	panic(message)
+}
+
+// InfoWithRequest INFO日志 + request_id + cost_ms
+func (l *EntryLogger) InfoWithRequest(ctx context.Context, module, message, requestID string, costMs int64, fields ...Field) {
+	l.log(ctx, LevelInfo, module, message, nil, Entry{RequestID: requestID, CostMs: costMs}, fields)
+}
+
+// ErrorWithRequest ERROR日志 + request_id + cost_ms
+func (l *EntryLogger) ErrorWithRequest(ctx context.Context, module, message, requestID string, err error, costMs int64, fields ...Field) {
+	l.log(ctx, LevelError, module, message, err, Entry{RequestID: requestID, CostMs: costMs}, fields)
+}
+
+// Tracef logs a formatted message at TRACE level
+func (l *EntryLogger) Tracef(ctx context.Context, module, format string, args ...interface{}) {
+	message, fields := formatMessage(format, args)
+	l.log(ctx, LevelTrace, module, message, nil, Entry{}, fields)
+}
+
+// Debugf logs a formatted message at DEBUG level
+func (l *EntryLogger) Debugf(ctx context.Context, module, format string, args ...interface{}) {
+	message, fields := formatMessage(format, args)
+	l.log(ctx, LevelDebug, module, message, nil, Entry{}, fields)
+}
+
+// Infof logs a formatted message at INFO level
+func (l *EntryLogger) Infof(ctx context.Context, module, format string, args ...interface{}) {
+	message, fields := formatMessage(format, args)
+	l.log(ctx, LevelInfo, module, message, nil, Entry{}, fields)
+}
+
+// Warnf logs a formatted message at WARN level
+func (l *EntryLogger) Warnf(ctx context.Context, module, format string, args ...interface{}) {
+	message, fields := formatMessage(format, args)
+	l.log(ctx, LevelWarn, module, message, nil, Entry{}, fields)
+}
+
+// Errorf logs a formatted message at ERROR level with error info
+func (l *EntryLogger) Errorf(ctx context.Context, module, format string, err error, args ...interface{}) {
+	message, fields := formatMessage(format, args)
+	l.log(ctx, LevelError, module, message, err, Entry{}, fields)
+}
+
+// ErrorWithCodef logs a formatted message at ERROR level with error code
+func (l *EntryLogger) ErrorWithCodef(ctx context.Context, module, format string, errorCode string, err error, args ...interface{}) {
+	message, fields := formatMessage(format, args)
+	l.log(ctx, LevelError, module, message, err, Entry{ErrorCode: errorCode}, fields)
+}
+
+// Fatalf logs a formatted message at FATAL level and exits
+func (l *EntryLogger) Fatalf(ctx context.Context, module, format string, err error, args ...interface{}) {
+	message, fields := formatMessage(format, args)
+	l.log(ctx, LevelFatal, module, message, err, Entry{}, fields)
+	l.fatal()
+}
+
+// InfoWithRequestf INFO日志 + request_id + cost_ms (formatted)
+func (l *EntryLogger) InfoWithRequestf(ctx context.Context, module, format string, requestID string, costMs int64, args ...interface{}) {
+	message, fields := formatMessage(format, args)
+	l.log(ctx, LevelInfo, module, message, nil, Entry{RequestID: requestID, CostMs: costMs}, fields)
+}
+
+// ErrorWithRequestf ERROR日志 + request_id + cost_ms (formatted)
+func (l *EntryLogger) ErrorWithRequestf(ctx context.Context, module, format string, requestID string, err error, costMs int64, args ...interface{}) {
+	message, fields := formatMessage(format, args)
+	l.log(ctx, LevelError, module, message, err, Entry{RequestID: requestID, CostMs: costMs}, fields)
+}
@@ -0,0 +1,223 @@
+package zllog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestEncodeJSON 测试 Entry 的公共字段编码
+func TestEncodeJSON(t *testing.T) {
+	e := Entry{
+		Time:      time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Level:     "error",
+		Service:   "svc",
+		TraceID:   "trace-1",
+		Module:    "payment",
+		Message:   "charge failed",
+		Error:     "timeout",
+		ErrorCode: "PAY_001",
+		RequestID: "req-1",
+		CostMs:    12,
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(EncodeJSON(e), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	want := map[string]interface{}{
+		"time":       "2024-01-02T03:04:05Z",
+		"level":      "error",
+		"service":    "svc",
+		"trace_id":   "trace-1",
+		"module":     "payment",
+		"message":    "charge failed",
+		"error":      "timeout",
+		"error_code": "PAY_001",
+		"request_id": "req-1",
+		"cost_ms":    float64(12),
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s: expected %v, got %v", k, v, got[k])
+		}
+	}
+	if _, ok := got["fields"]; ok {
+		t.Error("fields should be omitted when empty")
+	}
+}
+
+// TestEncodeJSONFields 测试所有 Field 值类型的编码
+func TestEncodeJSONFields(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	e := Entry{Fields: []Field{
+		String("string", "s"),
+		Int("int", 1),
+		Int64("int64", 2),
+		Uint("uint", 3),
+		Uint64("uint64", 4),
+		Float64("float64", 1.5),
+		Bool("bool", true),
+		Time("time", now),
+		Dur("duration", 1500*time.Millisecond),
+		NamedErr("err", errors.New("boom")),
+		Any("any", []int{1, 2}),
+		Dict("dict", String("a", "x"), Dict("inner", Int("b", 2))),
+		Array("array", String("", "x"), Int("", 1)),
+		RawJSON("raw", []byte(`{"n":1}`)),
+		Bytes("bytes", 2048),
+	}}
+
+	var got struct {
+		Fields map[string]interface{} `json:"fields"`
+	}
+	if err := json.Unmarshal(EncodeJSON(e), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	f := got.Fields
+	checks := map[string]interface{}{
+		"string":   "s",
+		"int":      float64(1),
+		"int64":    float64(2),
+		"uint":     float64(3),
+		"uint64":   float64(4),
+		"float64":  1.5,
+		"bool":     true,
+		"time":     "2024-01-02T03:04:05Z",
+		"duration": float64(1500),
+		"err":      "boom",
+		"bytes":    float64(2048),
+	}
+	for k, v := range checks {
+		if f[k] != v {
+			t.Errorf("%s: expected %v (%T), got %v (%T)", k, v, v, f[k], f[k])
+		}
+	}
+
+	if anyVal, _ := f["any"].([]interface{}); len(anyVal) != 2 {
+		t.Errorf("any: unexpected %v", f["any"])
+	}
+	dict, _ := f["dict"].(map[string]interface{})
+	inner, _ := dict["inner"].(map[string]interface{})
+	if dict["a"] != "x" || inner["b"] != float64(2) {
+		t.Errorf("dict: unexpected %v", f["dict"])
+	}
+	if raw, _ := f["raw"].(map[string]interface{}); raw["n"] != float64(1) {
+		t.Errorf("raw: unexpected %v", f["raw"])
+	}
+	arr, _ := f["array"].([]interface{})
+	if len(arr) != 2 || arr[0] != "x" || arr[1] != float64(1) {
+		t.Errorf("array: unexpected %v", f["array"])
+	}
+}
+
+// TestEncodeJSONUnsupportedValue 测试无法序列化的字段值降级为字符串
+func TestEncodeJSONUnsupportedValue(t *testing.T) {
+	e := Entry{Message: "m", Fields: []Field{Any("ch", make(chan int)), String("k", "v")}}
+
+	var got struct {
+		Message string                 `json:"message"`
+		Fields  map[string]interface{} `json:"fields"`
+	}
+	if err := json.Unmarshal(EncodeJSON(e), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got.Message != "m" || got.Fields["k"] != "v" {
+		t.Errorf("unexpected entry: %+v", got)
+	}
+	if _, ok := got.Fields["ch"].(string); !ok {
+		t.Errorf("unsupported value should be stringified, got %v", got.Fields["ch"])
+	}
+}
+
+// TestEntryLogger 测试 EntryLogger 填充公共字段后交给 handler
+func TestEntryLogger(t *testing.T) {
+	withTestTraceProvider(t)
+
+	var entries []Entry
+	logger := NewEntryLogger(func(ctx context.Context, e Entry) {
+		entries = append(entries, e)
+	})
+
+	ctx := WithRequestID(context.WithValue(context.Background(), testTraceKey{}, "0af7651916cd43dd8448eb211c80319c"), "req-ctx")
+	logger.ErrorWithCode(ctx, "payment", "charge failed", "PAY_001", errors.New("timeout"), String("k", "v"))
+	logger.InfoWithRequest(ctx, "api", "done", "req-1", 5)
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	e := entries[0]
+	if e.Level != "error" || e.TraceID != "0af7651916cd43dd8448eb211c80319c" || e.ErrorCode != "PAY_001" ||
+		e.Error != "timeout" || e.RequestID != "req-ctx" || len(e.Fields) != 1 {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+	if e := entries[1]; e.RequestID != "req-1" || e.CostMs != 5 {
+		t.Errorf("explicit request_id should win: %+v", e)
+	}
+}
+
+// TestEntryLoggerLevel 测试低于全局级别的日志不交给 handler，WithLevelOverride 可放宽门槛
+func TestEntryLoggerLevel(t *testing.T) {
+	original := GetLevel()
+	SetLevel(LevelInfo)
+	defer SetLevel(original)
+
+	var levels []string
+	logger := NewEntryLogger(func(ctx context.Context, e Entry) {
+		levels = append(levels, e.Level)
+	})
+
+	ctx := context.Background()
+	logger.Debug(ctx, "api", "filtered")
+	logger.Debugf(ctx, "api", "filtered %d", 1)
+	logger.Info(ctx, "api", "kept")
+	logger.Debug(WithLevelOverride(ctx, LevelDebug), "api", "override")
+
+	if want := []string{"info", "debug"}; len(levels) != len(want) || levels[0] != want[0] || levels[1] != want[1] {
+		t.Errorf("expected levels %v, got %v", want, levels)
+	}
+	if logger.Enabled(ctx, "api", LevelDebug) || !logger.Enabled(ctx, "api", LevelWarn) {
+		t.Error("Enabled should follow the global level")
+	}
+}
+
+// TestAddFieldsDictArray 测试 ZerologLogger 输出 Dict 为对象、Array 为数组
+func TestAddFieldsDictArray(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{})
+
+	logger.Info(context.Background(), "test", "nested",
+		Dict("dict", String("a", "x")),
+		Array("array", Int("", 1), Int("", 2)))
+
+	line := decodeLines(t, buf)[0]
+	if dict, _ := line["dict"].(map[string]interface{}); dict["a"] != "x" {
+		t.Errorf("dict: unexpected %v", line["dict"])
+	}
+	if arr, _ := line["array"].([]interface{}); len(arr) != 2 || arr[1] != float64(2) {
+		t.Errorf("array: unexpected %v", line["array"])
+	}
+}
+
+// TestEncodeJSONLazyField 测试 Lazy 字段在编码时求值
+func TestEncodeJSONLazyField(t *testing.T) {
+	e := Entry{Fields: []Field{
+		Lazy("lazy", func() interface{} { return 42 }),
+		Array("arr", Lazy("", func() interface{} { return "x" })),
+	}}
+
+	var got struct {
+		Fields map[string]interface{} `json:"fields"`
+	}
+	if err := json.Unmarshal(EncodeJSON(e), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got.Fields["lazy"] != float64(42) {
+		t.Errorf("lazy: unexpected %v", got.Fields["lazy"])
+	}
+	if arr, _ := got.Fields["arr"].([]interface{}); len(arr) != 1 || arr[0] != "x" {
+		t.Errorf("arr: unexpected %v", got.Fields["arr"])
+	}
+}
@@ -0,0 +1,103 @@
+package zllog
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ============================================================================
+// ErrorMarshaler - 将结构化错误展开为字段
+// ============================================================================
+
+// errorDetailKey 展开后的错误字段所在的嵌套对象名（err_detail.*）
+const errorDetailKey = "err_detail"
+
+// ErrorMarshaler 从 error 中提取结构化字段，展开到日志的 err_detail 对象中
+// 不认识的 error 返回 nil；error 字段本身仍按 err.Error() 输出
+type ErrorMarshaler func(err error) []Field
+
+// FieldsError 携带结构化字段的 error 可以实现该接口，无需注册 ErrorMarshaler 即可展开字段
+// 错误链中（errors.As）任意一层实现了该接口都会生效
+type FieldsError interface {
+	error
+	ErrorFields() []Field
+}
+
+// errorMarshalerEntry 包装 ErrorMarshaler 以便按指针注销
+type errorMarshalerEntry struct {
+	fn ErrorMarshaler
+}
+
+var (
+	errorMarshalersMu sync.Mutex
+	errorMarshalers   atomic.Value // []*errorMarshalerEntry，写时复制，日志热路径无锁读取
+)
+
+// RegisterErrorMarshaler 注册错误字段提取函数，ZerologLogger 和 EntryLogger 输出带 error 的日志时调用
+// 后注册的先执行，第一个返回非空字段的结果生效；都返回空时再检查 FieldsError 接口
+// 返回的函数用于注销
+//
+// 用法示例：
+//   zllog.RegisterErrorMarshaler(func(err error) []zllog.Field {
+//       var apiErr *client.APIError
+//       if errors.As(err, &apiErr) {
+//           return []zllog.Field{zllog.Int("status", apiErr.Status), zllog.String("endpoint", apiErr.Endpoint)}
+//       }
+//       return nil
+//   })
+//   // 输出 "error":"...","err_detail":{"status":503,"endpoint":"/v1/pay"}
+func RegisterErrorMarshaler(fn ErrorMarshaler) (unregister func()) {
+	entry := &errorMarshalerEntry{fn: fn}
+
+	errorMarshalersMu.Lock()
+	current := loadErrorMarshalers()
+	next := make([]*errorMarshalerEntry, 0, len(current)+1)
+	next = append(next, current...)
+	next = append(next, entry)
+	errorMarshalers.Store(next)
+	errorMarshalersMu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { removeErrorMarshaler(entry) })
+	}
+}
+
+// removeErrorMarshaler 注销 ErrorMarshaler
+func removeErrorMarshaler(entry *errorMarshalerEntry) {
+	errorMarshalersMu.Lock()
+	defer errorMarshalersMu.Unlock()
+
+	current := loadErrorMarshalers()
+	next := make([]*errorMarshalerEntry, 0, len(current))
+	for _, item := range current {
+		if item != entry {
+			next = append(next, item)
+		}
+	}
+	errorMarshalers.Store(next)
+}
+
+// loadErrorMarshalers 返回当前注册的 ErrorMarshaler 列表（只读）
+func loadErrorMarshalers() []*errorMarshalerEntry {
+	current, _ := errorMarshalers.Load().([]*errorMarshalerEntry)
+	return current
+}
+
+// errorDetail 返回 err 展开后的 err_detail 字段，没有可展开的内容时返回 false
+func errorDetail(err error) (Field, bool) {
+	list := loadErrorMarshalers()
+	for i := len(list) - 1; i >= 0; i-- {
+		if fields := list[i].fn(err); len(fields) > 0 {
+			return Dict(errorDetailKey, fields...), true
+		}
+	}
+	var fe FieldsError
+	if errors.As(err, &fe) {
+		if fields := fe.ErrorFields(); len(fields) > 0 {
+			return Dict(errorDetailKey, fields...), true
+		}
+	}
+	return Field{}, false
+}
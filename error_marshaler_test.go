@@ -0,0 +1,92 @@
+package zllog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// fieldsTestError 携带结构化字段的错误
+type fieldsTestError struct {
+	field  string
+	reason string
+}
+
+func (e *fieldsTestError) Error() string {
+	return "invalid " + e.field + ": " + e.reason
+}
+
+func (e *fieldsTestError) ErrorFields() []Field {
+	return []Field{String("field", e.field), String("reason", e.reason)}
+}
+
+// upstreamError 没有实现 FieldsError 的错误，通过 RegisterErrorMarshaler 展开
+type upstreamError struct {
+	status int
+}
+
+func (e *upstreamError) Error() string {
+	return fmt.Sprintf("upstream returned %d", e.status)
+}
+
+// TestErrorMarshaler 测试结构化错误展开为 err_detail 对象
+func TestErrorMarshaler(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{})
+
+	unregister := RegisterErrorMarshaler(func(err error) []Field {
+		var ue *upstreamError
+		if errors.As(err, &ue) {
+			return []Field{Int("status", ue.status)}
+		}
+		return nil
+	})
+
+	wrapped := fmt.Errorf("create order: %w", &fieldsTestError{field: "qty", reason: "must be positive"})
+	logger.Error(context.Background(), "order", "rejected", wrapped)
+	logger.ErrorWithCode(context.Background(), "order", "upstream failed", "E_UPSTREAM", &upstreamError{status: 503})
+	logger.Error(context.Background(), "order", "plain", errors.New("boom"))
+	unregister()
+	logger.Error(context.Background(), "order", "unregistered", &upstreamError{status: 502})
+
+	lines := decodeLines(t, buf)
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines, got %d", len(lines))
+	}
+	detail, _ := lines[0]["err_detail"].(map[string]interface{})
+	if detail["field"] != "qty" || detail["reason"] != "must be positive" {
+		t.Errorf("expected FieldsError expanded through wrapping, got %v", lines[0])
+	}
+	if lines[0]["error"] != wrapped.Error() {
+		t.Errorf("error message should be kept, got %v", lines[0]["error"])
+	}
+	detail, _ = lines[1]["err_detail"].(map[string]interface{})
+	if detail["status"] != float64(503) || lines[1]["error_code"] != "E_UPSTREAM" {
+		t.Errorf("expected registered marshaler expanded, got %v", lines[1])
+	}
+	if _, ok := lines[2]["err_detail"]; ok {
+		t.Errorf("plain error should not have err_detail: %v", lines[2])
+	}
+	if _, ok := lines[3]["err_detail"]; ok {
+		t.Errorf("expected no err_detail after unregister: %v", lines[3])
+	}
+}
+
+// TestErrorMarshalerEntryLogger 测试 EntryLogger 将 err_detail 附加到字段且不修改调用方的切片
+func TestErrorMarshalerEntryLogger(t *testing.T) {
+	var got Entry
+	logger := NewEntryLogger(func(ctx context.Context, e Entry) { got = e })
+
+	fields := make([]Field, 1, 4)
+	fields[0] = String("order_id", "o1")
+	logger.Error(context.Background(), "order", "rejected", &fieldsTestError{field: "qty", reason: "zero"}, fields...)
+
+	m := FieldsToMap(got.Fields)
+	detail, _ := m["err_detail"].(map[string]interface{})
+	if detail["field"] != "qty" || m["order_id"] != "o1" {
+		t.Errorf("unexpected entry fields: %v", m)
+	}
+	if extra := fields[:2][1]; extra.Key != "" {
+		t.Errorf("caller's slice should not be modified, got %v", extra)
+	}
+}
@@ -0,0 +1,52 @@
+package zllog
+
+import (
+	"expvar"
+	"sync"
+)
+
+// ============================================================================
+// expvar 指标（由 LogConfig.EnableExpvar 开启）
+// ============================================================================
+
+// expvarName 发布到 expvar 的变量名（/debug/vars 中的 key）
+const expvarName = "zllog"
+
+var expvarOnce sync.Once
+
+// publishExpvar 将日志统计发布为 expvar.Map，多次调用只发布一次
+// 各项在读取时实时计算，包括：
+//   - levels：各级别的日志调用次数（同 Stats().Levels）
+//   - dropped / sampled：被丢弃、被采样过滤的日志条数
+//   - buffer_depth：异步缓冲区中待写入的日志条数（未启用异步写入时为 0）
+//   - healthy / last_error：日志通道的健康状态和最近的错误（同 Healthy、LastError）
+func publishExpvar() {
+	expvarOnce.Do(func() {
+		m := new(expvar.Map).Init()
+		m.Set("levels", expvar.Func(func() interface{} {
+			return Stats().Levels
+		}))
+		m.Set("dropped", expvar.Func(func() interface{} {
+			return Stats().Dropped
+		}))
+		m.Set("sampled", expvar.Func(func() interface{} {
+			return Stats().Sampled
+		}))
+		m.Set("buffer_depth", expvar.Func(func() interface{} {
+			if globalAsyncWriter == nil {
+				return 0
+			}
+			return globalAsyncWriter.Len()
+		}))
+		m.Set("healthy", expvar.Func(func() interface{} {
+			return Healthy()
+		}))
+		m.Set("last_error", expvar.Func(func() interface{} {
+			if err := LastError(); err != nil {
+				return err.Error()
+			}
+			return ""
+		}))
+		expvar.Publish(expvarName, m)
+	})
+}
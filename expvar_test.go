@@ -0,0 +1,48 @@
+package zllog
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"testing"
+)
+
+// TestExpvar 测试日志统计发布到 expvar 后可以读取
+func TestExpvar(t *testing.T) {
+	originalLogger := globalLoggerImpl
+	defer func() {
+		globalLoggerImpl = originalLogger
+	}()
+
+	SetLogger(&MockLogger{})
+	resetStats()
+	defer resetStats()
+	publishExpvar()
+	publishExpvar() // 重复发布不会 panic
+
+	ctx := context.Background()
+	Info(ctx, "test", "info message")
+	Warn(ctx, "test", "warn message")
+	Warn(ctx, "test", "warn message")
+
+	v := expvar.Get("zllog")
+	if v == nil {
+		t.Fatal("expected zllog to be published")
+	}
+	var got struct {
+		Levels      map[string]uint64 `json:"levels"`
+		Dropped     uint64            `json:"dropped"`
+		BufferDepth int               `json:"buffer_depth"`
+		Healthy     bool              `json:"healthy"`
+		LastError   string            `json:"last_error"`
+	}
+	if err := json.Unmarshal([]byte(v.String()), &got); err != nil {
+		t.Fatalf("invalid expvar JSON %s: %v", v.String(), err)
+	}
+	if got.Levels["INFO"] != 1 || got.Levels["WARN"] != 2 {
+		t.Errorf("unexpected level counts: %v", got.Levels)
+	}
+	if !got.Healthy || got.LastError != "" || got.Dropped != 0 {
+		t.Errorf("unexpected health metrics: %+v", got)
+	}
+}
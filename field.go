@@ -1,6 +1,15 @@
 package zllog
 
-import "time"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+)
 
 // ============================================================================
 // 结构化日志字段（完整支持 Zerolog 所有类型）
@@ -107,6 +116,12 @@ func Time(key string, value time.Time) Field {
 	return Field{Key: key, Value: value}
 }
 
+// TimeLayout 创建按指定格式输出的时间字段（输出为字符串，不受全局 TimeFieldFormat 影响）
+// 适用于只需要日期等特定格式的字段，如 TimeLayout("birthday", t, "2006-01-02")
+func TimeLayout(key string, value time.Time, layout string) Field {
+	return Field{Key: key, Value: value.Format(layout)}
+}
+
 // Dur 创建时间间隔字段（duration）
 func Dur(key string, value time.Duration) Field {
 	return Field{Key: key, Value: value}
@@ -150,7 +165,164 @@ func Dict(key string, f ...Field) Field {
 	return Field{Key: key, Value: f}
 }
 
-// Array 创建数组字段
+// Array 创建数组字段（只使用各字段的值，忽略 Key）
 func Array(key string, f ...Field) Field {
-	return Field{Key: key, Value: f}
+	return Field{Key: key, Value: FieldArray(f)}
+}
+
+// GeoPoint 创建经纬度字段，输出为嵌套对象 {"lat": 纬度, "lng": 经度}
+//   zllog.GeoPoint("location", 31.2304, 121.4737)  // "location":{"lat":31.2304,"lng":121.4737}
+func GeoPoint(key string, lat, lng float64) Field {
+	return Dict(key, Float64("lat", lat), Float64("lng", lng))
+}
+
+// ValidationErrors 创建字段级校验错误，输出为嵌套对象 {"字段名": "错误信息"}，字段名按字典序排列
+// 适用于记录 422 响应：
+//   zllog.Warn(ctx, "api", "validation failed", zllog.Int("status", 422),
+//       zllog.ValidationErrors("validation_errors", map[string]string{"email": "invalid format", "age": "must be >= 18"}))
+//   // "validation_errors":{"age":"must be >= 18","email":"invalid format"}
+func ValidationErrors(key string, errs map[string]string) Field {
+	names := make([]string, 0, len(errs))
+	for name := range errs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fields := make([]Field, len(names))
+	for i, name := range names {
+		fields[i] = String(name, errs[name])
+	}
+	return Dict(key, fields...)
+}
+
+// HTTPStatus 创建 HTTP 状态码字段：status（数值）和 status_class（如 2xx、4xx、5xx），便于按类别过滤
+// 返回多个字段，需要展开传入：
+//   zllog.Info(ctx, "http", "request", zllog.HTTPStatus(404)...)  // status=404 status_class=4xx
+func HTTPStatus(code int) Fields {
+	return Fields{Int("status", code), String("status_class", StatusClass(code))}
+}
+
+// StatusClass 返回 HTTP 状态码的类别（1xx~5xx），不在 100~599 范围内时返回 "unknown"
+func StatusClass(code int) string {
+	if code < 100 || code > 599 {
+		return "unknown"
+	}
+	return strconv.Itoa(code/100) + "xx"
+}
+
+// FieldArray Array 字段的值类型，用于和 Dict 的 []Field 区分
+// 自定义 Logger 实现应将 []Field 输出为对象、FieldArray 输出为数组
+type FieldArray []Field
+
+// Objects 创建对象数组字段，每个元素通过 MarshalZerologObject 输出为一个 JSON 对象，不使用反射
+// 适合批量记录实现了 zerolog.LogObjectMarshaler 的领域对象：
+//   zllog.Info(ctx, "order", "batch created", zllog.Objects("orders", []zerolog.LogObjectMarshaler{o1, o2}))
+//   // "orders":[{"id":"o1","qty":1},{"id":"o2","qty":3}]
+func Objects(key string, ms []zerolog.LogObjectMarshaler) Field {
+	return Field{Key: key, Value: ObjectArray(ms)}
+}
+
+// ObjectArray Objects 字段的值类型，实现了 zerolog.LogArrayMarshaler
+// 自定义 Logger 实现可调用 MarshalJSON 获取 JSON 数组
+type ObjectArray []zerolog.LogObjectMarshaler
+
+// MarshalZerologArray 将每个元素作为对象追加到数组
+func (a ObjectArray) MarshalZerologArray(arr *zerolog.Array) {
+	for _, m := range a {
+		arr.Object(m)
+	}
+}
+
+// MarshalJSON 以 JSON 数组输出（与 ZerologLogger 的输出一致）
+func (a ObjectArray) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+	logger.Log().Array("v", a).Send()
+	var wrapper struct {
+		V json.RawMessage `json:"v"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &wrapper); err != nil {
+		return nil, err
+	}
+	return wrapper.V, nil
+}
+
+// Lazy 创建延迟求值字段，fn 只在日志真正输出时才会被调用
+// 适合计算开销较大的字段（如序列化大对象），级别被过滤或条件不满足时不会产生开销
+func Lazy(key string, fn func() interface{}) Field {
+	return Field{Key: key, Value: LazyValue(fn)}
+}
+
+// LazyValue Lazy 字段的值类型
+// 自定义 Logger 实现应调用它获取实际的值
+type LazyValue func() interface{}
+
+// ============================================================================
+// 字段集合
+// ============================================================================
+
+// Fields 可复用的字段集合（如用户上下文字段）
+// Fields 的底层类型是 []Field，可以直接展开传给任何接收 ...Field 的方法：
+//   userFields := zllog.Fields{zllog.String("user_id", uid), zllog.String("tenant", tenant)}
+//   zllog.Info(ctx, "api", "login", userFields...)
+type Fields []Field
+
+// Merge 返回追加了 fields 的新集合，不修改原集合（可安全地在多处复用同一个 Fields）
+func (f Fields) Merge(fields ...Field) Fields {
+	merged := make(Fields, 0, len(f)+len(fields))
+	merged = append(merged, f...)
+	return append(merged, fields...)
+}
+
+// MergeFields 合并两组字段并按 key 去重，同名字段以后出现的值为准
+// 结果按每个 key 第一次出现的位置排序，a、b 均不会被修改
+func MergeFields(a, b []Field) []Field {
+	merged := make([]Field, 0, len(a)+len(b))
+	index := make(map[string]int, len(a)+len(b))
+	for _, fields := range [][]Field{a, b} {
+		for _, field := range fields {
+			if i, ok := index[field.Key]; ok {
+				merged[i] = field
+				continue
+			}
+			index[field.Key] = len(merged)
+			merged = append(merged, field)
+		}
+	}
+	return merged
+}
+
+// formatMessage 格式化 *f 方法的消息，args 末尾连续的 Field 作为结构化字段而不参与格式化
+// 例如 Infof(ctx, "order", "created %d items", n, zllog.String("user_id", uid))
+// 只有末尾的 Field 会被拆出，格式参数中间的 Field 仍按 %v 格式化
+func formatMessage(format string, args []interface{}) (string, []Field) {
+	n := len(args)
+	for n > 0 {
+		if _, ok := args[n-1].(Field); !ok {
+			break
+		}
+		n--
+	}
+	if n == len(args) {
+		return fmt.Sprintf(format, args...), nil
+	}
+
+	fields := make([]Field, 0, len(args)-n)
+	for _, arg := range args[n:] {
+		fields = append(fields, arg.(Field))
+	}
+	return fmt.Sprintf(format, args[:n]...), fields
+}
+
+// ============================================================================
+// 带渲染语义的字段
+// ============================================================================
+
+// ByteSize 字节大小，作为字段值时 JSON 中输出原始字节数，彩色控制台输出显示为可读的大小
+type ByteSize int64
+
+// Bytes 创建字节大小字段
+// JSON 输出保留原始字节数（便于聚合计算），彩色控制台输出显示为可读的大小（如 size=1.5MB）
+// 只有本次调用中值为 ByteSize 的字段按字节大小显示，其他日志中的同名字段不受影响
+func Bytes(key string, n int64) Field {
+	return Field{Key: key, Value: ByteSize(n)}
 }
@@ -0,0 +1,121 @@
+package zllog
+
+import "sync"
+
+// ============================================================================
+// 字段切片池（减少高频日志场景下临时 []Field 的分配）
+// ============================================================================
+
+const (
+	// defaultFieldSliceCap 池中新建切片的初始容量
+	defaultFieldSliceCap = 16
+	// maxPooledFieldSliceCap 超过此容量的切片不放回池中，避免个别超大日志长期占用内存
+	maxPooledFieldSliceCap = 256
+)
+
+// fieldSlicePool 合并上下文字段和显式字段时使用的临时切片
+var fieldSlicePool = sync.Pool{
+	New: func() interface{} {
+		s := make([]Field, 0, defaultFieldSliceCap)
+		return &s
+	},
+}
+
+// getFieldSlice 从池中取出一个长度为 0 的字段切片
+func getFieldSlice() *[]Field {
+	return fieldSlicePool.Get().(*[]Field)
+}
+
+// putFieldSlice 清空切片后放回池中
+// 元素置零以释放对字段值的引用，调用方放回后不得再使用该切片
+func putFieldSlice(s *[]Field) {
+	if cap(*s) > maxPooledFieldSliceCap {
+		return
+	}
+	fields := *s
+	for i := range fields {
+		fields[i] = Field{}
+	}
+	*s = fields[:0]
+	fieldSlicePool.Put(s)
+}
+
+// appendMergedFields 将上下文字段和显式字段追加到 dst，显式字段覆盖同名的上下文字段
+// 与 MergeFields 不同，不分配额外的索引 map，适用于字段数量较少的热路径
+func appendMergedFields(dst, ctxFields, fields []Field) []Field {
+	base := len(dst)
+	dst = append(dst, ctxFields...)
+	ctxEnd := len(dst)
+	for _, field := range fields {
+		replaced := false
+		for i := base; i < ctxEnd; i++ {
+			if dst[i].Key == field.Key {
+				dst[i] = field
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			dst = append(dst, field)
+		}
+	}
+	return dst
+}
+
+// removeFieldKeys 原地删除 key 在 keys 中的字段，删除后空出的位置置零
+func removeFieldKeys(fields []Field, keys map[string]struct{}) []Field {
+	n := 0
+	for _, field := range fields {
+		if _, ok := keys[field.Key]; ok {
+			continue
+		}
+		fields[n] = field
+		n++
+	}
+	for i := n; i < len(fields); i++ {
+		fields[i] = Field{}
+	}
+	return fields[:n]
+}
+
+// dedupFieldKeys 原地去掉同名字段，删除后空出的位置置零，返回去重后的切片和第一个重复的字段名
+// keepFirst 为 false 时保留最后一个值（位置不变，与 MergeFields 的覆盖语义一致），为 true 时保留第一个值
+// 字段数量通常很少，逐个比较比分配 map 更快
+func dedupFieldKeys(fields []Field, keepFirst bool) ([]Field, string) {
+	var dupKey string
+	n := 0
+	for _, field := range fields {
+		dup := false
+		for i := 0; i < n; i++ {
+			if fields[i].Key == field.Key {
+				if !keepFirst {
+					fields[i] = field
+				}
+				dup = true
+				break
+			}
+		}
+		if dup {
+			if dupKey == "" {
+				dupKey = field.Key
+			}
+			continue
+		}
+		fields[n] = field
+		n++
+	}
+	for i := n; i < len(fields); i++ {
+		fields[i] = Field{}
+	}
+	return fields[:n], dupKey
+}
+
+// sortFieldsByKey 原地按 key 字母序排序（稳定排序，同名字段保持原有顺序）
+// 字段数量通常很少，插入排序不产生额外分配
+func sortFieldsByKey(fields []Field) {
+	for i := 1; i < len(fields); i++ {
+		for j := i; j > 0 && fields[j].Key < fields[j-1].Key; j-- {
+			fields[j], fields[j-1] = fields[j-1], fields[j]
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package zllog
+
+import "testing"
+
+// TestPutFieldSliceClears 测试放回池中的切片长度归零且不再引用字段值
+func TestPutFieldSliceClears(t *testing.T) {
+	s := getFieldSlice()
+	*s = append(*s, String("a", "1"), Int("b", 2))
+	backing := (*s)[:2]
+
+	putFieldSlice(s)
+
+	if len(*s) != 0 {
+		t.Errorf("expected length 0, got %d", len(*s))
+	}
+	for i, f := range backing {
+		if f.Key != "" || f.Value != nil {
+			t.Errorf("element %d not cleared: %+v", i, f)
+		}
+	}
+}
+
+// TestAppendMergedFields 测试显式字段覆盖同名的上下文字段
+func TestAppendMergedFields(t *testing.T) {
+	ctxFields := []Field{String("a", "ctx"), String("b", "ctx")}
+	merged := appendMergedFields(nil, ctxFields, []Field{String("b", "explicit"), String("c", "explicit")})
+
+	want := []Field{String("a", "ctx"), String("b", "explicit"), String("c", "explicit")}
+	if len(merged) != len(want) {
+		t.Fatalf("expected %v, got %v", want, merged)
+	}
+	for i := range want {
+		if merged[i] != want[i] {
+			t.Errorf("index %d: expected %v, got %v", i, want[i], merged[i])
+		}
+	}
+	if ctxFields[1].Value != "ctx" {
+		t.Error("context fields must not be modified")
+	}
+}
@@ -0,0 +1,48 @@
+package zllog
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestFieldsMerge 测试 Fields.Merge 追加字段且不修改原集合
+func TestFieldsMerge(t *testing.T) {
+	base := Fields{String("user_id", "u1")}
+	merged := base.Merge(String("tenant", "t1"), Int("age", 18))
+
+	if len(base) != 1 {
+		t.Errorf("base should not be modified: %v", base)
+	}
+	want := Fields{String("user_id", "u1"), String("tenant", "t1"), Int("age", 18)}
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("unexpected merge result: %v", merged)
+	}
+}
+
+// TestMergeFields 测试按 key 去重（后出现的值为准，保持首次出现的顺序）
+func TestMergeFields(t *testing.T) {
+	a := []Field{String("a", "1"), String("b", "1"), String("a", "2")}
+	b := []Field{String("c", "1"), String("b", "2")}
+
+	got := MergeFields(a, b)
+	want := []Field{String("a", "2"), String("b", "2"), String("c", "1")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeFields = %v, want %v", got, want)
+	}
+}
+
+// TestHTTPStatus 测试 HTTP 状态码字段及其类别
+func TestHTTPStatus(t *testing.T) {
+	got := HTTPStatus(404)
+	want := Fields{Int("status", 404), String("status_class", "4xx")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("HTTPStatus(404) = %v, want %v", got, want)
+	}
+
+	classes := map[int]string{100: "1xx", 200: "2xx", 301: "3xx", 499: "4xx", 503: "5xx", 0: "unknown", 600: "unknown"}
+	for code, want := range classes {
+		if got := StatusClass(code); got != want {
+			t.Errorf("StatusClass(%d) = %q, want %q", code, got, want)
+		}
+	}
+}
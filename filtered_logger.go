@@ -0,0 +1,224 @@
+package zllog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ============================================================================
+// FilteredLogger - 按级别和 module 过滤的 Logger 装饰器
+// ============================================================================
+
+// FilteredLogger 丢弃低于最小级别或不在 module 白名单中的调用，其余转发给内部 Logger
+// 常与 MultiLogger 组合：全部日志写本地文件，只有部分 module 的 ERROR 以上日志上报远程
+//
+// 用法示例：
+//   remoteErrors := zllog.NewFilteredLogger(remoteLogger, "ERROR", "payment", "order")
+//   zllog.SetLogger(zllog.NewMultiLogger(zllog.GetLogger(), remoteErrors))
+type FilteredLogger struct {
+	inner    Logger
+	minLevel Level
+	modules  []string
+}
+
+// NewFilteredLogger 创建过滤 Logger
+// minLevel 为最小级别（TRACE/DEBUG/INFO/WARN/ERROR/FATAL，无法识别时使用 INFO）；
+// modules 为 module 白名单，同时匹配其子 module（"api" 匹配 "api.payment"），为空时不按 module 过滤
+func NewFilteredLogger(inner Logger, minLevel string, modules ...string) Logger {
+	level, _ := ParseLevel(minLevel)
+	return &FilteredLogger{inner: inner, minLevel: level, modules: modules}
+}
+
+// allowed 该级别和 module 的调用是否转发
+func (f *FilteredLogger) allowed(level Level, module string) bool {
+	if level < f.minLevel {
+		return false
+	}
+	if len(f.modules) == 0 {
+		return true
+	}
+	for _, m := range f.modules {
+		if module == m || strings.HasPrefix(module, m+moduleSeparator) {
+			return true
+		}
+	}
+	return false
+}
+
+// Trace logs a message at TRACE level
+// 内部 Logger 未实现 TraceLogger 时降级为 DEBUG 级别
+func (f *FilteredLogger) Trace(ctx context.Context, module, message string, fields ...Field) {
+	if !f.allowed(LevelTrace, module) {
+		return
+	}
+	if tl, ok := f.inner.(TraceLogger); ok {
+		tl.Trace(ctx, module, message, fields...)
+		return
+	}
+	f.inner.Debug(ctx, module, message, fields...)
+}
+
+// Debug logs a message at DEBUG level
+func (f *FilteredLogger) Debug(ctx context.Context, module, message string, fields ...Field) {
+	if f.allowed(LevelDebug, module) {
+		f.inner.Debug(ctx, module, message, fields...)
+	}
+}
+
+// Info logs a message at INFO level
+func (f *FilteredLogger) Info(ctx context.Context, module, message string, fields ...Field) {
+	if f.allowed(LevelInfo, module) {
+		f.inner.Info(ctx, module, message, fields...)
+	}
+}
+
+// Warn logs a message at WARN level
+func (f *FilteredLogger) Warn(ctx context.Context, module, message string, fields ...Field) {
+	if f.allowed(LevelWarn, module) {
+		f.inner.Warn(ctx, module, message, fields...)
+	}
+}
+
+// Error logs a message at ERROR level with error info
+func (f *FilteredLogger) Error(ctx context.Context, module, message string, err error, fields ...Field) {
+	if f.allowed(LevelError, module) {
+		f.inner.Error(ctx, module, message, err, fields...)
+	}
+}
+
+// ErrorWithCode logs a message at ERROR level with error code
+func (f *FilteredLogger) ErrorWithCode(ctx context.Context, module, message, errorCode string, err error, fields ...Field) {
+	if f.allowed(LevelError, module) {
+		f.inner.ErrorWithCode(ctx, module, message, errorCode, err, fields...)
+	}
+}
+
+// FatalNoExit logs a message at FATAL level without exiting
+func (f *FilteredLogger) FatalNoExit(ctx context.Context, module, message string, err error, fields ...Field) {
+	if f.allowed(LevelFatal, module) {
+		fatalNoExit(f.inner, ctx, module, message, err, fields)
+	}
+}
+
+// Fatal logs a message at FATAL level and exits
+// 被过滤时不记录日志，但仍然退出进程
+func (f *FilteredLogger) Fatal(ctx context.Context, module, message string, err error, fields ...Field) {
+	if f.allowed(LevelFatal, module) {
+		f.inner.Fatal(ctx, module, message, err, fields...)
+	}
+	exitFunc(1)
+}
+
+// InfoWithRequest INFO日志 + request_id + cost_ms
+func (f *FilteredLogger) InfoWithRequest(ctx context.Context, module, message, requestID string, costMs int64, fields ...Field) {
+	if f.allowed(LevelInfo, module) {
+		f.inner.InfoWithRequest(ctx, module, message, requestID, costMs, fields...)
+	}
+}
+
+// ErrorWithRequest ERROR日志 + request_id + cost_ms
+func (f *FilteredLogger) ErrorWithRequest(ctx context.Context, module, message, requestID string, err error, costMs int64, fields ...Field) {
+	if f.allowed(LevelError, module) {
+		f.inner.ErrorWithRequest(ctx, module, message, requestID, err, costMs, fields...)
+	}
+}
+
+// Tracef logs a formatted message at TRACE level
+func (f *FilteredLogger) Tracef(ctx context.Context, module, format string, args ...interface{}) {
+	if f.allowed(LevelTrace, module) {
+		f.Trace(ctx, module, fmt.Sprintf(format, args...))
+	}
+}
+
+// Debugf logs a formatted message at DEBUG level
+func (f *FilteredLogger) Debugf(ctx context.Context, module, format string, args ...interface{}) {
+	if f.allowed(LevelDebug, module) {
+		f.inner.Debugf(ctx, module, format, args...)
+	}
+}
+
+// Infof logs a formatted message at INFO level
+func (f *FilteredLogger) Infof(ctx context.Context, module, format string, args ...interface{}) {
+	if f.allowed(LevelInfo, module) {
+		f.inner.Infof(ctx, module, format, args...)
+	}
+}
+
+// Warnf logs a formatted message at WARN level
+func (f *FilteredLogger) Warnf(ctx context.Context, module, format string, args ...interface{}) {
+	if f.allowed(LevelWarn, module) {
+		f.inner.Warnf(ctx, module, format, args...)
+	}
+}
+
+// Errorf logs a formatted message at ERROR level with error info
+func (f *FilteredLogger) Errorf(ctx context.Context, module, format string, err error, args ...interface{}) {
+	if f.allowed(LevelError, module) {
+		f.inner.Errorf(ctx, module, format, err, args...)
+	}
+}
+
+// ErrorWithCodef logs a formatted message at ERROR level with error code
+func (f *FilteredLogger) ErrorWithCodef(ctx context.Context, module, format string, errorCode string, err error, args ...interface{}) {
+	if f.allowed(LevelError, module) {
+		f.inner.ErrorWithCodef(ctx, module, format, errorCode, err, args...)
+	}
+}
+
+// Fatalf logs a formatted message at FATAL level and exits
+// 被过滤时不记录日志，但仍然退出进程
+func (f *FilteredLogger) Fatalf(ctx context.Context, module, format string, err error, args ...interface{}) {
+	if f.allowed(LevelFatal, module) {
+		f.inner.Fatalf(ctx, module, format, err, args...)
+	}
+	exitFunc(1)
+}
+
+// InfoWithRequestf INFO日志 + request_id + cost_ms (formatted)
+func (f *FilteredLogger) InfoWithRequestf(ctx context.Context, module, format string, requestID string, costMs int64, args ...interface{}) {
+	if f.allowed(LevelInfo, module) {
+		f.inner.InfoWithRequestf(ctx, module, format, requestID, costMs, args...)
+	}
+}
+
+// ErrorWithRequestf ERROR日志 + request_id + cost_ms (formatted)
+func (f *FilteredLogger) ErrorWithRequestf(ctx context.Context, module, format string, requestID string, err error, costMs int64, args ...interface{}) {
+	if f.allowed(LevelError, module) {
+		f.inner.ErrorWithRequestf(ctx, module, format, requestID, err, costMs, args...)
+	}
+}
+
+// Enabled 实现 LevelEnabler：通过级别和 module 过滤且内部 Logger 会输出时返回 true
+func (f *FilteredLogger) Enabled(ctx context.Context, module string, level Level) bool {
+	return f.allowed(level, module) && loggerEnabled(f.inner, ctx, module, level)
+}
+
+// countsLevels 内部 Logger 自行统计级别时，FilteredLogger 同样不需要包级日志函数计数
+func (f *FilteredLogger) countsLevels() bool {
+	return loggerCountsLevels(f.inner)
+}
+
+// Healthy 内部 Logger 实现了 HealthChecker 时返回其健康状态，否则返回 true
+func (f *FilteredLogger) Healthy() bool {
+	if hc, ok := f.inner.(HealthChecker); ok {
+		return hc.Healthy()
+	}
+	return true
+}
+
+// LastError 内部 Logger 实现了 HealthChecker 时返回其最近错误，否则返回 nil
+func (f *FilteredLogger) LastError() error {
+	if hc, ok := f.inner.(HealthChecker); ok {
+		return hc.LastError()
+	}
+	return nil
+}
+
+// Flush 内部 Logger 实现了 Flusher 时刷新内部 Logger
+func (f *FilteredLogger) Flush(ctx context.Context) error {
+	if fl, ok := f.inner.(Flusher); ok {
+		return fl.Flush(ctx)
+	}
+	return nil
+}
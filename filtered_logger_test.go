@@ -0,0 +1,73 @@
+package zllog
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// TestFilteredLoggerLevel 测试低于最小级别的调用被丢弃
+func TestFilteredLoggerLevel(t *testing.T) {
+	mock := &MockLogger{}
+	logger := NewFilteredLogger(mock, "warning")
+
+	ctx := context.Background()
+	logger.Debug(ctx, "api", "debug")
+	logger.Info(ctx, "api", "info")
+	logger.Infof(ctx, "api", "info %d", 1)
+	logger.Warn(ctx, "api", "warn")
+	logger.Error(ctx, "api", "error", errors.New("boom"))
+	logger.ErrorWithCode(ctx, "api", "coded", "E001", nil)
+
+	want := []string{"[WARN] api: warn", "[ERROR] api: error", "[ERROR_CODE] api: coded"}
+	if len(mock.calls) != len(want) {
+		t.Fatalf("expected %v, got %v", want, mock.calls)
+	}
+	for i := range want {
+		if mock.calls[i] != want[i] {
+			t.Errorf("call %d: expected %q, got %q", i, want[i], mock.calls[i])
+		}
+	}
+}
+
+// TestFilteredLoggerModules 测试 module 白名单（包括子 module）
+func TestFilteredLoggerModules(t *testing.T) {
+	mock := &MockLogger{}
+	logger := NewFilteredLogger(mock, "DEBUG", "payment", "order")
+
+	ctx := context.Background()
+	logger.Info(ctx, "payment", "allowed")
+	logger.Info(ctx, "payment.refund", "child allowed")
+	logger.Info(ctx, "paymentgateway", "prefix only")
+	logger.Info(ctx, "user", "not allowed")
+	logger.Error(ctx, "order", "allowed error", nil)
+
+	want := []string{"[INFO] payment: allowed", "[INFO] payment.refund: child allowed", "[ERROR] order: allowed error"}
+	if len(mock.calls) != len(want) {
+		t.Fatalf("expected %v, got %v", want, mock.calls)
+	}
+	for i := range want {
+		if mock.calls[i] != want[i] {
+			t.Errorf("call %d: expected %q, got %q", i, want[i], mock.calls[i])
+		}
+	}
+}
+
+// TestFilteredLoggerFatal 测试被过滤的 Fatal 不记录日志但仍然退出
+func TestFilteredLoggerFatal(t *testing.T) {
+	originalExit := exitFunc
+	defer func() {
+		exitFunc = originalExit
+	}()
+	exited := false
+	exitFunc = func(int) { exited = true }
+
+	mock := &MockLogger{}
+	NewFilteredLogger(mock, "INFO", "payment").Fatal(context.Background(), "user", "fatal", nil)
+	if !exited {
+		t.Error("expected filtered Fatal to exit")
+	}
+	if mock.getCallCount() != 0 {
+		t.Errorf("expected no calls, got %v", mock.calls)
+	}
+}
@@ -0,0 +1,34 @@
+package zllog
+
+import (
+	"context"
+	"errors"
+)
+
+// ============================================================================
+// 刷新缓冲中的日志
+// ============================================================================
+
+// Flush 刷新缓冲中的日志：等待异步缓冲区写完，当前 Logger 实现了 Flusher 时调用其 Flush
+// 用于优雅退出前确保日志已落盘或已上报，等待时间受 ctx 限制
+//
+// 用法示例：
+//   ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+//   defer cancel()
+//   if err := zllog.Flush(ctx); err != nil {
+//       fmt.Fprintln(os.Stderr, "flush logs:", err)
+//   }
+func Flush(ctx context.Context) error {
+	var errs []error
+	if globalAsyncWriter != nil {
+		if err := globalAsyncWriter.Flush(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if f, ok := getLogger().(Flusher); ok {
+		if err := f.Flush(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
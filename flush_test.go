@@ -0,0 +1,53 @@
+package zllog
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// flushMockLogger 记录 Flush 调用的 Logger
+type flushMockLogger struct {
+	MockLogger
+	flushed int
+	err     error
+}
+
+func (f *flushMockLogger) Flush(ctx context.Context) error {
+	f.flushed++
+	return f.err
+}
+
+// TestFlush 测试包级 Flush 调用当前 Logger 的 Flush，未实现 Flusher 时直接返回
+func TestFlush(t *testing.T) {
+	originalLogger := globalLoggerImpl
+	originalAsync := globalAsyncWriter
+	defer func() {
+		globalLoggerImpl = originalLogger
+		globalAsyncWriter = originalAsync
+	}()
+	globalAsyncWriter = nil
+
+	mock := &flushMockLogger{}
+	SetLogger(mock)
+	if err := Flush(context.Background()); err != nil || mock.flushed != 1 {
+		t.Fatalf("expected one successful flush, got %d, %v", mock.flushed, err)
+	}
+
+	mock.err = errors.New("endpoint down")
+	if err := Flush(context.Background()); !errors.Is(err, mock.err) {
+		t.Errorf("expected flush error, got %v", err)
+	}
+
+	// 组合 Logger 刷新其中所有实现了 Flusher 的 Logger
+	inner := &flushMockLogger{}
+	SetLogger(NewMultiLogger(&MockLogger{}, NewFilteredLogger(inner, "ERROR")))
+	if err := Flush(context.Background()); err != nil || inner.flushed != 1 {
+		t.Errorf("expected nested flush, got %d, %v", inner.flushed, err)
+	}
+
+	SetLogger(&MockLogger{})
+	if err := Flush(context.Background()); err != nil {
+		t.Errorf("expected nil for logger without Flusher, got %v", err)
+	}
+}
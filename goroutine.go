@@ -0,0 +1,31 @@
+package zllog
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+)
+
+// ============================================================================
+// goroutine ID（用于排查并发问题）
+// ============================================================================
+
+// goroutinePrefix runtime.Stack 输出的第一行前缀，如 "goroutine 18 [running]:"
+var goroutinePrefix = []byte("goroutine ")
+
+// goroutineID 返回当前 goroutine 的 ID，解析失败时返回 0
+// 通过解析 runtime.Stack 输出的第一行获取，每次调用约 1µs，
+// 因此只在 LogConfig.EnableGoroutineID 开启时使用
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, goroutinePrefix)
+	if i := bytes.IndexByte(b, ' '); i > 0 {
+		b = b[:i]
+	}
+	id, err := strconv.ParseUint(string(b), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
@@ -0,0 +1,195 @@
+package zllog
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// ============================================================================
+// 日志文件哈希链（防篡改）
+// ============================================================================
+
+// 哈希链字段
+const (
+	prevHashField = "prev_hash"
+	hashField     = "hash"
+)
+
+// hashChainWriter 为每行 JSON 日志追加 prev_hash/hash 字段，hash = sha256(prev_hash + 原始行)
+// 删除或修改任意一行都会导致后续的 hash 对不上，可通过 VerifyChain 检测
+// 非 JSON 对象的行（如通过 Writer() 写入的原始内容）原样输出，但同样参与哈希链：
+// 链值推进为 sha256(prev_hash + 去掉首尾空白的原始行)，由下一行 JSON 日志的 prev_hash 体现（空白行除外）
+type hashChainWriter struct {
+	mu   sync.Mutex
+	out  io.Writer
+	prev string
+	buf  []byte
+}
+
+// newHashChainWriter 创建哈希链 writer，prev 为链的起点（通常是已有文件最后一行的 hash）
+func newHashChainWriter(out io.Writer, prev string) *hashChainWriter {
+	return &hashChainWriter{out: out, prev: prev}
+}
+
+// Write 逐行计算哈希链后写入下层 writer
+func (w *hashChainWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = w.buf[:0]
+	for rest := p; len(rest) > 0; {
+		line := rest
+		if i := bytes.IndexByte(rest, '\n'); i >= 0 {
+			line, rest = rest[:i], rest[i+1:]
+		} else {
+			rest = nil
+		}
+		w.buf = w.appendChained(w.buf, line)
+		w.buf = append(w.buf, '\n')
+	}
+
+	if _, err := w.out.Write(w.buf); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// appendChained 追加一行，JSON 对象行带上 prev_hash/hash 字段，其他行只推进链值
+func (w *hashChainWriter) appendChained(dst, line []byte) []byte {
+	if !isJSONObjectLine(line) {
+		w.prev = chainRawLine(w.prev, line)
+		return append(dst, line...)
+	}
+
+	hash := chainHash(w.prev, line)
+	dst = append(dst, line[:len(line)-1]...)
+	if len(line) > 2 {
+		dst = append(dst, ',')
+	}
+	dst = appendChainFields(dst, w.prev, hash)
+	w.prev = hash
+	return dst
+}
+
+// appendChainFields 追加 "prev_hash":"...","hash":"..."} 后缀
+func appendChainFields(dst []byte, prev, hash string) []byte {
+	dst = append(dst, `"`+prevHashField+`":"`...)
+	dst = append(dst, prev...)
+	dst = append(dst, `","`+hashField+`":"`...)
+	dst = append(dst, hash...)
+	return append(dst, `"}`...)
+}
+
+// isJSONObjectLine 是否为带哈希字段的 JSON 对象行
+func isJSONObjectLine(line []byte) bool {
+	return len(line) >= 2 && line[0] == '{' && line[len(line)-1] == '}'
+}
+
+// chainRawLine 非 JSON 行推进链值，空白行不参与
+func chainRawLine(prev string, line []byte) string {
+	if line = bytes.TrimSpace(line); len(line) == 0 {
+		return prev
+	}
+	return chainHash(prev, line)
+}
+
+// chainHash 计算 sha256(prev_hash + line)
+func chainHash(prev string, line []byte) string {
+	h := sha256.New()
+	h.Write([]byte(prev))
+	h.Write(line)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// lastChainHash 读取已有日志文件末尾的链值（最后一行 JSON 日志的 hash，再依次推进其后的非 JSON 行），使重启后哈希链保持连续
+func lastChainHash(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	var last string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if !isJSONObjectLine(line) {
+			last = chainRawLine(last, line)
+			continue
+		}
+		var entry struct {
+			Hash string `json:"hash"`
+		}
+		if json.Unmarshal(line, &entry) == nil && entry.Hash != "" {
+			last = entry.Hash
+		}
+	}
+	return last
+}
+
+// VerifyChain 校验日志文件的哈希链，返回第一处不一致的错误，全部通过时返回 nil
+// 轮转后的文件以第一行 JSON 日志的 prev_hash 作为起点；之后的非 JSON 行同样参与校验，
+// 删除或修改后下一行 JSON 日志的 prev_hash 对不上
+//
+// 用法示例：
+//   f, _ := os.Open("logs/app.log")
+//   if err := zllog.VerifyChain(f); err != nil {
+//       // 日志被删除或篡改
+//   }
+func VerifyChain(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var (
+		lineNo int
+		prev   string
+		first  = true
+	)
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Bytes()
+		if !isJSONObjectLine(line) {
+			// 第一行 JSON 日志之前的链起点未知，无法校验
+			if !first {
+				prev = chainRawLine(prev, line)
+			}
+			continue
+		}
+
+		var entry struct {
+			PrevHash *string `json:"prev_hash"`
+			Hash     string  `json:"hash"`
+		}
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return fmt.Errorf("line %d: invalid log entry: %w", lineNo, err)
+		}
+		if entry.PrevHash == nil || entry.Hash == "" {
+			return fmt.Errorf("line %d: missing hash", lineNo)
+		}
+		if !first && *entry.PrevHash != prev {
+			return fmt.Errorf("line %d: prev_hash does not match the previous lines", lineNo)
+		}
+
+		// 去掉追加的字段还原原始行
+		suffix := appendChainFields(nil, *entry.PrevHash, entry.Hash)
+		if !bytes.HasSuffix(line, suffix) {
+			return fmt.Errorf("line %d: hash fields are not at the end of the line", lineNo)
+		}
+		original := bytes.TrimSuffix(line[:len(line)-len(suffix)], []byte(","))
+		original = append(original[:len(original):len(original)], '}')
+		if chainHash(*entry.PrevHash, original) != entry.Hash {
+			return fmt.Errorf("line %d: hash mismatch", lineNo)
+		}
+
+		prev, first = entry.Hash, false
+	}
+	return scanner.Err()
+}
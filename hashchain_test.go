@@ -0,0 +1,87 @@
+package zllog
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestHashChainVerify 测试哈希链的生成与校验，篡改或删除中间一行会导致校验失败
+func TestHashChainVerify(t *testing.T) {
+	var buf bytes.Buffer
+	w := newHashChainWriter(&buf, "")
+	w.Write([]byte(`{"level":"info","message":"first"}` + "\n"))
+	w.Write([]byte(`{"level":"info","message":"second"}` + "\n" + `{"level":"info","message":"third"}` + "\n"))
+	w.Write([]byte("plain text line\n"))
+	w.Write([]byte(`{"level":"info","message":"fourth"}` + "\n"))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected 5 lines, got %d: %q", len(lines), buf.String())
+	}
+	var first, second map[string]interface{}
+	json.Unmarshal([]byte(lines[0]), &first)
+	json.Unmarshal([]byte(lines[1]), &second)
+	if first["prev_hash"] != "" || first["hash"] == "" || first["message"] != "first" {
+		t.Errorf("unexpected first line: %v", first)
+	}
+	if second["prev_hash"] != first["hash"] {
+		t.Errorf("expected second prev_hash %v, got %v", first["hash"], second["prev_hash"])
+	}
+	if lines[3] != "plain text line" {
+		t.Errorf("expected non-JSON line unchanged, got %q", lines[3])
+	}
+
+	if err := VerifyChain(strings.NewReader(buf.String())); err != nil {
+		t.Fatalf("expected valid chain, got %v", err)
+	}
+
+	tampered := strings.Replace(buf.String(), `"message":"second"`, `"message":"SECOND"`, 1)
+	if err := VerifyChain(strings.NewReader(tampered)); err == nil {
+		t.Error("expected modified middle line to fail verification")
+	}
+
+	deleted := strings.Join([]string{lines[0], lines[2], lines[4]}, "\n")
+	if err := VerifyChain(strings.NewReader(deleted)); err == nil {
+		t.Error("expected deleted middle line to fail verification")
+	}
+
+	// 非 JSON 行同样参与哈希链
+	rawTampered := strings.Replace(buf.String(), "plain text line", "forged text line", 1)
+	if err := VerifyChain(strings.NewReader(rawTampered)); err == nil {
+		t.Error("expected modified plain text line to fail verification")
+	}
+	rawDeleted := strings.Join([]string{lines[0], lines[1], lines[2], lines[4]}, "\n")
+	if err := VerifyChain(strings.NewReader(rawDeleted)); err == nil {
+		t.Error("expected deleted plain text line to fail verification")
+	}
+}
+
+// TestHashChainLogFile 测试启用 HashChain 后日志文件可校验，且重新打开后链保持连续
+func TestHashChainLogFile(t *testing.T) {
+	config := &LogConfig{LogDir: t.TempDir(), HashChain: true, MaxSize: 10}
+
+	w := createLogFileWriter(config)
+	w.Write([]byte(`{"message":"a"}` + "\n"))
+	w.Write([]byte(`{"message":"b"}` + "\n"))
+
+	w.Write([]byte("raw line before restart\n"))
+
+	// 模拟重启
+	w = createLogFileWriter(config)
+	w.Write([]byte(`{"message":"c"}` + "\n"))
+
+	data, err := os.ReadFile(filepath.Join(config.LogDir, "app.log"))
+	if err != nil {
+		t.Fatalf("read log file: %v", err)
+	}
+	if n := strings.Count(string(data), `"hash":`); n != 3 {
+		t.Fatalf("expected 3 chained lines, got %d: %q", n, data)
+	}
+	if err := VerifyChain(bytes.NewReader(data)); err != nil {
+		t.Errorf("expected chain to continue across reopen, got %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package zllog
+
+// ============================================================================
+// 日志通道健康状态（用于就绪探针）
+// ============================================================================
+
+// healthCheckers 返回需要汇总的健康检查对象：异步 writer 和当前 Logger（实现了 HealthChecker 时）
+func healthCheckers() []HealthChecker {
+	var checkers []HealthChecker
+	if globalAsyncWriter != nil {
+		checkers = append(checkers, globalAsyncWriter)
+	}
+	if hc, ok := globalLoggerImpl.(HealthChecker); ok {
+		checkers = append(checkers, hc)
+	}
+	return checkers
+}
+
+// Healthy 日志通道是否健康：异步缓冲区未饱和、写入未失败，
+// 且当前 Logger（如远程上报）实现了 HealthChecker 时其状态也健康
+//
+// 用法示例：
+//   http.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
+//       if !zllog.Healthy() {
+//           http.Error(w, fmt.Sprint("logging unhealthy: ", zllog.LastError()), http.StatusServiceUnavailable)
+//           return
+//       }
+//       w.WriteHeader(http.StatusOK)
+//   })
+func Healthy() bool {
+	for _, hc := range healthCheckers() {
+		if !hc.Healthy() {
+			return false
+		}
+	}
+	return true
+}
+
+// LastError 返回第一个不健康通道的最近错误；都健康时返回最近出现过的错误，从未失败时返回 nil
+func LastError() error {
+	var last error
+	for _, hc := range healthCheckers() {
+		err := hc.LastError()
+		if err != nil && !hc.Healthy() {
+			return err
+		}
+		if last == nil {
+			last = err
+		}
+	}
+	return last
+}
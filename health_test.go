@@ -0,0 +1,46 @@
+package zllog
+
+import (
+	"errors"
+	"testing"
+)
+
+// healthMockLogger 可控制健康状态的 Logger
+type healthMockLogger struct {
+	MockLogger
+	err error
+}
+
+func (l *healthMockLogger) Healthy() bool    { return l.err == nil }
+func (l *healthMockLogger) LastError() error { return l.err }
+
+// TestHealthy 测试包级 Healthy/LastError 汇总当前 Logger 的健康状态
+func TestHealthy(t *testing.T) {
+	originalLogger := globalLoggerImpl
+	originalAsync := globalAsyncWriter
+	defer func() {
+		globalLoggerImpl = originalLogger
+		globalAsyncWriter = originalAsync
+	}()
+	globalAsyncWriter = nil
+
+	logger := &healthMockLogger{}
+	SetLogger(logger)
+	if !Healthy() || LastError() != nil {
+		t.Fatalf("expected healthy, got %v", LastError())
+	}
+
+	logger.err = errors.New("endpoint unreachable")
+	if Healthy() {
+		t.Error("expected unhealthy after send failure")
+	}
+	if LastError() != logger.err {
+		t.Errorf("expected logger error, got %v", LastError())
+	}
+
+	// 未实现 HealthChecker 的 Logger 视为健康
+	SetLogger(&MockLogger{})
+	if !Healthy() {
+		t.Error("expected healthy for logger without HealthChecker")
+	}
+}
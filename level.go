@@ -0,0 +1,120 @@
+package zllog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// ============================================================================
+// 日志级别
+// ============================================================================
+
+// Level 日志级别，取值与 zerolog.Level 一致
+type Level int8
+
+// 日志级别常量
+const (
+	LevelTrace Level = Level(zerolog.TraceLevel)
+	LevelDebug Level = Level(zerolog.DebugLevel)
+	LevelInfo  Level = Level(zerolog.InfoLevel)
+	LevelWarn  Level = Level(zerolog.WarnLevel)
+	LevelError Level = Level(zerolog.ErrorLevel)
+	LevelFatal Level = Level(zerolog.FatalLevel)
+	LevelPanic Level = Level(zerolog.PanicLevel)
+)
+
+// ParseLevel 解析日志级别字符串，不区分大小写，支持别名 WARNING
+// 无法识别时返回 LevelInfo 和错误
+func ParseLevel(levelStr string) (Level, error) {
+	switch strings.ToUpper(strings.TrimSpace(levelStr)) {
+	case "TRACE":
+		return LevelTrace, nil
+	case "DEBUG":
+		return LevelDebug, nil
+	case "INFO":
+		return LevelInfo, nil
+	case "WARN", "WARNING":
+		return LevelWarn, nil
+	case "ERROR":
+		return LevelError, nil
+	case "FATAL":
+		return LevelFatal, nil
+	case "PANIC":
+		return LevelPanic, nil
+	default:
+		return LevelInfo, fmt.Errorf("unknown log level: %s", levelStr)
+	}
+}
+
+// String 返回大写的级别名称（TRACE/DEBUG/INFO/WARN/ERROR/FATAL/PANIC），可再次被 ParseLevel 解析
+func (l Level) String() string {
+	switch l {
+	case LevelTrace:
+		return "TRACE"
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	case LevelFatal:
+		return "FATAL"
+	case LevelPanic:
+		return "PANIC"
+	default:
+		return fmt.Sprintf("Level(%d)", int8(l))
+	}
+}
+
+// MarshalText 实现 encoding.TextMarshaler，便于在 JSON/YAML 配置中使用 Level
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler
+func (l *Level) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
+// zerologLevel 转换为 zerolog.Level
+func (l Level) zerologLevel() zerolog.Level {
+	return zerolog.Level(l)
+}
+
+// SetLevel 设置配置的日志级别（等价于 config.LogLevel = level.String()）
+func (c *LogConfig) SetLevel(level Level) {
+	c.LogLevel = level.String()
+}
+
+// SetLevel 运行时调整全局日志级别，立即对所有日志生效
+//
+// 用法示例：
+//   zllog.SetLevel(zllog.LevelDebug)
+func SetLevel(level Level) {
+	zerolog.SetGlobalLevel(level.zerologLevel())
+}
+
+// SetLevelString 运行时调整全局日志级别（字符串形式，如 "DEBUG"、"warning"）
+// 无法识别的级别返回错误，当前级别保持不变
+func SetLevelString(levelStr string) error {
+	level, err := ParseLevel(levelStr)
+	if err != nil {
+		return err
+	}
+	SetLevel(level)
+	return nil
+}
+
+// GetLevel 返回当前的全局日志级别
+func GetLevel() Level {
+	return Level(zerolog.GlobalLevel())
+}
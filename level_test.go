@@ -0,0 +1,135 @@
+package zllog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// TestParseLevelRoundTrip 测试级别解析与格式化的往返，包括别名和大小写
+func TestParseLevelRoundTrip(t *testing.T) {
+	levels := []Level{LevelTrace, LevelDebug, LevelInfo, LevelWarn, LevelError, LevelFatal, LevelPanic}
+	for _, level := range levels {
+		parsed, err := ParseLevel(level.String())
+		if err != nil || parsed != level {
+			t.Errorf("round trip %s: got %v, %v", level, parsed, err)
+		}
+	}
+
+	aliases := map[string]Level{
+		"warning": LevelWarn,
+		"WARNING": LevelWarn,
+		"Warn":    LevelWarn,
+		" debug ": LevelDebug,
+		"info":    LevelInfo,
+	}
+	for s, want := range aliases {
+		if got, err := ParseLevel(s); err != nil || got != want {
+			t.Errorf("ParseLevel(%q) = %v, %v; want %v", s, got, err, want)
+		}
+	}
+
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("expected error for unknown level")
+	}
+
+	var level Level
+	if err := level.UnmarshalText([]byte("warning")); err != nil || level != LevelWarn {
+		t.Errorf("UnmarshalText: got %v, %v", level, err)
+	}
+	if text, _ := LevelError.MarshalText(); string(text) != "ERROR" {
+		t.Errorf("MarshalText: got %q", text)
+	}
+
+	config := &LogConfig{}
+	config.SetLevel(LevelDebug)
+	if config.LogLevel != "DEBUG" {
+		t.Errorf("expected LogLevel DEBUG, got %q", config.LogLevel)
+	}
+}
+
+// TestSetLevel 测试运行时调整全局级别
+func TestSetLevel(t *testing.T) {
+	original := zerolog.GlobalLevel()
+	defer zerolog.SetGlobalLevel(original)
+
+	logger, buf := newTestLogger(t, &LogConfig{})
+
+	SetLevel(LevelWarn)
+	if GetLevel() != LevelWarn {
+		t.Errorf("expected WARN, got %v", GetLevel())
+	}
+	logger.Info(context.Background(), "test", "filtered")
+	if buf.Len() != 0 {
+		t.Errorf("expected INFO to be filtered, got %q", buf.String())
+	}
+
+	if err := SetLevelString("debug"); err != nil {
+		t.Fatalf("SetLevelString: %v", err)
+	}
+	logger.Info(context.Background(), "test", "visible")
+	if buf.Len() == 0 {
+		t.Error("expected INFO after lowering level")
+	}
+
+	if err := SetLevelString("verbose"); err == nil {
+		t.Error("expected error for unknown level")
+	}
+	if GetLevel() != LevelDebug {
+		t.Errorf("expected level unchanged after invalid input, got %v", GetLevel())
+	}
+}
+
+// TestLogDynamicLevel 测试动态级别 API 分发到对应的级别，无法识别的级别降级为 INFO
+func TestLogDynamicLevel(t *testing.T) {
+	originalLogger := globalLoggerImpl
+	originalExit := exitFunc
+	defer func() {
+		globalLoggerImpl = originalLogger
+		exitFunc = originalExit
+	}()
+	exited := false
+	exitFunc = func(int) { exited = true }
+
+	logger, buf := newTestLogger(t, &LogConfig{})
+	SetLogger(logger)
+	ctx := context.Background()
+
+	levels := []Level{LevelTrace, LevelDebug, LevelInfo, LevelWarn, LevelError, LevelFatal}
+	for _, level := range levels {
+		Log(ctx, level, "consumer", "event")
+	}
+	func() {
+		defer func() {
+			if r := recover(); r != "event" {
+				t.Errorf("expected panic for PANIC level, got %v", r)
+			}
+		}()
+		Log(ctx, LevelPanic, "consumer", "event")
+	}()
+	Logf(ctx, LevelWarn, "consumer", "event %d", 7, String("source", "mq"))
+	Log(ctx, Level(42), "consumer", "event")
+	LogString(ctx, "warning", "consumer", "event")
+	LogfString(ctx, "severe", "consumer", "event %s", "x")
+
+	want := []string{"trace", "debug", "info", "warn", "error", "fatal", "panic", "warn", "info", "warn", "info"}
+	lines := decodeLines(t, buf)
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d lines, got %d: %v", len(want), len(lines), lines)
+	}
+	for i, line := range lines {
+		if line["level"] != want[i] {
+			t.Errorf("line %d: level = %v, want %s", i, line["level"], want[i])
+		}
+	}
+	if !exited {
+		t.Error("expected FATAL level to exit")
+	}
+	if lines[7]["message"] != "event 7" || lines[7]["source"] != "mq" {
+		t.Errorf("unexpected formatted line: %v", lines[7])
+	}
+	if lines[8]["unknown_level"] != "Level(42)" || lines[10]["unknown_level"] != "severe" || lines[10]["message"] != "event x" {
+		t.Errorf("expected unknown_level fields, got %v and %v", lines[8], lines[10])
+	}
+}
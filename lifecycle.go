@@ -0,0 +1,55 @@
+package zllog
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// ============================================================================
+// 服务生命周期日志（统一的启动/停止事件）
+// ============================================================================
+
+// 生命周期日志的字段值
+const (
+	lifecycleModule = "lifecycle"
+	lifecycleEvent  = "lifecycle"
+)
+
+// processStartTime 进程启动时间（包初始化时记录），未调用 LogStartup 时作为 uptime 的起点
+var processStartTime = time.Now()
+
+// startupTime 最近一次 LogStartup 的时间（UnixNano），0 表示未调用过
+var startupTime int64
+
+// LogStartup 输出统一格式的服务启动事件：event=lifecycle phase=started，并记录启动时间
+// service、env、host 等由公共字段提供，fields 可附加 version、listen 等信息
+//
+// 用法示例：
+//   zllog.LogStartup(ctx, zllog.String("listen", ":8080"))
+//   defer zllog.LogShutdown(ctx)
+func LogStartup(ctx context.Context, fields ...Field) {
+	atomic.StoreInt64(&startupTime, time.Now().UnixNano())
+	Info(ctx, lifecycleModule, "service started", append([]Field{
+		String("event", lifecycleEvent),
+		String("phase", "started"),
+	}, fields...)...)
+}
+
+// LogShutdown 输出统一格式的服务停止事件：event=lifecycle phase=stopped uptime_ms=<运行时长>
+// 运行时长从 LogStartup 开始计算，未调用过 LogStartup 时从进程启动开始计算
+func LogShutdown(ctx context.Context, fields ...Field) {
+	Info(ctx, lifecycleModule, "service stopped", append([]Field{
+		String("event", lifecycleEvent),
+		String("phase", "stopped"),
+		Int64("uptime_ms", Uptime().Milliseconds()),
+	}, fields...)...)
+}
+
+// Uptime 返回服务运行时长：从 LogStartup 开始计算，未调用过 LogStartup 时从进程启动开始计算
+func Uptime() time.Duration {
+	if started := atomic.LoadInt64(&startupTime); started != 0 {
+		return time.Since(time.Unix(0, started))
+	}
+	return time.Since(processStartTime)
+}
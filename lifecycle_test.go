@@ -0,0 +1,42 @@
+package zllog
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestLifecycleLogs 测试启动/停止事件的统一字段
+func TestLifecycleLogs(t *testing.T) {
+	originalLogger := globalLoggerImpl
+	defer func() {
+		globalLoggerImpl = originalLogger
+	}()
+	logger, buf := newTestLogger(t, &LogConfig{})
+	SetLogger(logger)
+
+	ctx := context.Background()
+	LogStartup(ctx, String("version", "1.2.3"))
+	time.Sleep(5 * time.Millisecond)
+	LogShutdown(ctx, String("reason", "sigterm"))
+
+	lines := decodeLines(t, buf)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+	started, stopped := lines[0], lines[1]
+	if started["event"] != "lifecycle" || started["phase"] != "started" || started["module"] != "lifecycle" ||
+		started["message"] != "service started" || started["version"] != "1.2.3" {
+		t.Errorf("unexpected startup line: %v", started)
+	}
+	if _, ok := started["uptime_ms"]; ok {
+		t.Errorf("startup line should not have uptime_ms: %v", started)
+	}
+	if stopped["event"] != "lifecycle" || stopped["phase"] != "stopped" || stopped["message"] != "service stopped" ||
+		stopped["reason"] != "sigterm" {
+		t.Errorf("unexpected shutdown line: %v", stopped)
+	}
+	if uptime, ok := stopped["uptime_ms"].(float64); !ok || uptime < 5 {
+		t.Errorf("expected uptime_ms >= 5, got %v", stopped["uptime_ms"])
+	}
+}
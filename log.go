@@ -2,6 +2,7 @@ package zllog
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"io"
@@ -32,8 +33,29 @@ var (
 	// ✅ 全局 TraceID Provider（解耦追踪系统）
 	globalTraceIDProvider TraceIDProvider
 
+	// 全局 Baggage Provider（业务属性透传到日志）
+	globalBaggageProvider BaggageProvider
+
 	// ✅ 全局 Logger 接口（支持自定义实现）
 	globalLoggerImpl Logger
+
+	// 初始化之前使用的 Logger（见 SetDefaultLogger）
+	globalDefaultLogger Logger
+
+	// 异步写入 writer（未启用异步写入时为 nil）
+	globalAsyncWriter *AsyncWriter
+
+	// 供第三方库直接写入的原始输出（见 Writer）
+	globalRawWriter io.Writer
+
+	// 初始化时实际生效的配置（见 EffectiveConfig）
+	globalEffectiveConfig LogConfig
+
+	// Provider 返回不合法的 trace_id 时是否丢弃（默认哈希为合法值，见 normalizeTraceID）
+	strictTraceID bool
+
+	// 传入 nil context 时是否输出一次 WARN（见 contextOrBackground）
+	warnNilContext bool
 )
 
 // ============================================================================
@@ -62,6 +84,40 @@ func GetTraceIDProvider() TraceIDProvider {
 	return globalTraceIDProvider
 }
 
+// ============================================================================
+// BaggageProvider 接口 - 将链路中透传的业务属性输出到日志
+// ============================================================================
+
+// baggageFieldPrefix baggage 字段名前缀，避免与日志的其他字段冲突
+const baggageFieldPrefix = "baggage."
+
+// BaggageProvider 定义 baggage 提供者接口
+// 如 OpenTelemetry baggage 中的租户、渠道等业务属性，
+// ZerologLogger 会将每一项以 "baggage.<key>" 字段输出
+type BaggageProvider interface {
+	// GetBaggage 从 context 中提取 baggage
+	// 如果 context 中没有 baggage，返回 nil
+	GetBaggage(ctx context.Context) map[string]string
+}
+
+// RegisterBaggageProvider 注册 baggage 提供者，传入 nil 取消注册
+func RegisterBaggageProvider(provider BaggageProvider) {
+	globalBaggageProvider = provider
+}
+
+// GetBaggageProvider 获取当前注册的 baggage 提供者
+func GetBaggageProvider() BaggageProvider {
+	return globalBaggageProvider
+}
+
+// getBaggage 从已注册的 BaggageProvider 获取 baggage，没有时返回 nil
+func getBaggage(ctx context.Context) map[string]string {
+	if globalBaggageProvider == nil || ctx == nil {
+		return nil
+	}
+	return globalBaggageProvider.GetBaggage(ctx)
+}
+
 // ============================================================================
 // Logger 接口 - 支持自定义日志实现
 // ============================================================================
@@ -118,6 +174,58 @@ type Logger interface {
 	ErrorWithRequestf(ctx context.Context, module, format string, requestID string, err error, costMs int64, args ...interface{})
 }
 
+// TraceLogger 可选接口：支持 TRACE 级别（低于 DEBUG）日志的 Logger 实现
+// 为兼容已有的自定义实现，TRACE 方法没有加入 Logger 接口；
+// 当前 Logger 未实现此接口时，包级 Trace/Tracef 会降级为 Debug/Debugf
+type TraceLogger interface {
+	// Trace logs a message at TRACE level
+	Trace(ctx context.Context, module, message string, fields ...Field)
+
+	// Tracef logs a formatted message at TRACE level
+	Tracef(ctx context.Context, module, format string, args ...interface{})
+}
+
+// PanicLogger 可选接口：支持 PANIC 级别日志的 Logger 实现
+// Panic 记录日志后调用 panic(message)，可以被上层的 recover 中间件捕获；
+// 与之不同，Fatal 记录日志后直接 os.Exit(1) 退出进程，defer 和 recover 都不会执行。
+// 当前 Logger 未实现此接口时，包级 Panic 会以 ERROR 级别记录后再 panic
+type PanicLogger interface {
+	// Panic logs a message at PANIC level and then panics
+	Panic(ctx context.Context, module, message string, err error, fields ...Field)
+}
+
+// FatalNoExitLogger 可选接口：记录 FATAL 级别日志并完成退出前的清理（如投递缓冲中的日志），但不退出进程
+// 供 MultiLogger 等组合实现在所有 Logger 都记录完之后再统一退出；
+// 未实现此接口的 Logger 在组合中会以 ERROR 级别记录 FATAL 日志
+type FatalNoExitLogger interface {
+	// FatalNoExit logs a message at FATAL level without exiting
+	FatalNoExit(ctx context.Context, module, message string, err error, fields ...Field)
+}
+
+// Flusher 可选接口：带缓冲的 Logger（如异步、网络上报）实现此接口以提供统一的刷新点
+// 包级 Flush 会在当前 Logger 实现此接口时调用它，用于优雅退出前投递缓冲中的日志
+type Flusher interface {
+	// Flush 同步输出缓冲中的日志，等待时间受 ctx 限制
+	Flush(ctx context.Context) error
+}
+
+// HealthChecker 可选接口：可报告输出通道健康状态的 Logger 或 writer（如异步 writer、远程上报）
+// 当前 Logger 实现此接口时，会被包级 Healthy/LastError 纳入汇总
+type HealthChecker interface {
+	// Healthy 当前是否健康（最近一次写入/上报成功且缓冲区未饱和）
+	Healthy() bool
+
+	// LastError 最近一次写入/上报失败的错误，从未失败时返回 nil
+	LastError() error
+}
+
+// LevelEnabler 可选接口：能预先判断一条日志是否会输出的 Logger 实现
+// 包级 Enabled/EnabledFor 在当前 Logger 实现此接口时调用它，否则只比较全局级别
+type LevelEnabler interface {
+	// Enabled 该 context 和 module 下 level 级别的日志是否可能输出（尽力而为，不消耗限流和采样配额）
+	Enabled(ctx context.Context, module string, level Level) bool
+}
+
 // SetLogger 设置自定义 Logger 实现
 // 允许用户在运行时替换默认的日志实现
 //
@@ -140,6 +248,32 @@ func GetLogger() Logger {
 	return globalLoggerImpl
 }
 
+// SetDefaultLogger 设置初始化之前（未调用 InitLogger/SetLogger 时）使用的 Logger
+// 在 InitLogger 之前被导入的库可能已经开始输出日志，默认情况下这些日志写入零值 zerolog（没有输出目标），
+// 设置后会交给 logger 输出；初始化或 SetLogger 之后不再使用。传入 nil 恢复默认行为
+//
+// 用法示例：
+//   func init() {
+//       zllog.SetDefaultLogger(myStderrLogger)
+//   }
+func SetDefaultLogger(logger Logger) {
+	globalDefaultLogger = logger
+}
+
+// SwapLogger 替换当前的 Logger 实现，返回恢复为原 Logger 的函数（主要用于测试）
+// 嵌套调用时按相反顺序恢复即可（配合 defer 或 t.Cleanup 自然满足）
+//
+// 用法示例：
+//   restore := zllog.SwapLogger(mockLogger)
+//   defer restore()
+func SwapLogger(logger Logger) (restore func()) {
+	previous := globalLoggerImpl
+	globalLoggerImpl = logger
+	return func() {
+		globalLoggerImpl = previous
+	}
+}
+
 // ============================================================================
 // 日志配置
 // ============================================================================
@@ -149,24 +283,111 @@ type LogConfig struct {
 	// 必须字段
 	ServiceName string // 服务名称
 	Env         string // 环境：dev/test/prod
-	LogLevel    string // 日志级别：DEBUG/INFO/WARN/ERROR/FATAL
+	LogLevel    string // 日志级别：TRACE/DEBUG/INFO/WARN/ERROR/FATAL/PANIC
 
 	// 日志文件配置
 	LogDir     string // 日志目录
 	MaxSize    int    // 单个日志文件最大大小（MB）
-	MaxBackups int    // 保留的历史日志文件个数
-	MaxAge     int    // 保留历史日志文件的最大天数
+	MaxBackups int    // 保留的历史日志文件个数（经过 AdjustConfigByEnv 时为 0 表示按环境取默认值）
+	MaxAge     int    // 保留历史日志文件的最大天数（经过 AdjustConfigByEnv 时为 0 表示按环境取默认值）
 	Compress   bool   // 是否压缩历史日志文件
+	ArchiveDir string // 历史日志归档目录（为空时不归档，相对路径相对于 LogDir，如 "archive"）
+
+	// 日志目录可用性配置（如网络挂载暂时不可用）
+	LogDirRetries       int           // LogDir 不可写时的重试次数（默认 3，负数表示不重试），仍不可写时回退到 os.TempDir()/zllog/<服务名> 并在 stderr 输出警告
+	LogDirRetryInterval time.Duration // 首次重试的间隔（默认 200ms），之后每次翻倍
+
+	// 日志文件权限配置（为 0 时使用默认值；权限不受 umask 影响）
+	FileMode os.FileMode // 日志文件权限（如 0640，默认新文件为 0600，已有文件保持不变），轮转后的新文件沿用该权限
+	DirMode  os.FileMode // 日志目录和归档目录的权限（默认 0755），需要新文件继承目录属组时可加上 os.ModeSetgid
 
 	// 日期滚动配置
-	EnableDailyRoll bool // 是否启用日期滚动（默认true）
+	EnableDailyRoll bool // 是否启用日期滚动（默认false，需显式开启）：每天写入 app-2006-01-02.log，app.log 为指向当天文件的软链接
+
+	// 全局静态字段配置
+	GlobalFields             map[string]interface{} // 每条日志都带上的静态字段（如 region、cluster、version）
+	AllowGlobalFieldOverride bool                   // 是否允许调用时传入的同名字段覆盖 GlobalFields（默认忽略同名字段）
+	IncludeBuildInfo         bool                   // 是否从构建信息（runtime/debug.ReadBuildInfo）中读取 version、vcs_revision 加入 GlobalFields（GlobalFields 中已配置的同名字段优先）
+	IncludePID               bool                   // 是否将进程号 pid 和每次初始化随机生成的 boot_id 加入 GlobalFields，用于区分进程重启前后的日志（GlobalFields 中已配置的同名字段优先）
+
+	// 输出格式配置
+	// json 格式的日志文件为 NDJSON：每行一个完整的 JSON 对象，以 \n 结尾，对象内部不换行（PrettyJSON 开启时除外）
+	OutputFormat  string // 日志文件的输出格式：json（默认）/logfmt/console（与控制台相同的文本格式，不带颜色）
+	PrettyJSON    bool   // json 格式的日志文件是否输出为多行缩进格式（默认关闭，便于开发时直接阅读）；prod 环境、开启 HashChain 或配置了 SchemaVersion 时不生效
+	SchemaVersion string // 日志格式版本（如 "1"），配置后每行日志带 schema_version 字段（覆盖 GlobalFields 中的同名字段），并保证日志文件为 NDJSON，便于采集端按版本演进解析逻辑
+
+	// 字段去重配置
+	DedupFieldKeys bool // 是否去掉同名字段（默认关闭，同名字段会输出重复的 JSON key），每个重复的字段名首次出现时输出一条 WARN
+	DedupKeepFirst bool // 去重时保留第一个值（默认保留最后一个值）
+
+	// 字段排序配置
+	SortFields bool // 是否按 key 字母序输出调用时传入的字段和 context 字段（默认按传入顺序），time/level/message/trace_id 等内置字段位置不变，便于 diff 比较日志
+
+	// 长度限制配置
+	MaxMessageLength int // 日志消息的最大字节数（0 表示不限制），超出部分截断并追加 "…(truncated)"，同时附加 truncated=true 字段
+	MaxFieldLength   int // 单个字符串字段的最大字节数（0 表示不限制），超出部分截断并追加 "…(truncated)"
+	MaxFields        int // 单条日志的最大字段数（0 表示不限制，不含 level/time/trace_id 等内置字段），超出的字段被丢弃，同时附加 fields_truncated=丢弃的字段数
+	MaxLineBytes     int // 单行日志的最大字节数（0 表示不限制），超出时整行丢弃，改为输出一条带 line_dropped=true、line_bytes 的摘要，并计入 Stats().Dropped
+
+	// 防篡改配置
+	HashChain bool // 日志文件每行追加 prev_hash/hash 字段（hash = sha256(prev_hash + 原始行)），仅 json 格式生效，可用 VerifyChain 校验
 
 	// 控制台输出配置
 	EnableConsole     bool // 是否输出到控制台（开发环境建议true）
-	ConsoleJSONFormat bool // 控制台是否使用JSON格式（false时使用彩色文本）
+	ConsoleJSONFormat bool  // 控制台是否使用JSON格式（false时使用彩色文本）
+	ConsoleColor      *bool // 彩色文本是否带颜色（nil 时自动检测：设置了 NO_COLOR 或输出不是终端时不带颜色）
+	ConsoleMinimal    bool  // 控制台是否使用精简格式 "级别: 消息 key=value"（适合命令行工具，不输出时间、服务名等公共字段，日志文件不受影响）
+	ConsoleKeyCase    string // 彩色文本中字段名的显示方式：as-is（默认）/snake/camel，JSON 中的字段名不变
+	ConsoleAlign      bool   // 彩色文本是否按列对齐（级别、caller、消息补齐到固定宽度，字段从同一列开始）
+	SplitStdStreams   bool  // 控制台按级别拆分输出：ERROR/FATAL/PANIC 输出到 stderr，其余输出到 stdout（默认全部输出到 stdout）
 
 	// 调用位置信息配置
-	EnableCaller bool // 是否记录调用位置（文件名和行号）
+	EnableCaller bool   // 是否记录调用位置（文件名和行号）；DefaultConfig 和配置文件加载的配置默认开启
+	CallerSkip   int    // 额外跳过的调用帧数（默认0，在 zllog 外再封装一层日志函数时设为1）
+	CallerFormat string // caller 输出格式：short（默认，文件名:行号）/full（完整路径:行号）/object（含 file、line、function 的对象）
+
+	// trace_id 校验配置
+	StrictTraceID bool // TraceIDProvider 返回不合法的 trace_id（非 32 位十六进制）时输出一次 WARN 并丢弃（默认哈希为合法的 trace_id）
+
+	// context 状态配置
+	EnableCtxErr   bool // context 已取消/超时时是否附加 ctx_err 字段（默认关闭）
+	WarnNilContext bool // 传入 nil context 时输出一次 WARN 提示误用（nil context 始终按 context.Background() 处理）
+
+	// 并发调试配置
+	EnableGoroutineID bool // 是否附加 goroutine_id 字段（默认关闭，每条日志需额外解析调用栈）
+
+	// 初始化信息配置
+	SuppressInitLog bool // 是否不输出初始化成功信息（event=logger_initialized，适合 serverless、测试等环境）
+
+	// 指标配置
+	EnableExpvar bool // 是否将日志统计发布到 expvar（/debug/vars 中的 zllog：各级别计数、丢弃数、缓冲区深度、最近错误）
+
+	// 错误码级别配置
+	ErrorCodeLevels map[string]string // 错误码对应的输出级别（如 {"VALIDATION_001": "WARN"}），ErrorWithCode 未配置的错误码使用 ERROR，错误码不区分大小写
+
+	// 采样配置（ERROR 及以上级别默认不参与任何采样，包括 TraceSampleRate，只有在 LevelSampling 中显式配置时才按配置突发采样）
+	TraceSampleRate float64                   // 按 trace_id 一致性采样的保留比例（0~1 之间生效，0 或 >=1 表示不采样）
+	Sampling        SamplingConfig            // 突发采样：同一条日志每个周期先保留 Initial 条，之后每 Thereafter 条保留 1 条（保留的日志带 sample_reason 字段）
+	LevelSampling   map[string]SamplingConfig // 各级别的突发采样配置（key 为级别名，不区分大小写），覆盖该级别的 Sampling；Initial 为 0 表示该级别不采样
+
+	// 限流配置
+	ModuleRateLimits map[string]int // 各 module 每秒最多输出的日志条数（按 module 全名匹配），超出的丢弃并每秒输出一条 dropped_by_ratelimit 汇总
+
+	// 按环境屏蔽 module
+	ModuleEnvAllowlist map[string][]string // module 允许输出日志的环境（如 {"payment.card": ["prod"]}），其他环境该 module 及其子 module 的日志全部丢弃；未配置的 module 不受限制
+
+	// panic 恢复配置
+	RecoverRepanic bool // Recover 记录 panic 后是否重新抛出（默认吞掉 panic）
+
+	// 异步写入配置
+	Async AsyncConfig // 异步写入（默认关闭，开启后日志先进入缓冲区再由后台写入）
+
+	// 审计日志配置
+	Audit AuditConfig // 审计日志（默认关闭，开启后 Audit 事件写入独立的防篡改文件）
+
+	// 配置来源（由 ConfigLoader.LoadConfig 和 InitLoggerFromFile 填写，直接构造的配置为空）
+	Source     string // 配置来源：log.yaml/application.yaml/env（application_{ENV}.yaml）/default（未找到配置文件，使用默认配置）
+	SourceFile string // 实际读取的配置文件路径，Source 为 default 时为空
 }
 
 // DefaultConfig 返回默认配置（符合等保3最低要求）
@@ -180,7 +401,7 @@ func DefaultConfig(serviceName string) *LogConfig {
 		MaxBackups:      180, // 保留180个历史文件（配合每日切割，可保留180天）
 		MaxAge:          180, // 保留180天（6个月，符合等保3对ERROR日志的最低要求）
 		Compress:        true, // 启用压缩（等保3要求）
-		EnableDailyRoll: true, // 启用日期滚动（每天切割）
+		EnableDailyRoll: false, // 日期滚动需显式开启（开启后 app.log 变为软链接，会影响直接读取 app.log 的采集配置）
 		EnableConsole:   true, // 开发环境默认开启控制台输出
 		ConsoleJSONFormat: false, // 控制台使用彩色文本格式（更友好）
 		EnableCaller:    true, // 默认启用调用位置记录
@@ -214,9 +435,17 @@ func InitLoggerWithConfigDir(configDir string) error {
 func InitLoggerWithConfig(config *LogConfig) error {
 	var initErr error
 	onceInit.Do(func() {
-		// 保存全局配置
+		// 保存全局配置（服务名和环境为空时自动检测）
+		globalEffectiveConfig = newEffectiveConfig(config)
+		config = &globalEffectiveConfig
 		serviceName = config.ServiceName
 		envName = config.Env
+		recoverRepanic = config.RecoverRepanic
+		strictTraceID = config.StrictTraceID
+		warnNilContext = config.WarnNilContext
+		if config.EnableExpvar {
+			publishExpvar()
+		}
 		if h, err := os.Hostname(); err == nil {
 			hostName = h
 		} else {
@@ -233,10 +462,12 @@ func InitLoggerWithConfig(config *LogConfig) error {
 
 		// 设置时间格式为纳秒精度（更适合日志分析和高并发场景）
 		zerolog.TimeFieldFormat = time.RFC3339Nano
+		// time 字段使用可替换的时钟（见 SetClock）
+		zerolog.TimestampFunc = Now
 
 		// 配置调用位置信息的格式（只显示文件名和行号，不显示完整路径）
 		// 注意：我们不在 logger 初始化时启用 Caller()，因为 Zerolog 会捕获到库内部的位置
-		// 而是在 ZerologLogger 的方法中手动通过 getCaller() 获取用户代码的位置
+		// 而是在 ZerologLogger 的方法中手动通过 callerFrame() 获取用户代码的位置
 		zerolog.CallerMarshalFunc = func(pc uintptr, file string, line int) string {
 			shortFile := filepath.Base(file)
 			return fmt.Sprintf("%s:%d", shortFile, line)
@@ -256,34 +487,40 @@ func InitLoggerWithConfig(config *LogConfig) error {
 		}
 
 		// 多路输出（文件 + 控制台）
-		multiWriter := zerolog.MultiLevelWriter(writers...)
+		var output io.Writer = zerolog.MultiLevelWriter(writers...)
 
-		// 创建全局logger（添加基础字段）
-		loggerBuilder := zerolog.New(multiWriter).
-			Level(level).
-			With().
-			Timestamp()
+		// 原始输出：写入同一个日志文件，控制台不经过 ConsoleWriter（其只能解析 JSON）
+		rawWriters := []io.Writer{logFile}
+		if config.EnableConsole {
+			rawWriters = append(rawWriters, os.Stdout)
+		}
+		globalRawWriter = zerolog.MultiLevelWriter(rawWriters...)
 
-		// 注意：我们不在 logger 初始化时启用 Caller()，因为 Zerolog 会捕获到库内部的位置
-		// 而是在 ZerologLogger 的方法中手动通过 getCaller() 获取用户代码的位置
-		// config.EnableCaller 配置项用于控制是否启用这个功能（在 ZerologLogger 中检查）
+		// 异步写入（缓冲区 + 后台 goroutine）
+		if config.Async.Enabled {
+			globalAsyncWriter = NewAsyncWriter(output, config.Async)
+			output = globalAsyncWriter
+		}
+
+		// 单行大小限制（在异步缓冲区之前检查，超长的行不占用缓冲区）
+		output = newLineLimitWriter(output, config.MaxLineBytes)
+
+		// 创建全局logger（添加基础字段）
+		// logger 自身不限制级别，只由全局级别控制，以便运行时通过 SetLevel 调整
+		globalLogger = newBaseLogger(output, zerolog.TraceLevel, config)
 
-		globalLogger = loggerBuilder.
-			Str("service", serviceName).
-			Str("env", config.Env).
-			Str("host", hostName).
-			Logger()
+		// 审计日志（独立文件）
+		if config.Audit.Enabled {
+			globalAuditLogger = newAuditFileLogger(config)
+		}
 
 		// ✅ 创建默认的 ZerologLogger 实现
-		globalLoggerImpl = NewZerologLogger(&globalLogger)
-
-		// 打印初始化成功信息
-		globalLogger.Info().
-			Str("service", serviceName).
-			Str("env", config.Env).
-			Str("level", config.LogLevel).
-			Str("dir", config.LogDir).
-			Send()
+		zl := newZerologLoggerWithConfig(&globalLogger, config)
+		zl.out = output
+		globalLoggerImpl = zl
+
+		// 打印初始化成功信息（附带实际生效的配置）
+		logInitialized(&globalLogger, config)
 	})
 
 	return initErr
@@ -305,6 +542,7 @@ func InitLoggerFromFile(filename string) error {
 			return fmt.Errorf("failed to read config file: %w", err)
 		}
 		config := loader.parseLogConfig(v)
+		config.Source, config.SourceFile = configSourceForFile(filename), filename
 		return InitLoggerWithConfig(config)
 	} else {
 		// application.yaml 或 application_{ENV}.yaml
@@ -314,65 +552,150 @@ func InitLoggerFromFile(filename string) error {
 			return fmt.Errorf("failed to read config file: %w", err)
 		}
 		config := loader.parseLoggerConfig(v)
+		config.Source, config.SourceFile = configSourceForFile(filename), filename
 		return InitLoggerWithConfig(config)
 	}
 }
 
+// newBaseLogger 创建带基础字段（时间、service、env、host 以及 GlobalFields）的 zerolog Logger
+func newBaseLogger(output io.Writer, level zerolog.Level, config *LogConfig) zerolog.Logger {
+	// 注意：我们不在 logger 初始化时启用 Caller()，因为 Zerolog 会捕获到库内部的位置
+	// 而是在 ZerologLogger 的方法中手动通过 callerFrame() 获取用户代码的位置
+	// config.EnableCaller 配置项用于控制是否启用这个功能（在 ZerologLogger 中检查）
+	// config.EnableCtxErr 同样由 ZerologLogger 在每次输出时检查
+	return zerolog.New(output).
+		Level(level).
+		With().
+		Timestamp().
+		Str("service", serviceName).
+		Str("env", config.Env).
+		Str("host", hostName).
+		Fields(config.GlobalFields).
+		Logger()
+}
+
 // parseLevel 解析日志级别字符串
 func parseLevel(levelStr string) (zerolog.Level, error) {
-	switch strings.ToUpper(levelStr) {
-	case "DEBUG":
-		return zerolog.DebugLevel, nil
-	case "INFO":
-		return zerolog.InfoLevel, nil
-	case "WARN", "WARNING":
-		return zerolog.WarnLevel, nil
-	case "ERROR":
-		return zerolog.ErrorLevel, nil
-	case "FATAL":
-		return zerolog.FatalLevel, nil
-	default:
-		return zerolog.InfoLevel, fmt.Errorf("unknown log level: %s", levelStr)
+	level, err := ParseLevel(levelStr)
+	return level.zerologLevel(), err
+}
+
+// logInitialized 输出初始化成功信息：event=logger_initialized，附带实际生效的配置
+// LogConfig.SuppressInitLog 为 true 时不输出
+func logInitialized(logger *zerolog.Logger, config *LogConfig) {
+	if config.SuppressInitLog {
+		return
 	}
+	logger.Info().
+		Str("event", "logger_initialized").
+		Str("service", serviceName).
+		Str("env", config.Env).
+		Str("level", config.LogLevel).
+		Str("dir", config.LogDir).
+		Str("config_source", config.Source).
+		Str("config_file", config.SourceFile).
+		Dict("config", effectiveConfigDict(config)).
+		Send()
 }
 
 // createLogFileWriter 创建日志文件输出writer
 func createLogFileWriter(config *LogConfig) io.Writer {
-	// 确保日志目录存在
-	os.MkdirAll(config.LogDir, 0755)
+	// 确认日志目录可写，不可写时重试并回退到临时目录（生效配置中的 LogDir 同步更新）
+	config.LogDir = resolveLogDir(config)
 
 	// 日志文件路径
 	logFilePath := filepath.Join(config.LogDir, "app.log")
 
-	// 使用lumberjack进行日志轮转
-	return &lumberjack.Logger{
-		Filename:   logFilePath,
-		MaxSize:    config.MaxSize,    // MB
-		MaxBackups: config.MaxBackups, // 保留历史文件数
-		MaxAge:     config.MaxAge,     // 天数
-		Compress:   config.Compress,   // 压缩
+	var w io.Writer
+	if config.EnableDailyRoll {
+		// 按日期滚动：写入 app-2006-01-02.log，app.log 为指向当天文件的软链接
+		w = newDailyRollWriter(config, logFilePath)
+	} else {
+		// 确保日志目录和日志文件存在并设置权限
+		prepareLogFile(config, logFilePath)
+
+		// 使用lumberjack进行日志轮转
+		rotator := &lumberjack.Logger{
+			Filename:   logFilePath,
+			MaxSize:    config.MaxSize,    // MB
+			MaxBackups: config.MaxBackups, // 保留历史文件数
+			MaxAge:     config.MaxAge,     // 天数
+			Compress:   config.Compress,   // 压缩
+		}
+
+		// 配置了归档目录时，轮转后的历史文件会被移动到归档目录
+		w = rotator
+		if archiveDir := resolveArchiveDir(config); archiveDir != "" {
+			w = newArchiveWriter(rotator, archiveDir, config.DirMode)
+		}
+	}
+
+	switch strings.ToLower(config.OutputFormat) {
+	case OutputFormatLogfmt:
+		return stripFieldHints(NewLogfmtWriter(w), config)
+	case OutputFormatConsole:
+		return newPlainTextWriter(w, config)
+	default:
+		// 哈希链在写入 lumberjack 之前计算，起点为已有文件最后一行的 hash
+		if config.HashChain {
+			return stripFieldHints(newHashChainWriter(w, lastChainHash(logFilePath)), config)
+		}
+		// 缩进格式只用于开发环境阅读，生产环境和声明了 SchemaVersion 的采集场景始终输出 NDJSON
+		if config.PrettyJSON && !isProdEnv(config.Env) && config.SchemaVersion == "" {
+			return stripFieldHints(newPrettyJSONWriter(w), config)
+		}
+		return stripFieldHints(w, config)
+	}
+}
+
+// prepareLogFile 按 LogConfig.DirMode/FileMode 创建日志目录和日志文件
+// lumberjack 轮转时新文件沿用旧文件的权限和属主，因此只需设置好当前文件的权限
+// 显式配置的权限通过 Chmod 设置，不受 umask 影响
+func prepareLogFile(config *LogConfig, path string) {
+	makeLogDir(filepath.Dir(path), config.DirMode)
+
+	if config.FileMode != 0 {
+		if f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, config.FileMode); err == nil {
+			f.Close()
+		}
+		os.Chmod(path, config.FileMode)
 	}
 }
 
+// makeLogDir 按 DirMode 创建日志目录（为 0 时使用 0755），显式配置的权限通过 Chmod 设置，不受 umask 影响
+func makeLogDir(dir string, mode os.FileMode) error {
+	if mode == 0 {
+		return os.MkdirAll(dir, 0755)
+	}
+	if err := os.MkdirAll(dir, mode); err != nil {
+		return err
+	}
+	return os.Chmod(dir, mode)
+}
+
 // createConsoleWriter 创建控制台输出writer
 func createConsoleWriter(config *LogConfig) io.Writer {
+	if config.SplitStdStreams {
+		// ERROR 及以上级别输出到 stderr，其余输出到 stdout
+		return newLevelSplitWriter(createConsoleOutput(os.Stdout, config), createConsoleOutput(os.Stderr, config))
+	}
+	return createConsoleOutput(os.Stdout, config)
+}
+
+// createConsoleOutput 按配置的控制台格式创建输出到 out 的 writer
+func createConsoleOutput(out io.Writer, config *LogConfig) io.Writer {
 	if config.ConsoleJSONFormat {
 		// JSON格式（适合生产环境日志采集）
-		return os.Stdout
+		return stripFieldHints(out, config)
 	}
 
-	// 彩色文本格式（开发环境友好）
-	return zerolog.ConsoleWriter{
-		Out:        os.Stdout,
-		NoColor:    false,
-		TimeFormat: "2006-01-02 15:04:05",
-		FormatLevel: func(i interface{}) string {
-			return fmt.Sprintf("[%s]", strings.ToUpper(i.(string)))
-		},
-		FormatMessage: func(i interface{}) string {
-			return fmt.Sprintf("%s", i)
-		},
+	// 精简格式（命令行工具）
+	if config.ConsoleMinimal {
+		return newMinimalConsoleWriter(out, config)
 	}
+
+	// 彩色文本格式（开发环境友好）
+	return newConsoleWriter(out, config)
 }
 
 // GetGlobalLogger 获取全局logger实例
@@ -380,6 +703,28 @@ func GetGlobalLogger() *zerolog.Logger {
 	return &globalLogger
 }
 
+// Writer 返回初始化时创建的原始输出（日志文件，启用控制台时同时输出到标准输出）
+// 用于让第三方库将输出写入同一个带轮转的日志文件，如 http.Server.ErrorLog：
+//   srv.ErrorLog = log.New(zllog.Writer(), "http: ", log.LstdFlags)
+//
+// 写入的内容原样输出，不会添加 trace_id 等字段，也不经过异步缓冲区。
+// 可以并发调用（日志文件的写入由 lumberjack 加锁），但每次 Write 应写入完整的一行，
+// 否则多个 goroutine 的内容可能交错。初始化之前调用时返回 os.Stderr
+func Writer() io.Writer {
+	if globalRawWriter == nil {
+		return os.Stderr
+	}
+	return globalRawWriter
+}
+
+// DroppedCount 返回异步写入因缓冲区溢出而丢弃的日志条数（未启用异步写入时为 0）
+func DroppedCount() uint64 {
+	if globalAsyncWriter == nil {
+		return 0
+	}
+	return globalAsyncWriter.DroppedCount()
+}
+
 // GetServiceName 获取服务名称
 func GetServiceName() string {
 	return serviceName
@@ -390,6 +735,158 @@ func GetEnvName() string {
 	return envName
 }
 
+// EffectiveConfig 返回初始化时实际生效的配置副本
+// 包含环境变量、配置文件和默认值合并后的结果，以及自动检测的服务名和环境；初始化之前返回零值
+// 修改返回值不会影响正在运行的日志系统
+func EffectiveConfig() LogConfig {
+	return cloneConfig(globalEffectiveConfig)
+}
+
+// newEffectiveConfig 复制配置并补全自动检测的服务名和环境
+func newEffectiveConfig(config *LogConfig) LogConfig {
+	effective := cloneConfig(*config)
+	if effective.ServiceName == "" {
+		effective.ServiceName = detectServiceName()
+	}
+	if effective.Env == "" {
+		effective.Env = detectEnv()
+	}
+	if effective.IncludeBuildInfo {
+		addBuildInfo(&effective)
+	}
+	if effective.IncludePID {
+		addProcessInfo(&effective)
+	}
+	if effective.SchemaVersion != "" {
+		if effective.GlobalFields == nil {
+			effective.GlobalFields = make(map[string]interface{}, 1)
+		}
+		effective.GlobalFields["schema_version"] = effective.SchemaVersion
+	}
+	return effective
+}
+
+// cloneConfig 深拷贝配置中的 map 和指针字段
+func cloneConfig(config LogConfig) LogConfig {
+	if config.GlobalFields != nil {
+		fields := make(map[string]interface{}, len(config.GlobalFields))
+		for k, v := range config.GlobalFields {
+			fields[k] = v
+		}
+		config.GlobalFields = fields
+	}
+	if config.ErrorCodeLevels != nil {
+		levels := make(map[string]string, len(config.ErrorCodeLevels))
+		for k, v := range config.ErrorCodeLevels {
+			levels[k] = v
+		}
+		config.ErrorCodeLevels = levels
+	}
+	if config.LevelSampling != nil {
+		sampling := make(map[string]SamplingConfig, len(config.LevelSampling))
+		for k, v := range config.LevelSampling {
+			sampling[k] = v
+		}
+		config.LevelSampling = sampling
+	}
+	if config.ModuleRateLimits != nil {
+		limits := make(map[string]int, len(config.ModuleRateLimits))
+		for k, v := range config.ModuleRateLimits {
+			limits[k] = v
+		}
+		config.ModuleRateLimits = limits
+	}
+	if config.ModuleEnvAllowlist != nil {
+		allowlist := make(map[string][]string, len(config.ModuleEnvAllowlist))
+		for k, v := range config.ModuleEnvAllowlist {
+			allowlist[k] = append([]string(nil), v...)
+		}
+		config.ModuleEnvAllowlist = allowlist
+	}
+	if config.ConsoleColor != nil {
+		color := *config.ConsoleColor
+		config.ConsoleColor = &color
+	}
+	return config
+}
+
+// effectiveConfigDict 启动日志中输出的配置（HMAC 密钥等敏感信息不输出）
+func effectiveConfigDict(config *LogConfig) *zerolog.Event {
+	dict := zerolog.Dict().
+		Int("max_size", config.MaxSize).
+		Int("max_backups", config.MaxBackups).
+		Int("max_age", config.MaxAge).
+		Bool("compress", config.Compress).
+		Str("archive_dir", config.ArchiveDir).
+		Str("file_mode", fmt.Sprintf("%#o", uint32(config.FileMode))).
+		Str("dir_mode", fmt.Sprintf("%#o", uint32(config.DirMode))).
+		Int("log_dir_retries", config.LogDirRetries).
+		Dur("log_dir_retry_interval", config.LogDirRetryInterval).
+		Bool("daily_roll", config.EnableDailyRoll).
+		Str("output_format", config.OutputFormat).
+		Bool("pretty_json", config.PrettyJSON).
+		Str("schema_version", config.SchemaVersion).
+		Int("max_message_length", config.MaxMessageLength).
+		Int("max_field_length", config.MaxFieldLength).
+		Int("max_fields", config.MaxFields).
+		Int("max_line_bytes", config.MaxLineBytes).
+		Bool("dedup_field_keys", config.DedupFieldKeys).
+		Bool("dedup_keep_first", config.DedupKeepFirst).
+		Bool("sort_fields", config.SortFields).
+		Bool("hash_chain", config.HashChain).
+		Bool("console", config.EnableConsole).
+		Bool("console_json", config.ConsoleJSONFormat).
+		Bool("console_minimal", config.ConsoleMinimal).
+		Str("console_key_case", config.ConsoleKeyCase).
+		Bool("console_align", config.ConsoleAlign).
+		Bool("split_std_streams", config.SplitStdStreams).
+		Bool("caller", config.EnableCaller).
+		Int("caller_skip", config.CallerSkip).
+		Str("caller_format", config.CallerFormat).
+		Bool("strict_trace_id", config.StrictTraceID).
+		Bool("warn_nil_context", config.WarnNilContext).
+		Bool("ctx_err", config.EnableCtxErr).
+		Bool("goroutine_id", config.EnableGoroutineID).
+		Bool("expvar", config.EnableExpvar).
+		Float64("trace_sample_rate", config.TraceSampleRate).
+		Int("sampling_initial", config.Sampling.Initial).
+		Int("sampling_thereafter", config.Sampling.Thereafter).
+		Dur("sampling_tick", config.Sampling.Tick).
+		Bool("recover_repanic", config.RecoverRepanic).
+		Bool("async", config.Async.Enabled).
+		Bool("audit", config.Audit.Enabled).
+		Bool("audit_hmac", config.Audit.HMACKey != "")
+	if config.ConsoleColor != nil {
+		dict = dict.Bool("console_color", *config.ConsoleColor)
+	}
+	if config.Async.Enabled {
+		dict = dict.Int("async_buffer_size", config.Async.BufferSize).
+			Str("async_overflow_policy", string(config.Async.OverflowPolicy))
+	}
+	if config.IncludeBuildInfo {
+		dict = dict.Bool("include_build_info", true)
+	}
+	if config.IncludePID {
+		dict = dict.Bool("include_pid", true)
+	}
+	if len(config.GlobalFields) > 0 {
+		dict = dict.Interface("global_fields", config.GlobalFields)
+	}
+	if len(config.ErrorCodeLevels) > 0 {
+		dict = dict.Interface("error_code_levels", config.ErrorCodeLevels)
+	}
+	if len(config.LevelSampling) > 0 {
+		dict = dict.Interface("level_sampling", config.LevelSampling)
+	}
+	if len(config.ModuleRateLimits) > 0 {
+		dict = dict.Interface("module_rate_limits", config.ModuleRateLimits)
+	}
+	if len(config.ModuleEnvAllowlist) > 0 {
+		dict = dict.Interface("module_env_allowlist", config.ModuleEnvAllowlist)
+	}
+	return dict
+}
+
 // ============================================================================
 // Trace ID 工具函数
 // ============================================================================
@@ -400,13 +897,74 @@ func GetEnvName() string {
 // 3. 生成的 trace_id 符合 W3C Trace Context 标准（32位十六进制字符）
 func GetOrCreateTraceID(ctx context.Context) string {
 	// 1. 尝试从 context 获取 trace_id
+	if traceID := getTraceID(ctx); traceID != "" {
+		return traceID
+	}
+
+	// 2. 如果没有 trace_id，自动生成一个符合 W3C 标准的 trace_id
+	return newTraceID()
+}
+
+// getTraceID 从已注册的 TraceIDProvider 获取 trace_id，
+// 取不到时使用 context 中固定的 trace_id（见 FromContext），都没有时返回空字符串
+// Provider 返回的 trace_id 不是合法的 W3C trace_id 时按 LogConfig.StrictTraceID 处理（见 normalizeTraceID）
+func getTraceID(ctx context.Context) string {
+	if ctx == nil {
+		ctx = context.Background()
+	}
 	if globalTraceIDProvider != nil {
-		if traceID := globalTraceIDProvider.GetTraceID(ctx); traceID != "" {
+		if traceID := normalizeTraceID(globalTraceIDProvider.GetTraceID(ctx)); traceID != "" {
 			return traceID
 		}
 	}
+	return traceIDFromContext(ctx)
+}
 
-	// 2. 如果没有 trace_id，自动生成一个符合 W3C 标准的 trace_id
+// IsValidTraceID 判断是否为合法的 W3C trace_id：32 位小写十六进制字符且不全为 0
+func IsValidTraceID(traceID string) bool {
+	if len(traceID) != 32 {
+		return false
+	}
+	allZero := true
+	for i := 0; i < len(traceID); i++ {
+		c := traceID[i]
+		switch {
+		case c == '0':
+		case c >= '1' && c <= '9', c >= 'a' && c <= 'f':
+			allZero = false
+		default:
+			return false
+		}
+	}
+	return !allZero
+}
+
+// normalizeTraceID 校验 Provider 返回的 trace_id，大写的十六进制 trace_id 转为小写
+// 其他不合法的 trace_id：
+//   - 默认：哈希为合法的 trace_id（同一个原始值总是得到同一个结果，日志仍能按 trace 关联）
+//   - StrictTraceID：输出一次 WARN 后丢弃（返回空字符串，由调用方重新生成）
+func normalizeTraceID(traceID string) string {
+	if traceID == "" || IsValidTraceID(traceID) {
+		return traceID
+	}
+	if lower := strings.ToLower(traceID); IsValidTraceID(lower) {
+		return lower
+	}
+
+	if strictTraceID {
+		// 先记录再输出，输出 WARN 时再次取 trace_id 不会重复提示
+		if _, loaded := onceKeys.LoadOrStore("invalid_trace_id", struct{}{}); !loaded {
+			Warn(context.Background(), "zllog", "invalid trace_id from provider replaced",
+				String("invalid_trace_id", traceID), String("provider", globalTraceIDProvider.Name()))
+		}
+		return ""
+	}
+	sum := sha256.Sum256([]byte(traceID))
+	return hex.EncodeToString(sum[:16])
+}
+
+// newTraceID 生成符合 W3C 标准的 trace_id（32位十六进制字符）
+func newTraceID() string {
 	// 使用 hex 编码，性能优于 strings.Replace
 	traceID := uuid.New()
 	return hex.EncodeToString(traceID[:])
@@ -419,40 +977,106 @@ func GetOrCreateTraceID(ctx context.Context) string {
 // getLogger 获取当前 logger 实现（如果未设置则使用默认实现）
 func getLogger() Logger {
 	if globalLoggerImpl == nil {
-		// 如果没有设置自定义实现，使用默认的 ZerologLogger
+		// 尚未初始化：优先使用 SetDefaultLogger 设置的 Logger，否则使用默认的 ZerologLogger
+		if globalDefaultLogger != nil {
+			return globalDefaultLogger
+		}
 		return NewZerologLogger(&globalLogger)
 	}
 	return globalLoggerImpl
 }
 
+// Trace logs a message at TRACE level
+// 当前 Logger 未实现 TraceLogger 时降级为 DEBUG 级别
+func Trace(ctx context.Context, module, message string, fields ...Field) {
+	logger := getLogger()
+	if tl, ok := logger.(TraceLogger); ok {
+		countLevelFor(logger, zerolog.TraceLevel)
+		tl.Trace(ctx, module, message, fields...)
+		return
+	}
+	Debug(ctx, module, message, fields...)
+}
+
 // Debug logs a message at DEBUG level
 func Debug(ctx context.Context, module, message string, fields ...Field) {
-	getLogger().Debug(ctx, module, message, fields...)
+	logger := getLogger()
+	countLevelFor(logger, zerolog.DebugLevel)
+	logger.Debug(ctx, module, message, fields...)
 }
 
 // Info logs a message at INFO level
 func Info(ctx context.Context, module, message string, fields ...Field) {
-	getLogger().Info(ctx, module, message, fields...)
+	logger := getLogger()
+	countLevelFor(logger, zerolog.InfoLevel)
+	logger.Info(ctx, module, message, fields...)
 }
 
 // Warn logs a message at WARN level
 func Warn(ctx context.Context, module, message string, fields ...Field) {
-	getLogger().Warn(ctx, module, message, fields...)
+	logger := getLogger()
+	countLevelFor(logger, zerolog.WarnLevel)
+	logger.Warn(ctx, module, message, fields...)
 }
 
 // Error logs a message at ERROR level with error info
 func Error(ctx context.Context, module, message string, err error, fields ...Field) {
-	getLogger().Error(ctx, module, message, err, fields...)
+	logger := getLogger()
+	countLevelFor(logger, zerolog.ErrorLevel)
+	logger.Error(ctx, module, message, err, fields...)
 }
 
 // ErrorWithCode logs a message at ERROR level with error code
 func ErrorWithCode(ctx context.Context, module, message, errorCode string, err error, fields ...Field) {
-	getLogger().ErrorWithCode(ctx, module, message, errorCode, err, fields...)
+	logger := getLogger()
+	countLevelFor(logger, zerolog.ErrorLevel)
+	logger.ErrorWithCode(ctx, module, message, errorCode, err, fields...)
+}
+
+// LogErr 输出 ERROR 日志并原样返回 err，用于 "记录后返回" 的场景；err 为 nil 时不输出日志并返回 nil
+// 需要附加上下文时由调用方包装返回值
+//
+// 用法示例：
+//   if err := repo.Save(ctx, order); err != nil {
+//       return zllog.LogErr(ctx, "order", "save order failed", err, zllog.String("order_id", order.ID))
+//   }
+//   return fmt.Errorf("create order: %w", zllog.LogErr(ctx, "order", "save order failed", err))
+func LogErr(ctx context.Context, module, message string, err error, fields ...Field) error {
+	if err == nil {
+		return nil
+	}
+	Error(ctx, module, message, err, fields...)
+	return err
+}
+
+// LogErrWithCode 与 LogErr 相同，附带错误码
+func LogErrWithCode(ctx context.Context, module, message, errorCode string, err error, fields ...Field) error {
+	if err == nil {
+		return nil
+	}
+	ErrorWithCode(ctx, module, message, errorCode, err, fields...)
+	return err
 }
 
 // Fatal logs a message at FATAL level and exits
 func Fatal(ctx context.Context, module, message string, err error, fields ...Field) {
-	getLogger().Fatal(ctx, module, message, err, fields...)
+	logger := getLogger()
+	countLevelFor(logger, zerolog.FatalLevel)
+	logger.Fatal(ctx, module, message, err, fields...)
+}
+
+// Panic logs a message at PANIC level and then panics
+// 与 Fatal 不同，Panic 不会退出进程，panic 可以被 recover 捕获（如 HTTP 框架的 recover 中间件）
+// 当前 Logger 未实现 PanicLogger 时，以 ERROR 级别记录后 panic
+func Panic(ctx context.Context, module, message string, err error, fields ...Field) {
+	logger := getLogger()
+	if pl, ok := logger.(PanicLogger); ok {
+		countLevelFor(logger, zerolog.PanicLevel)
+		pl.Panic(ctx, module, message, err, fields...)
+		return
+	}
+	Error(ctx, module, message, err, fields...)
+	panic(message)
 }
 
 // ============================================================================
@@ -461,55 +1085,147 @@ func Fatal(ctx context.Context, module, message string, err error, fields ...Fie
 
 // InfoWithRequest INFO日志 + request_id + cost_ms
 func InfoWithRequest(ctx context.Context, module, message, requestID string, costMs int64, fields ...Field) {
-	getLogger().InfoWithRequest(ctx, module, message, requestID, costMs, fields...)
+	logger := getLogger()
+	countLevelFor(logger, zerolog.InfoLevel)
+	logger.InfoWithRequest(ctx, module, message, requestID, costMs, fields...)
 }
 
 // ErrorWithRequest ERROR日志 + request_id + cost_ms
 func ErrorWithRequest(ctx context.Context, module, message, requestID string, err error, costMs int64, fields ...Field) {
-	getLogger().ErrorWithRequest(ctx, module, message, requestID, err, costMs, fields...)
+	logger := getLogger()
+	countLevelFor(logger, zerolog.ErrorLevel)
+	logger.ErrorWithRequest(ctx, module, message, requestID, err, costMs, fields...)
 }
 
 // ============================================================================
 // 格式化日志方法（支持 Printf 风格的参数替换）
 // ============================================================================
 
+// 格式化方法的 args 末尾可以追加结构化字段：末尾连续的 Field 不参与格式化，作为字段输出
+// （默认的 ZerologLogger 和 EntryLogger 支持，自定义 Logger 需自行处理）
+//
+//   zllog.Infof(ctx, "order", "created %d items", n, zllog.String("user_id", uid))
+//   // => message="created 3 items" user_id=...
+
+// Tracef logs a formatted message at TRACE level
+// 当前 Logger 未实现 TraceLogger 时降级为 DEBUG 级别
+func Tracef(ctx context.Context, module, format string, args ...interface{}) {
+	logger := getLogger()
+	if tl, ok := logger.(TraceLogger); ok {
+		countLevelFor(logger, zerolog.TraceLevel)
+		tl.Tracef(ctx, module, format, args...)
+		return
+	}
+	Debugf(ctx, module, format, args...)
+}
+
 // Debugf logs a formatted message at DEBUG level
 func Debugf(ctx context.Context, module, format string, args ...interface{}) {
-	getLogger().Debugf(ctx, module, format, args...)
+	logger := getLogger()
+	countLevelFor(logger, zerolog.DebugLevel)
+	logger.Debugf(ctx, module, format, args...)
 }
 
 // Infof logs a formatted message at INFO level
 func Infof(ctx context.Context, module, format string, args ...interface{}) {
-	getLogger().Infof(ctx, module, format, args...)
+	logger := getLogger()
+	countLevelFor(logger, zerolog.InfoLevel)
+	logger.Infof(ctx, module, format, args...)
 }
 
 // Warnf logs a formatted message at WARN level
 func Warnf(ctx context.Context, module, format string, args ...interface{}) {
-	getLogger().Warnf(ctx, module, format, args...)
+	logger := getLogger()
+	countLevelFor(logger, zerolog.WarnLevel)
+	logger.Warnf(ctx, module, format, args...)
 }
 
 // Errorf logs a formatted message at ERROR level with error info
 func Errorf(ctx context.Context, module, format string, err error, args ...interface{}) {
-	getLogger().Errorf(ctx, module, format, err, args...)
+	logger := getLogger()
+	countLevelFor(logger, zerolog.ErrorLevel)
+	logger.Errorf(ctx, module, format, err, args...)
 }
 
 // ErrorWithCodef logs a formatted message at ERROR level with error code
 func ErrorWithCodef(ctx context.Context, module, format string, errorCode string, err error, args ...interface{}) {
-	getLogger().ErrorWithCodef(ctx, module, format, errorCode, err, args...)
+	logger := getLogger()
+	countLevelFor(logger, zerolog.ErrorLevel)
+	logger.ErrorWithCodef(ctx, module, format, errorCode, err, args...)
 }
 
 // Fatalf logs a formatted message at FATAL level and exits
 func Fatalf(ctx context.Context, module, format string, err error, args ...interface{}) {
-	getLogger().Fatalf(ctx, module, format, err, args...)
+	logger := getLogger()
+	countLevelFor(logger, zerolog.FatalLevel)
+	logger.Fatalf(ctx, module, format, err, args...)
 }
 
 // InfoWithRequestf INFO日志 + request_id + cost_ms (formatted)
 func InfoWithRequestf(ctx context.Context, module, format string, requestID string, costMs int64, args ...interface{}) {
-	getLogger().InfoWithRequestf(ctx, module, format, requestID, costMs, args...)
+	logger := getLogger()
+	countLevelFor(logger, zerolog.InfoLevel)
+	logger.InfoWithRequestf(ctx, module, format, requestID, costMs, args...)
 }
 
 // ErrorWithRequestf ERROR日志 + request_id + cost_ms (formatted)
 func ErrorWithRequestf(ctx context.Context, module, format string, requestID string, err error, costMs int64, args ...interface{}) {
-	getLogger().ErrorWithRequestf(ctx, module, format, requestID, err, costMs, args...)
+	logger := getLogger()
+	countLevelFor(logger, zerolog.ErrorLevel)
+	logger.ErrorWithRequestf(ctx, module, format, requestID, err, costMs, args...)
 }
 
+
+// ============================================================================
+// 动态级别日志方法（级别在运行时确定，如来自上游事件的 severity 字段）
+// ============================================================================
+
+// Log 按运行时确定的级别输出日志，分发到对应的 Trace/Debug/Info/Warn/Error/Fatal/Panic
+// ERROR 及以上级别没有 err 参数，需要时通过 Err 字段传入；FATAL 会退出进程，PANIC 会 panic
+// 无法识别的级别降级为 INFO，并附加 unknown_level 字段
+//
+// 用法示例：
+//   zllog.Log(ctx, level, "consumer", "upstream event", zllog.String("event_id", id))
+func Log(ctx context.Context, level Level, module, message string, fields ...Field) {
+	switch level {
+	case LevelTrace:
+		Trace(ctx, module, message, fields...)
+	case LevelDebug:
+		Debug(ctx, module, message, fields...)
+	case LevelInfo:
+		Info(ctx, module, message, fields...)
+	case LevelWarn:
+		Warn(ctx, module, message, fields...)
+	case LevelError:
+		Error(ctx, module, message, nil, fields...)
+	case LevelFatal:
+		Fatal(ctx, module, message, nil, fields...)
+	case LevelPanic:
+		Panic(ctx, module, message, nil, fields...)
+	default:
+		Info(ctx, module, message, append(fields[:len(fields):len(fields)], String("unknown_level", level.String()))...)
+	}
+}
+
+// Logf 按运行时确定的级别输出格式化日志（args 末尾的 Field 作为结构化字段，见格式化日志方法）
+func Logf(ctx context.Context, level Level, module, format string, args ...interface{}) {
+	message, fields := formatMessage(format, args)
+	Log(ctx, level, module, message, fields...)
+}
+
+// LogString 与 Log 相同，级别为字符串（解析规则见 ParseLevel）
+// 无法解析的级别降级为 INFO，并附加 unknown_level 字段（原始字符串）
+func LogString(ctx context.Context, level, module, message string, fields ...Field) {
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		Info(ctx, module, message, append(fields[:len(fields):len(fields)], String("unknown_level", level))...)
+		return
+	}
+	Log(ctx, lvl, module, message, fields...)
+}
+
+// LogfString 与 Logf 相同，级别为字符串
+func LogfString(ctx context.Context, level, module, format string, args ...interface{}) {
+	message, fields := formatMessage(format, args)
+	LogString(ctx, level, module, message, fields...)
+}
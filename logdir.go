@@ -0,0 +1,84 @@
+package zllog
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ============================================================================
+// 日志目录可用性检查（网络挂载等暂时不可用时重试，仍不可用时回退到临时目录）
+// ============================================================================
+
+// 日志目录检查的默认重试次数和首次重试间隔（之后每次翻倍）
+const (
+	defaultLogDirRetries       = 3
+	defaultLogDirRetryInterval = 200 * time.Millisecond
+)
+
+// 测试中可替换
+var (
+	logDirRetrySleep           = time.Sleep
+	logDirWarnOutput io.Writer = os.Stderr
+)
+
+// resolveLogDir 返回可写的日志目录
+// LogDir 不可写时（创建目录或创建文件失败）按 LogDirRetries、LogDirRetryInterval 退避重试，
+// 仍不可写时回退到临时目录（os.TempDir()/zllog/<服务名>）并在 stderr 输出警告，避免日志静默丢失；
+// 临时目录也不可写时返回原目录（与未检查时的行为一致）
+func resolveLogDir(config *LogConfig) string {
+	retries := config.LogDirRetries
+	if retries == 0 {
+		retries = defaultLogDirRetries
+	}
+	interval := config.LogDirRetryInterval
+	if interval <= 0 {
+		interval = defaultLogDirRetryInterval
+	}
+
+	var err error
+	attempts := 0
+	for {
+		attempts++
+		if err = probeLogDir(config, config.LogDir); err == nil {
+			return config.LogDir
+		}
+		if attempts > retries {
+			break
+		}
+		logDirRetrySleep(interval)
+		interval *= 2
+	}
+
+	fallback := filepath.Join(os.TempDir(), "zllog", config.ServiceName)
+	if fallbackErr := probeLogDir(config, fallback); fallbackErr != nil {
+		fmt.Fprintf(logDirWarnOutput, "zllog: WARNING: log dir %q is not writable after %d attempts (%v), fallback dir %q is not writable either (%v)\n",
+			config.LogDir, attempts, err, fallback, fallbackErr)
+		return config.LogDir
+	}
+	fmt.Fprintf(logDirWarnOutput, "zllog: WARNING: log dir %q is not writable after %d attempts (%v), writing logs to %q instead\n",
+		config.LogDir, attempts, err, fallback)
+	return fallback
+}
+
+// probeLogDir 创建日志目录并写入一个临时文件，检查目录是否可写
+// 不打开 app.log 本身，避免影响日期滚动时 app.log 软链接的处理
+func probeLogDir(config *LogConfig, dir string) error {
+	dirMode := config.DirMode
+	if dirMode == 0 {
+		dirMode = 0755
+	}
+	if err := os.MkdirAll(dir, dirMode); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, ".zllog-probe-*")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write([]byte{'\n'})
+	f.Close()
+	os.Remove(f.Name())
+	return err
+}
@@ -0,0 +1,99 @@
+package zllog
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stubLogDirRetry 在测试期间替换重试等待和警告输出，返回记录的等待间隔和警告内容
+func stubLogDirRetry(t *testing.T, onSleep func()) (*[]time.Duration, *bytes.Buffer) {
+	originalSleep, originalOutput := logDirRetrySleep, logDirWarnOutput
+	var sleeps []time.Duration
+	warnings := &bytes.Buffer{}
+	logDirRetrySleep = func(d time.Duration) {
+		sleeps = append(sleeps, d)
+		if onSleep != nil {
+			onSleep()
+		}
+	}
+	logDirWarnOutput = warnings
+	t.Cleanup(func() {
+		logDirRetrySleep, logDirWarnOutput = originalSleep, originalOutput
+	})
+	return &sleeps, warnings
+}
+
+// unwritableLogDir 返回一个不可创建的日志目录（父路径是普通文件）和该文件的路径
+func unwritableLogDir(t *testing.T) (string, string) {
+	blocker := filepath.Join(t.TempDir(), "mnt")
+	if err := os.WriteFile(blocker, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(blocker, "logs"), blocker
+}
+
+// TestLogDirRetry 测试日志目录暂时不可写时重试，恢复后继续使用原目录
+func TestLogDirRetry(t *testing.T) {
+	logDir, blocker := unwritableLogDir(t)
+	// 第一次重试前"挂载恢复"
+	sleeps, warnings := stubLogDirRetry(t, func() { os.Remove(blocker) })
+
+	config := &LogConfig{LogDir: logDir, MaxSize: 1, LogDirRetryInterval: 50 * time.Millisecond}
+	w := createLogFileWriter(config)
+	if _, err := w.Write([]byte(`{"message":"hello"}` + "\n")); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	if config.LogDir != logDir {
+		t.Errorf("expected original log dir, got %q", config.LogDir)
+	}
+	if len(*sleeps) != 1 || (*sleeps)[0] != 50*time.Millisecond {
+		t.Errorf("expected one retry after 50ms, got %v", *sleeps)
+	}
+	if warnings.Len() != 0 {
+		t.Errorf("unexpected warning: %q", warnings.String())
+	}
+	if data, err := os.ReadFile(filepath.Join(logDir, "app.log")); err != nil || !strings.Contains(string(data), "hello") {
+		t.Errorf("expected log written to original dir, got %q, %v", data, err)
+	}
+	entries, _ := os.ReadDir(logDir)
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".zllog-probe") {
+			t.Errorf("probe file should be removed: %s", e.Name())
+		}
+	}
+}
+
+// TestLogDirFallback 测试日志目录一直不可写时按退避重试后回退到临时目录并输出警告
+func TestLogDirFallback(t *testing.T) {
+	logDir, _ := unwritableLogDir(t)
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	sleeps, warnings := stubLogDirRetry(t, nil)
+
+	config := &LogConfig{ServiceName: "order-svc", LogDir: logDir, LogDirRetries: 2, LogDirRetryInterval: 10 * time.Millisecond}
+	got := resolveLogDir(config)
+
+	want := filepath.Join(tmp, "zllog", "order-svc")
+	if got != want {
+		t.Errorf("expected fallback %q, got %q", want, got)
+	}
+	if len(*sleeps) != 2 || (*sleeps)[0] != 10*time.Millisecond || (*sleeps)[1] != 20*time.Millisecond {
+		t.Errorf("expected backoff 10ms, 20ms, got %v", *sleeps)
+	}
+	if !strings.Contains(warnings.String(), "WARNING") || !strings.Contains(warnings.String(), want) {
+		t.Errorf("expected prominent warning naming the fallback dir, got %q", warnings.String())
+	}
+
+	// 负数表示不重试
+	*sleeps = nil
+	config.LogDirRetries = -1
+	resolveLogDir(config)
+	if len(*sleeps) != 0 {
+		t.Errorf("expected no retries, got %v", *sleeps)
+	}
+}
@@ -0,0 +1,172 @@
+package zllog
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ============================================================================
+// logfmt 输出格式
+// ============================================================================
+
+// 日志文件的输出格式（LogConfig.OutputFormat）
+const (
+	OutputFormatJSON    = "json"    // JSON（默认）
+	OutputFormatLogfmt  = "logfmt"  // logfmt，如 time=... level=info module=api msg="user login"
+	OutputFormatConsole = "console" // 与控制台相同的文本格式（不带颜色）
+)
+
+// logfmtLeadingKeys 优先输出的字段，其余字段保持原有顺序
+var logfmtLeadingKeys = []string{"time", "level", "message"}
+
+// logfmtWriter 将 zerolog 输出的 JSON 日志转换为 logfmt 后写入 out
+type logfmtWriter struct {
+	out io.Writer
+}
+
+// NewLogfmtWriter 创建 logfmt 格式的 writer，可作为 zerolog 的输出
+// 每行 JSON 日志被转换为一行 logfmt：message 字段输出为 msg，
+// 嵌套对象和数组以紧凑 JSON 输出，含空格、等号、引号或控制字符的 key 和 value 会加引号转义。
+// 无法解析为 JSON 对象的内容原样写入
+func NewLogfmtWriter(out io.Writer) io.Writer {
+	return &logfmtWriter{out: out}
+}
+
+// Write 逐行转换并写入
+func (w *logfmtWriter) Write(p []byte) (int, error) {
+	buf := make([]byte, 0, len(p))
+	for _, line := range bytes.SplitAfter(p, []byte("\n")) {
+		trimmed := bytes.TrimSpace(line)
+		if len(trimmed) == 0 {
+			continue
+		}
+		if encoded, ok := appendLogfmt(buf, trimmed); ok {
+			buf = append(encoded, '\n')
+		} else {
+			buf = append(buf, line...)
+		}
+	}
+	if _, err := w.out.Write(buf); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// logfmtPair 一个 key=value
+type logfmtPair struct {
+	key   string
+	value string
+	raw   bool // true 表示 value 是数字、布尔或 null，不需要引号
+}
+
+// appendLogfmt 将一行 JSON 对象转换为 logfmt 追加到 dst，解析失败时返回 false
+func appendLogfmt(dst, line []byte) ([]byte, bool) {
+	pairs, ok := parseJSONPairs(line)
+	if !ok {
+		return dst, false
+	}
+
+	first := true
+	write := func(p logfmtPair) {
+		if !first {
+			dst = append(dst, ' ')
+		}
+		first = false
+		key := p.key
+		if key == "message" {
+			key = "msg"
+		}
+		dst = appendLogfmtString(dst, key)
+		dst = append(dst, '=')
+		if p.raw {
+			dst = append(dst, p.value...)
+		} else {
+			dst = appendLogfmtString(dst, p.value)
+		}
+	}
+
+	written := make([]bool, len(pairs))
+	for _, key := range logfmtLeadingKeys {
+		for i, p := range pairs {
+			if !written[i] && p.key == key {
+				write(p)
+				written[i] = true
+				break
+			}
+		}
+	}
+	for i, p := range pairs {
+		if !written[i] {
+			write(p)
+		}
+	}
+	return dst, true
+}
+
+// parseJSONPairs 按原有顺序解析 JSON 对象的顶层字段
+func parseJSONPairs(line []byte) ([]logfmtPair, bool) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, false
+	}
+
+	var pairs []logfmtPair
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, false
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, false
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, false
+		}
+
+		switch raw[0] {
+		case '"':
+			var s string
+			if err := json.Unmarshal(raw, &s); err != nil {
+				return nil, false
+			}
+			pairs = append(pairs, logfmtPair{key: key, value: s})
+		case '{', '[':
+			var compact bytes.Buffer
+			if err := json.Compact(&compact, raw); err != nil {
+				return nil, false
+			}
+			pairs = append(pairs, logfmtPair{key: key, value: compact.String()})
+		default:
+			pairs = append(pairs, logfmtPair{key: key, value: string(raw), raw: true})
+		}
+	}
+	return pairs, true
+}
+
+// appendLogfmtString 追加 key 或 value，需要时加引号转义
+func appendLogfmtString(dst []byte, s string) []byte {
+	if !needsLogfmtQuote(s) {
+		return append(dst, s...)
+	}
+	return strconv.AppendQuote(dst, s)
+}
+
+// needsLogfmtQuote 空字符串或包含空白、等号、引号、控制字符、非法 UTF-8 时需要加引号
+func needsLogfmtQuote(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r == '=' || r == '"' || r == '\\' || r == utf8.RuneError || unicode.IsSpace(r) || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package zllog
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestLogfmtWriter 测试 JSON 日志转换为 logfmt 以及各种转义
+func TestLogfmtWriter(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "leading keys and msg",
+			in:   `{"module":"api","level":"info","time":"2024-01-02T03:04:05Z","message":"user login"}`,
+			want: `time=2024-01-02T03:04:05Z level=info msg="user login" module=api`,
+		},
+		{
+			name: "raw values",
+			in:   `{"count":42,"ratio":0.5,"ok":true,"none":null}`,
+			want: `count=42 ratio=0.5 ok=true none=null`,
+		},
+		{
+			name: "escaping",
+			in:   `{"quote":"say \"hi\"","newline":"a\nb","equals":"a=b","empty":"","path":"C:\\tmp","unicode":"登录"}`,
+			want: `quote="say \"hi\"" newline="a\nb" equals="a=b" empty="" path="C:\\tmp" unicode=登录`,
+		},
+		{
+			name: "key with space",
+			in:   `{"user id":"u-1"}`,
+			want: `"user id"=u-1`,
+		},
+		{
+			name: "nested values",
+			in:   `{"dict":{"a": 1},"arr":[1, "x"]}`,
+			want: `dict="{\"a\":1}" arr="[1,\"x\"]"`,
+		},
+		{
+			name: "not json",
+			in:   `plain text`,
+			want: `plain text`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewLogfmtWriter(&buf)
+			n, err := w.Write([]byte(tt.in + "\n"))
+			if err != nil || n != len(tt.in)+1 {
+				t.Fatalf("Write() = %d, %v", n, err)
+			}
+			if got := buf.String(); got != tt.want+"\n" {
+				t.Errorf("got  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestLogfmtFileOutput 测试 OutputFormat=logfmt 时日志文件使用 logfmt
+func TestLogfmtFileOutput(t *testing.T) {
+	dir := t.TempDir()
+	w := createLogFileWriter(&LogConfig{LogDir: dir, MaxSize: 1, OutputFormat: OutputFormatLogfmt})
+	if _, err := w.Write([]byte(`{"level":"info","message":"hello world"}` + "\n")); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatalf("read log file: %v", err)
+	}
+	if string(data) != "level=info msg=\"hello world\"\n" {
+		t.Errorf("unexpected file content %q", data)
+	}
+}
@@ -4,8 +4,13 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
 )
 
 // MockLogger 用于测试的 Mock 实现
@@ -114,15 +119,9 @@ func (m *MockLogger) getLastCall() string {
 
 // TestLoggerInterface 测试 Logger 接口
 func TestLoggerInterface(t *testing.T) {
-	// 保存原始的 logger
-	originalLogger := globalLoggerImpl
-	defer func() {
-		globalLoggerImpl = originalLogger
-	}()
-
-	// 创建 mock logger
+	// 替换为 mock logger，测试结束后恢复
 	mock := &MockLogger{}
-	SetLogger(mock)
+	defer SwapLogger(mock)()
 
 	ctx := context.Background()
 
@@ -209,3 +208,243 @@ func TestZerologLogger(t *testing.T) {
 	Debug(ctx, "test", "debug message")
 	Warn(ctx, "test", "warn message")
 }
+
+// TestStats 测试按级别统计日志条数（自定义 Logger 同样生效）
+func TestStats(t *testing.T) {
+	originalLogger := globalLoggerImpl
+	defer func() {
+		globalLoggerImpl = originalLogger
+	}()
+
+	SetLogger(&MockLogger{})
+	resetStats()
+	defer resetStats()
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		Info(ctx, "test", "info message")
+	}
+	Infof(ctx, "test", "info %d", 4)
+	Debug(ctx, "test", "debug message")
+	Error(ctx, "test", "error message", fmt.Errorf("test error"))
+	ErrorWithCode(ctx, "test", "error with code", "E001", nil)
+
+	stats := Stats()
+	if stats.Levels["INFO"] != 4 {
+		t.Errorf("expected 4 INFO lines, got %d", stats.Levels["INFO"])
+	}
+	if stats.Levels["DEBUG"] != 1 {
+		t.Errorf("expected 1 DEBUG line, got %d", stats.Levels["DEBUG"])
+	}
+	if stats.Levels["ERROR"] != 2 {
+		t.Errorf("expected 2 ERROR lines, got %d", stats.Levels["ERROR"])
+	}
+	if _, ok := stats.Levels["WARN"]; ok {
+		t.Errorf("unexpected WARN count: %v", stats.Levels)
+	}
+}
+
+// TestStatsZerologLogger 测试 ZerologLogger 按最终输出的级别计数：被级别过滤的不计入，ErrorCodeLevels 调整后按新级别计数
+func TestStatsZerologLogger(t *testing.T) {
+	originalLogger := globalLoggerImpl
+	defer func() {
+		globalLoggerImpl = originalLogger
+	}()
+
+	logger, _ := newTestLogger(t, &LogConfig{ErrorCodeLevels: map[string]string{"VALIDATION_001": "WARN"}})
+	infoOnly := logger.logger.Level(zerolog.InfoLevel)
+	logger.logger = &infoOnly
+	SetLogger(NewMultiLogger(logger, &MockLogger{}))
+	resetStats()
+	defer resetStats()
+
+	ctx := context.Background()
+	Debug(ctx, "test", "filtered")
+	Info(ctx, "test", "info message")
+	ErrorWithCode(ctx, "test", "invalid input", "VALIDATION_001", nil)
+
+	stats := Stats()
+	if _, ok := stats.Levels["DEBUG"]; ok {
+		t.Errorf("filtered DEBUG should not be counted: %v", stats.Levels)
+	}
+	if stats.Levels["INFO"] != 1 || stats.Levels["WARN"] != 1 {
+		t.Errorf("expected 1 INFO and 1 WARN line, got %v", stats.Levels)
+	}
+	if _, ok := stats.Levels["ERROR"]; ok {
+		t.Errorf("remapped error code should not be counted as ERROR: %v", stats.Levels)
+	}
+}
+
+// TestTraceFallback 测试未实现 TraceLogger 的自定义 Logger 降级为 Debug
+func TestTraceFallback(t *testing.T) {
+	originalLogger := globalLoggerImpl
+	defer func() {
+		globalLoggerImpl = originalLogger
+	}()
+
+	mock := &MockLogger{}
+	SetLogger(mock)
+
+	Trace(context.Background(), "test", "trace message")
+	if mock.getLastCall() != "[DEBUG] test: trace message" {
+		t.Errorf("Trace fallback failed: %s", mock.getLastCall())
+	}
+
+	Tracef(context.Background(), "test", "trace %d", 1)
+	if mock.getLastCall() != "[DEBUGF] test: trace 1" {
+		t.Errorf("Tracef fallback failed: %s", mock.getLastCall())
+	}
+}
+
+// TestWriter 测试 Writer 写入的原始内容出现在日志文件中
+func TestWriter(t *testing.T) {
+	config := DefaultConfig("test")
+	if err := InitLoggerWithConfig(config); err != nil {
+		t.Fatalf("Failed to init logger: %v", err)
+	}
+
+	line := fmt.Sprintf("raw line from third-party library %d\n", time.Now().UnixNano())
+	if _, err := Writer().Write([]byte(line)); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(config.LogDir, "app.log"))
+	if err != nil {
+		t.Fatalf("read log file: %v", err)
+	}
+	if !strings.Contains(string(data), line) {
+		t.Errorf("raw line not found in log file")
+	}
+}
+
+// TestEffectiveConfig 测试 EffectiveConfig 返回实际生效的配置，并补全自动检测的服务名和环境
+func TestEffectiveConfig(t *testing.T) {
+	if err := InitLoggerWithConfig(DefaultConfig("test")); err != nil {
+		t.Fatalf("Failed to init logger: %v", err)
+	}
+	effective := EffectiveConfig()
+	if effective.ServiceName != GetServiceName() || effective.LogDir != "./logs" {
+		t.Errorf("unexpected effective config: %+v", effective)
+	}
+
+	t.Setenv("SERVICE_NAME", "detected-service")
+	t.Setenv("ENV", "staging")
+	config := &LogConfig{LogLevel: "INFO", GlobalFields: map[string]interface{}{"region": "cn"}}
+	effective = newEffectiveConfig(config)
+	if effective.ServiceName != "detected-service" {
+		t.Errorf("expected detected service name, got %q", effective.ServiceName)
+	}
+	if effective.Env != "staging" {
+		t.Errorf("expected detected env, got %q", effective.Env)
+	}
+
+	// 副本与原配置互不影响
+	effective.GlobalFields["region"] = "us"
+	if config.GlobalFields["region"] != "cn" {
+		t.Errorf("expected original config untouched, got %v", config.GlobalFields)
+	}
+}
+
+// TestLogFileMode 测试日志目录和日志文件使用配置的权限（不受 umask 影响）
+func TestLogFileMode(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "logs")
+	w := createLogFileWriter(&LogConfig{LogDir: dir, ArchiveDir: "archive", MaxSize: 1, FileMode: 0640, DirMode: 0750})
+	if _, err := w.Write([]byte(`{"level":"info","message":"hello"}` + "\n")); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatalf("stat log file: %v", err)
+	}
+	if info.Mode().Perm() != 0640 {
+		t.Errorf("file mode = %#o, want 0640", info.Mode().Perm())
+	}
+	if info, err = os.Stat(dir); err != nil || info.Mode().Perm() != 0750 {
+		t.Errorf("dir mode = %#o, want 0750 (err: %v)", info.Mode().Perm(), err)
+	}
+	if info, err = os.Stat(filepath.Join(dir, "archive")); err != nil || info.Mode().Perm() != 0750 {
+		t.Errorf("archive dir mode = %#o, want 0750 (err: %v)", info.Mode().Perm(), err)
+	}
+
+	for v, want := range map[interface{}]os.FileMode{"0640": 0640, "0o600": 0600, 0644: 0644, "rw-r": 0} {
+		if got := parseFileMode(v); got != want {
+			t.Errorf("parseFileMode(%v) = %#o, want %#o", v, got, want)
+		}
+	}
+}
+
+// TestSwapLogger 测试嵌套替换 Logger 后按相反顺序恢复
+func TestSwapLogger(t *testing.T) {
+	original := GetLogger()
+	outer, inner := &MockLogger{}, &MockLogger{}
+
+	restoreOuter := SwapLogger(outer)
+	if GetLogger() != outer {
+		t.Fatal("expected outer logger after first swap")
+	}
+	restoreInner := SwapLogger(inner)
+	Info(context.Background(), "test", "to inner")
+	if GetLogger() != inner || len(inner.calls) != 1 || len(outer.calls) != 0 {
+		t.Errorf("expected inner logger to receive the call, inner=%v outer=%v", inner.calls, outer.calls)
+	}
+
+	restoreInner()
+	if GetLogger() != outer {
+		t.Error("expected outer logger after restoring inner swap")
+	}
+	restoreOuter()
+	if GetLogger() != original {
+		t.Error("expected original logger after restoring outer swap")
+	}
+}
+
+// TestLogErr 测试 LogErr 输出 ERROR 日志并原样返回错误，nil 错误不输出
+func TestLogErr(t *testing.T) {
+	mock := &MockLogger{}
+	defer SwapLogger(mock)()
+
+	ctx := context.Background()
+	err := fmt.Errorf("save order: %w", os.ErrPermission)
+	if got := LogErr(ctx, "order", "save failed", err); got != err {
+		t.Errorf("expected the same error, got %v", got)
+	}
+	if got := LogErrWithCode(ctx, "order", "save failed", "E_DB", err); got != err {
+		t.Errorf("expected the same error, got %v", got)
+	}
+	if got := NewScopedLogger("order").LogErr(ctx, "scoped failed", err); got != err {
+		t.Errorf("expected the same error from scoped logger, got %v", got)
+	}
+	if got := LogErr(ctx, "order", "no error", nil); got != nil {
+		t.Errorf("expected nil for nil error, got %v", got)
+	}
+
+	want := []string{"[ERROR] order: save failed", "[ERROR_CODE] order: save failed", "[ERROR] order: scoped failed"}
+	if strings.Join(mock.calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("calls = %v, want %v", mock.calls, want)
+	}
+}
+
+// TestSetDefaultLogger 测试初始化之前的日志交给 SetDefaultLogger 设置的 Logger，SetLogger 之后不再使用
+func TestSetDefaultLogger(t *testing.T) {
+	restore := SwapLogger(nil)
+	defer restore()
+	defer SetDefaultLogger(nil)
+
+	early := &MockLogger{}
+	SetDefaultLogger(early)
+	ctx := context.Background()
+	Info(ctx, "lib", "loaded before init")
+	Warnf(ctx, "lib", "cache size %d", 0)
+
+	if early.getCallCount() != 2 || early.getLastCall() != "[WARNF] lib: cache size 0" {
+		t.Errorf("expected early lines captured by default logger, got %v", early.calls)
+	}
+
+	app := &MockLogger{}
+	SetLogger(app)
+	Info(ctx, "app", "initialized")
+	if early.getCallCount() != 2 || app.getLastCall() != "[INFO] app: initialized" {
+		t.Errorf("expected default logger unused after SetLogger, got early=%v app=%v", early.calls, app.calls)
+	}
+}
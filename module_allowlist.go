@@ -0,0 +1,47 @@
+package zllog
+
+import "strings"
+
+// ============================================================================
+// 按环境屏蔽 module（如会输出敏感信息的 module 只在 prod 输出）
+// ============================================================================
+
+// moduleEnvFilter 记录 ModuleEnvAllowlist 中各 module 在当前环境是否允许输出
+// 初始化后只读，查找无需加锁
+type moduleEnvFilter struct {
+	allowed map[string]bool
+}
+
+// newModuleEnvFilter 根据当前环境计算各 module 是否允许输出，没有配置时返回 nil
+// 环境名不区分大小写；列表为空的 module 在任何环境都不输出
+func newModuleEnvFilter(allowlist map[string][]string, env string) *moduleEnvFilter {
+	if len(allowlist) == 0 {
+		return nil
+	}
+	allowed := make(map[string]bool, len(allowlist))
+	for module, envs := range allowlist {
+		allowed[module] = false
+		for _, e := range envs {
+			if strings.EqualFold(e, env) {
+				allowed[module] = true
+				break
+			}
+		}
+	}
+	return &moduleEnvFilter{allowed: allowed}
+}
+
+// Allow 判断 module 在当前环境是否允许输出
+// 按层级匹配最长的已配置 module（payment 的配置同样适用于 payment.card），都未配置时允许
+func (f *moduleEnvFilter) Allow(module string) bool {
+	for {
+		if allowed, ok := f.allowed[module]; ok {
+			return allowed
+		}
+		i := strings.LastIndex(module, moduleSeparator)
+		if i < 0 {
+			return true
+		}
+		module = module[:i]
+	}
+}
@@ -0,0 +1,51 @@
+package zllog
+
+import (
+	"context"
+	"testing"
+)
+
+// TestModuleEnvAllowlist 测试敏感 module 在 dev 中不输出、在 prod 中输出
+func TestModuleEnvAllowlist(t *testing.T) {
+	allowlist := map[string][]string{"payment.card": {"PROD"}, "debug": {}}
+	for _, tc := range []struct {
+		env  string
+		want []string
+	}{
+		{"dev", []string{"order"}},
+		{"prod", []string{"order", "payment.card", "payment.card.token"}},
+	} {
+		logger, buf := newTestLogger(t, &LogConfig{Env: tc.env, ModuleEnvAllowlist: allowlist})
+		ctx := context.Background()
+		for _, module := range []string{"order", "payment.card", "payment.card.token", "debug"} {
+			logger.Info(ctx, module, "card number received")
+			logger.Error(ctx, module, "card charge failed", nil)
+		}
+
+		lines := decodeLines(t, buf)
+		if len(lines) != 2*len(tc.want) {
+			t.Fatalf("env=%s: expected %d lines, got %v", tc.env, 2*len(tc.want), lines)
+		}
+		for i, module := range tc.want {
+			if lines[2*i]["module"] != module {
+				t.Errorf("env=%s: expected module=%s, got %v", tc.env, module, lines[2*i]["module"])
+			}
+		}
+	}
+}
+
+// TestModuleEnvFilterParent 测试子 module 使用最长匹配的配置
+func TestModuleEnvFilterParent(t *testing.T) {
+	f := newModuleEnvFilter(map[string][]string{"payment": {"prod"}, "payment.public": {"dev", "prod"}}, "dev")
+	for module, want := range map[string]bool{
+		"payment": false, "payment.card": false, "payment.public": true, "payment.public.list": true,
+		"paymentx": true, "order": true, "": true,
+	} {
+		if got := f.Allow(module); got != want {
+			t.Errorf("Allow(%q) = %v, want %v", module, got, want)
+		}
+	}
+	if newModuleEnvFilter(nil, "dev") != nil {
+		t.Error("expected nil filter without allowlist")
+	}
+}
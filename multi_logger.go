@@ -0,0 +1,235 @@
+package zllog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ============================================================================
+// MultiLogger - 同时输出到多个 Logger
+// ============================================================================
+
+// exitFunc Fatal 退出进程的函数（测试中替换）
+var exitFunc = os.Exit
+
+// MultiLogger 将每次调用按顺序转发给多个 Logger（如本地文件 + 远程上报）
+// 某个 Logger panic 时会被捕获并输出到标准错误，不影响其他 Logger；
+// Fatal 在所有 Logger 都记录完之后才退出进程（见 FatalNoExitLogger）
+type MultiLogger struct {
+	loggers []Logger
+}
+
+// NewMultiLogger 创建同时输出到多个 Logger 的组合 Logger，nil 会被忽略
+//
+// 用法示例：
+//   remoteLogger := remote.NewRemoteLogger(remote.Config{URL: "https://log.example.com/ingest"})
+//   zllog.SetLogger(zllog.NewMultiLogger(zllog.GetLogger(), remoteLogger))
+func NewMultiLogger(loggers ...Logger) Logger {
+	m := &MultiLogger{loggers: make([]Logger, 0, len(loggers))}
+	for _, l := range loggers {
+		if l != nil {
+			m.loggers = append(m.loggers, l)
+		}
+	}
+	return m
+}
+
+// Loggers 返回组合中的 Logger
+func (m *MultiLogger) Loggers() []Logger {
+	return m.loggers
+}
+
+// each 按顺序调用每个 Logger，单个 Logger 的 panic 不影响后续 Logger
+func (m *MultiLogger) each(fn func(Logger)) {
+	for _, l := range m.loggers {
+		callLogger(l, fn)
+	}
+}
+
+// callLogger 调用单个 Logger 并捕获其 panic
+func callLogger(l Logger, fn func(Logger)) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "zllog: logger %T panicked: %v\n", l, r)
+		}
+	}()
+	fn(l)
+}
+
+// fatalNoExit 记录 FATAL 日志但不退出，未实现 FatalNoExitLogger 的 Logger 以 ERROR 级别记录
+func fatalNoExit(l Logger, ctx context.Context, module, message string, err error, fields []Field) {
+	if fl, ok := l.(FatalNoExitLogger); ok {
+		fl.FatalNoExit(ctx, module, message, err, fields...)
+		return
+	}
+	l.Error(ctx, module, message, err, fields...)
+}
+
+// Trace logs a message at TRACE level
+// 未实现 TraceLogger 的 Logger 降级为 DEBUG 级别
+func (m *MultiLogger) Trace(ctx context.Context, module, message string, fields ...Field) {
+	m.each(func(l Logger) {
+		if tl, ok := l.(TraceLogger); ok {
+			tl.Trace(ctx, module, message, fields...)
+			return
+		}
+		l.Debug(ctx, module, message, fields...)
+	})
+}
+
+// Debug logs a message at DEBUG level
+func (m *MultiLogger) Debug(ctx context.Context, module, message string, fields ...Field) {
+	m.each(func(l Logger) { l.Debug(ctx, module, message, fields...) })
+}
+
+// Info logs a message at INFO level
+func (m *MultiLogger) Info(ctx context.Context, module, message string, fields ...Field) {
+	m.each(func(l Logger) { l.Info(ctx, module, message, fields...) })
+}
+
+// Warn logs a message at WARN level
+func (m *MultiLogger) Warn(ctx context.Context, module, message string, fields ...Field) {
+	m.each(func(l Logger) { l.Warn(ctx, module, message, fields...) })
+}
+
+// Error logs a message at ERROR level with error info
+func (m *MultiLogger) Error(ctx context.Context, module, message string, err error, fields ...Field) {
+	m.each(func(l Logger) { l.Error(ctx, module, message, err, fields...) })
+}
+
+// ErrorWithCode logs a message at ERROR level with error code
+func (m *MultiLogger) ErrorWithCode(ctx context.Context, module, message, errorCode string, err error, fields ...Field) {
+	m.each(func(l Logger) { l.ErrorWithCode(ctx, module, message, errorCode, err, fields...) })
+}
+
+// FatalNoExit logs a message at FATAL level without exiting
+func (m *MultiLogger) FatalNoExit(ctx context.Context, module, message string, err error, fields ...Field) {
+	m.each(func(l Logger) { fatalNoExit(l, ctx, module, message, err, fields) })
+}
+
+// Fatal logs a message at FATAL level and exits
+// 所有 Logger 都记录完之后才退出进程
+func (m *MultiLogger) Fatal(ctx context.Context, module, message string, err error, fields ...Field) {
+	m.FatalNoExit(ctx, module, message, err, fields...)
+	exitFunc(1)
+}
+
+// InfoWithRequest INFO日志 + request_id + cost_ms
+func (m *MultiLogger) InfoWithRequest(ctx context.Context, module, message, requestID string, costMs int64, fields ...Field) {
+	m.each(func(l Logger) { l.InfoWithRequest(ctx, module, message, requestID, costMs, fields...) })
+}
+
+// ErrorWithRequest ERROR日志 + request_id + cost_ms
+func (m *MultiLogger) ErrorWithRequest(ctx context.Context, module, message, requestID string, err error, costMs int64, fields ...Field) {
+	m.each(func(l Logger) { l.ErrorWithRequest(ctx, module, message, requestID, err, costMs, fields...) })
+}
+
+// Tracef logs a formatted message at TRACE level
+func (m *MultiLogger) Tracef(ctx context.Context, module, format string, args ...interface{}) {
+	m.Trace(ctx, module, fmt.Sprintf(format, args...))
+}
+
+// Debugf logs a formatted message at DEBUG level
+func (m *MultiLogger) Debugf(ctx context.Context, module, format string, args ...interface{}) {
+	m.each(func(l Logger) { l.Debugf(ctx, module, format, args...) })
+}
+
+// Infof logs a formatted message at INFO level
+func (m *MultiLogger) Infof(ctx context.Context, module, format string, args ...interface{}) {
+	m.each(func(l Logger) { l.Infof(ctx, module, format, args...) })
+}
+
+// Warnf logs a formatted message at WARN level
+func (m *MultiLogger) Warnf(ctx context.Context, module, format string, args ...interface{}) {
+	m.each(func(l Logger) { l.Warnf(ctx, module, format, args...) })
+}
+
+// Errorf logs a formatted message at ERROR level with error info
+func (m *MultiLogger) Errorf(ctx context.Context, module, format string, err error, args ...interface{}) {
+	m.each(func(l Logger) { l.Errorf(ctx, module, format, err, args...) })
+}
+
+// ErrorWithCodef logs a formatted message at ERROR level with error code
+func (m *MultiLogger) ErrorWithCodef(ctx context.Context, module, format string, errorCode string, err error, args ...interface{}) {
+	m.each(func(l Logger) { l.ErrorWithCodef(ctx, module, format, errorCode, err, args...) })
+}
+
+// Fatalf logs a formatted message at FATAL level and exits
+// 所有 Logger 都记录完之后才退出进程
+func (m *MultiLogger) Fatalf(ctx context.Context, module, format string, err error, args ...interface{}) {
+	m.Fatal(ctx, module, fmt.Sprintf(format, args...), err)
+}
+
+// InfoWithRequestf INFO日志 + request_id + cost_ms (formatted)
+func (m *MultiLogger) InfoWithRequestf(ctx context.Context, module, format string, requestID string, costMs int64, args ...interface{}) {
+	m.each(func(l Logger) { l.InfoWithRequestf(ctx, module, format, requestID, costMs, args...) })
+}
+
+// ErrorWithRequestf ERROR日志 + request_id + cost_ms (formatted)
+func (m *MultiLogger) ErrorWithRequestf(ctx context.Context, module, format string, requestID string, err error, costMs int64, args ...interface{}) {
+	m.each(func(l Logger) { l.ErrorWithRequestf(ctx, module, format, requestID, err, costMs, args...) })
+}
+
+// Enabled 实现 LevelEnabler：任一 Logger 会输出时返回 true
+func (m *MultiLogger) Enabled(ctx context.Context, module string, level Level) bool {
+	for _, l := range m.loggers {
+		if loggerEnabled(l, ctx, module, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// countsLevels 任一 Logger 自行统计级别时返回 true（统计以该 Logger 的实际输出为准，避免重复计数）
+func (m *MultiLogger) countsLevels() bool {
+	for _, l := range m.loggers {
+		if loggerCountsLevels(l) {
+			return true
+		}
+	}
+	return false
+}
+
+// Healthy 所有实现了 HealthChecker 的 Logger 都健康时返回 true
+func (m *MultiLogger) Healthy() bool {
+	for _, l := range m.loggers {
+		if hc, ok := l.(HealthChecker); ok && !hc.Healthy() {
+			return false
+		}
+	}
+	return true
+}
+
+// LastError 返回第一个不健康 Logger 的最近错误；都健康时返回最近出现过的错误
+func (m *MultiLogger) LastError() error {
+	var last error
+	for _, l := range m.loggers {
+		hc, ok := l.(HealthChecker)
+		if !ok {
+			continue
+		}
+		err := hc.LastError()
+		if err != nil && !hc.Healthy() {
+			return err
+		}
+		if last == nil {
+			last = err
+		}
+	}
+	return last
+}
+
+// Flush 刷新所有实现了 Flusher 的 Logger，返回合并后的错误
+func (m *MultiLogger) Flush(ctx context.Context) error {
+	var errs []error
+	for _, l := range m.loggers {
+		if f, ok := l.(Flusher); ok {
+			if err := f.Flush(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
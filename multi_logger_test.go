@@ -0,0 +1,73 @@
+package zllog
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// panicLogger 每次调用都 panic 的 Logger
+type panicLogger struct {
+	MockLogger
+}
+
+func (p *panicLogger) Info(ctx context.Context, module, message string, fields ...Field) {
+	panic("broken logger")
+}
+
+// fatalNoExitMock 支持 FatalNoExit 的 Logger
+type fatalNoExitMock struct {
+	MockLogger
+}
+
+func (f *fatalNoExitMock) FatalNoExit(ctx context.Context, module, message string, err error, fields ...Field) {
+	f.record("FATAL", module, message)
+}
+
+// TestMultiLogger 测试每个 Logger 都收到调用，单个 Logger panic 不影响其他 Logger
+func TestMultiLogger(t *testing.T) {
+	first, second := &MockLogger{}, &MockLogger{}
+	logger := NewMultiLogger(first, &panicLogger{}, nil, second)
+
+	ctx := context.Background()
+	logger.Info(ctx, "order", "created")
+	logger.Errorf(ctx, "order", "failed %d", errors.New("boom"), 42)
+
+	for i, mock := range []*MockLogger{first, second} {
+		if mock.getCallCount() != 2 {
+			t.Errorf("logger %d: expected 2 calls, got %d", i, mock.getCallCount())
+		}
+		if mock.calls[0] != "[INFO] order: created" || mock.getLastCall() != "[ERRORF] order: failed 42" {
+			t.Errorf("logger %d: unexpected calls %v", i, mock.calls)
+		}
+	}
+}
+
+// TestMultiLoggerFatal 测试 Fatal 在所有 Logger 都记录之后才退出
+func TestMultiLoggerFatal(t *testing.T) {
+	originalExit := exitFunc
+	defer func() {
+		exitFunc = originalExit
+	}()
+
+	withNoExit, plain := &fatalNoExitMock{}, &MockLogger{}
+	var exitCode int
+	var callsAtExit int
+	exitFunc = func(code int) {
+		exitCode = code
+		callsAtExit = withNoExit.getCallCount() + plain.getCallCount()
+	}
+
+	NewMultiLogger(withNoExit, plain).Fatal(context.Background(), "main", "config missing", nil)
+
+	if exitCode != 1 || callsAtExit != 2 {
+		t.Errorf("expected exit(1) after both loggers, got code %d with %d calls", exitCode, callsAtExit)
+	}
+	if withNoExit.getLastCall() != "[FATAL] main: config missing" {
+		t.Errorf("unexpected call: %s", withNoExit.getLastCall())
+	}
+	// 未实现 FatalNoExitLogger 的 Logger 以 ERROR 级别记录
+	if plain.getLastCall() != "[ERROR] main: config missing" {
+		t.Errorf("unexpected fallback call: %s", plain.getLastCall())
+	}
+}
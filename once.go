@@ -0,0 +1,33 @@
+package zllog
+
+import (
+	"context"
+	"sync"
+)
+
+// ============================================================================
+// 只输出一次的日志（进程内按 key 去重）
+// ============================================================================
+
+// onceKeys 已经输出过的 key
+var onceKeys sync.Map
+
+// WarnOnce 以 WARN 级别输出日志，同一个 key 在进程内只输出第一次
+// 适用于启动告警（如使用了已废弃的配置项）等在循环中可能被反复触发的场景
+//
+// 用法示例：
+//   zllog.WarnOnce(ctx, "config", "deprecated.log_path", "log_path is deprecated, use log_dir instead")
+func WarnOnce(ctx context.Context, module, key, message string, fields ...Field) {
+	if _, loaded := onceKeys.LoadOrStore(key, struct{}{}); loaded {
+		return
+	}
+	Warn(ctx, module, message, fields...)
+}
+
+// ResetOnce 清空 WarnOnce 的去重记录（用于测试）
+func ResetOnce() {
+	onceKeys.Range(func(key, _ interface{}) bool {
+		onceKeys.Delete(key)
+		return true
+	})
+}
@@ -0,0 +1,40 @@
+package zllog
+
+import (
+	"context"
+	"testing"
+)
+
+// TestWarnOnce 测试同一个 key 只输出一次，ResetOnce 后可再次输出
+func TestWarnOnce(t *testing.T) {
+	originalLogger := globalLoggerImpl
+	defer func() {
+		globalLoggerImpl = originalLogger
+	}()
+	ResetOnce()
+	defer ResetOnce()
+
+	logger, buf := newTestLogger(t, &LogConfig{})
+	SetLogger(logger)
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		WarnOnce(ctx, "config", "deprecated.log_path", "log_path is deprecated")
+	}
+	WarnOnce(ctx, "config", "deprecated.level", "level is deprecated")
+
+	lines := decodeLines(t, buf)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+	if lines[0]["level"] != "warn" || lines[0]["message"] != "log_path is deprecated" {
+		t.Errorf("unexpected line: %v", lines[0])
+	}
+
+	buf.Reset()
+	ResetOnce()
+	WarnOnce(ctx, "config", "deprecated.log_path", "log_path is deprecated")
+	if len(decodeLines(t, buf)) != 1 {
+		t.Error("expected the message again after ResetOnce")
+	}
+}
@@ -0,0 +1,62 @@
+package zllog
+
+import "time"
+
+// ============================================================================
+// Phases - 按阶段统计耗时（db、cache、render 等）
+// ============================================================================
+
+// Phases 按顺序记录各阶段耗时，Mark 记录从上一次 Mark（或创建时）到现在的时间
+// 同名阶段多次 Mark 时耗时累加，位置保持首次 Mark 的顺序；不是并发安全的，应在单个请求内使用
+//
+// 用法示例：
+//   p := zllog.NewPhases()
+//   rows := queryDB(ctx)
+//   p.Mark("db")
+//   data := loadCache(ctx)
+//   p.Mark("cache")
+//   render(w, rows, data)
+//   p.Mark("render")
+//   zllog.Info(ctx, "api", "request done", p.Field())  // "phases":{"db":12,"cache":3,"render":8}
+type Phases struct {
+	last      time.Time
+	names     []string
+	durations map[string]time.Duration
+}
+
+// NewPhases 创建从当前时间开始计时的 Phases
+func NewPhases() *Phases {
+	return &Phases{last: Now()}
+}
+
+// Mark 结束名为 name 的阶段：记录从上一次 Mark（或创建时）到现在的耗时，下一个阶段从现在开始
+func (p *Phases) Mark(name string) {
+	now := Now()
+	if p.durations == nil {
+		p.durations = make(map[string]time.Duration)
+	}
+	if _, ok := p.durations[name]; !ok {
+		p.names = append(p.names, name)
+	}
+	p.durations[name] += now.Sub(p.last)
+	p.last = now
+}
+
+// Duration 返回阶段的累计耗时，未记录的阶段返回 0
+func (p *Phases) Duration(name string) time.Duration {
+	return p.durations[name]
+}
+
+// Names 按首次 Mark 的顺序返回阶段名
+func (p *Phases) Names() []string {
+	return append([]string(nil), p.names...)
+}
+
+// Field 创建 phases 字段：按首次 Mark 的顺序输出各阶段的耗时（毫秒）的嵌套对象
+func (p *Phases) Field() Field {
+	fields := make([]Field, len(p.names))
+	for i, name := range p.names {
+		fields[i] = Int64(name, p.durations[name].Milliseconds())
+	}
+	return Dict("phases", fields...)
+}
@@ -0,0 +1,43 @@
+package zllog
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestPhases 测试各阶段耗时按 Mark 的顺序记录，同名阶段累加
+func TestPhases(t *testing.T) {
+	clock := NewManualClock(time.Date(2025, 1, 27, 10, 0, 0, 0, time.UTC))
+	defer SetClock(clock)()
+
+	p := NewPhases()
+	clock.Advance(12 * time.Millisecond)
+	p.Mark("db")
+	clock.Advance(3 * time.Millisecond)
+	p.Mark("cache")
+	clock.Advance(8 * time.Millisecond)
+	p.Mark("render")
+	clock.Advance(5 * time.Millisecond)
+	p.Mark("db")
+
+	if got := strings.Join(p.Names(), ","); got != "db,cache,render" {
+		t.Errorf("expected phases in mark order, got %s", got)
+	}
+	if p.Duration("db") != 17*time.Millisecond || p.Duration("missing") != 0 {
+		t.Errorf("expected db=17ms accumulated, got %v", p.Duration("db"))
+	}
+
+	logger, buf := newTestLogger(t, &LogConfig{})
+	logger.Info(context.Background(), "api", "request done", p.Field())
+	if want := `"phases":{"db":17,"cache":3,"render":8}`; !strings.Contains(buf.String(), want) {
+		t.Errorf("expected %s in %s", want, buf.String())
+	}
+
+	empty := NewPhases()
+	logger.Info(context.Background(), "api", "no phases", empty.Field())
+	if !strings.Contains(buf.String(), `"phases":{}`) {
+		t.Errorf("expected empty phases object, got %s", buf.String())
+	}
+}
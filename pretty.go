@@ -0,0 +1,57 @@
+package zllog
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// ============================================================================
+// 缩进格式的 JSON 输出（LogConfig.PrettyJSON，仅用于开发环境）
+// ============================================================================
+
+// prettyJSONIndent 缩进使用的字符串
+const prettyJSONIndent = "  "
+
+// prettyJSONWriter 将每行 JSON 日志展开为多行缩进格式后写入 out，字段顺序不变
+// 无法解析为 JSON 的内容原样写入
+type prettyJSONWriter struct {
+	out io.Writer
+}
+
+// newPrettyJSONWriter 创建缩进格式的 JSON writer
+func newPrettyJSONWriter(out io.Writer) io.Writer {
+	return &prettyJSONWriter{out: out}
+}
+
+// Write 逐行展开并写入
+func (w *prettyJSONWriter) Write(p []byte) (int, error) {
+	var buf bytes.Buffer
+	buf.Grow(len(p) * 2)
+	for _, line := range bytes.SplitAfter(p, []byte("\n")) {
+		trimmed := bytes.TrimSpace(line)
+		if len(trimmed) == 0 {
+			continue
+		}
+		mark := buf.Len()
+		if err := json.Indent(&buf, trimmed, "", prettyJSONIndent); err != nil {
+			buf.Truncate(mark)
+			buf.Write(line)
+			continue
+		}
+		buf.WriteByte('\n')
+	}
+	if _, err := w.out.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// isProdEnv 判断是否为生产环境（PrettyJSON 等开发用配置在生产环境自动关闭）
+func isProdEnv(env string) bool {
+	switch env {
+	case "prod", "production", "docker":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package zllog
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestPrettyJSONWriter 测试每行 JSON 展开为缩进格式，字段顺序不变，非 JSON 内容原样写入
+func TestPrettyJSONWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := newPrettyJSONWriter(buf)
+	in := `{"level":"info","message":"hi","req":{"id":1}}` + "\n" + "not json\n"
+	if n, err := w.Write([]byte(in)); err != nil || n != len(in) {
+		t.Fatalf("Write = %d, %v", n, err)
+	}
+
+	want := "{\n  \"level\": \"info\",\n  \"message\": \"hi\",\n  \"req\": {\n    \"id\": 1\n  }\n}\nnot json\n"
+	if buf.String() != want {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
+
+// TestPrettyJSONFileOutput 测试只有开启 PrettyJSON、不是 prod 环境且未配置 SchemaVersion 时日志文件才使用缩进格式
+func TestPrettyJSONFileOutput(t *testing.T) {
+	line := []byte(`{"level":"info","message":"hello"}` + "\n")
+	tests := []struct {
+		name   string
+		config LogConfig
+		pretty bool
+	}{
+		{name: "disabled", config: LogConfig{Env: "dev"}},
+		{name: "dev", config: LogConfig{Env: "dev", PrettyJSON: true}, pretty: true},
+		{name: "prod", config: LogConfig{Env: "prod", PrettyJSON: true}},
+		{name: "schema version", config: LogConfig{Env: "dev", PrettyJSON: true, SchemaVersion: "2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			config := tt.config
+			config.LogDir, config.MaxSize = dir, 1
+			w := createLogFileWriter(&config)
+			if _, err := w.Write(line); err != nil {
+				t.Fatalf("write failed: %v", err)
+			}
+			if c, ok := w.(io.Closer); ok {
+				defer c.Close()
+			}
+
+			data, err := os.ReadFile(filepath.Join(dir, "app.log"))
+			if err != nil {
+				t.Fatalf("read log file: %v", err)
+			}
+			if indented := strings.Contains(string(data), "\n  \"level\""); indented != tt.pretty {
+				t.Errorf("expected indentation=%v, got %q", tt.pretty, data)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package zllog
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// ============================================================================
+// 按 module 限流（保护日志链路，与采样相互独立）
+// ============================================================================
+
+// rateLimitWindow 限流的统计窗口（测试中可调小）
+var rateLimitWindow = time.Second
+
+// moduleRateLimiter 按 module 限制每个窗口内输出的日志条数
+// 只为配置了限额的 module 创建窗口，初始化后 windows 只读，因此查找无需加锁
+type moduleRateLimiter struct {
+	windows map[string]*rateWindow
+	// report 输出被丢弃条数的汇总日志
+	report func(module string, limit, dropped int64)
+}
+
+// rateWindow 单个 module 的限流窗口
+type rateWindow struct {
+	module string
+	limit  int64
+
+	mu      sync.Mutex
+	window  int64 // 当前窗口编号
+	count   int64 // 当前窗口已输出的条数
+	dropped int64 // 尚未汇总的丢弃条数
+}
+
+// newModuleRateLimiter 根据配置创建限流器，没有有效限额时返回 nil
+func newModuleRateLimiter(limits map[string]int, report func(module string, limit, dropped int64)) *moduleRateLimiter {
+	windows := make(map[string]*rateWindow, len(limits))
+	for module, limit := range limits {
+		if limit > 0 {
+			windows[module] = &rateWindow{module: module, limit: int64(limit), window: -1}
+		}
+	}
+	if len(windows) == 0 {
+		return nil
+	}
+	return &moduleRateLimiter{windows: windows, report: report}
+}
+
+// Allow 判断 module 的日志是否可以输出，未配置限额的 module 总是允许
+// 窗口内第一次丢弃时安排在窗口结束后输出汇总日志
+func (r *moduleRateLimiter) Allow(module string) bool {
+	w, ok := r.windows[module]
+	if !ok {
+		return true
+	}
+
+	now := time.Now().UnixNano()
+	window := now / int64(rateLimitWindow)
+
+	w.mu.Lock()
+	if w.window != window {
+		w.window = window
+		w.count = 0
+	}
+	if w.count < w.limit {
+		w.count++
+		w.mu.Unlock()
+		return true
+	}
+	w.dropped++
+	first := w.dropped == 1
+	w.mu.Unlock()
+
+	countDropped()
+	if first {
+		remaining := time.Duration((window+1)*int64(rateLimitWindow) - now)
+		time.AfterFunc(remaining, func() {
+			r.flush(w)
+		})
+	}
+	return false
+}
+
+// flush 输出并清零窗口的丢弃条数
+func (r *moduleRateLimiter) flush(w *rateWindow) {
+	w.mu.Lock()
+	dropped := w.dropped
+	w.dropped = 0
+	w.mu.Unlock()
+
+	if dropped > 0 {
+		r.report(w.module, w.limit, dropped)
+	}
+}
+
+// reportRateLimited 以 WARN 级别输出限流汇总日志（不受限流本身的约束）
+func (l *ZerologLogger) reportRateLimited(module string, limit, dropped int64) {
+	l.logger.WithLevel(zerolog.WarnLevel).
+		Int64("dropped_by_ratelimit", dropped).
+		Int64("rate_limit", limit).
+		Str("trace_id", newTraceID()).
+		Str("module", module).
+		Msg("log lines dropped by rate limit")
+}
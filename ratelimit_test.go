@@ -0,0 +1,57 @@
+package zllog
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestModuleRateLimit 测试超出限额的日志被丢弃，窗口结束后输出汇总
+func TestModuleRateLimit(t *testing.T) {
+	originalWindow := rateLimitWindow
+	rateLimitWindow = 200 * time.Millisecond
+	t.Cleanup(func() {
+		rateLimitWindow = originalWindow
+	})
+
+	logger, out := newSyncTestLogger(t, &LogConfig{ModuleRateLimits: map[string]int{"noisy": 5}})
+
+	// 从新窗口的开头开始，避免突发日志跨越两个窗口
+	window := int64(rateLimitWindow)
+	time.Sleep(time.Duration(window - time.Now().UnixNano()%window))
+
+	ctx := context.Background()
+	for i := 0; i < 20; i++ {
+		logger.Info(ctx, "noisy", "burst")
+		logger.Info(ctx, "quiet", "burst")
+	}
+
+	var lines []map[string]interface{}
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		lines = decodeLines(t, out.snapshot())
+		if len(lines) > 0 && lines[len(lines)-1]["dropped_by_ratelimit"] != nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	counts := map[string]int{}
+	var summary map[string]interface{}
+	for _, line := range lines {
+		if line["dropped_by_ratelimit"] != nil {
+			summary = line
+			continue
+		}
+		counts[line["module"].(string)]++
+	}
+	if counts["noisy"] != 5 || counts["quiet"] != 20 {
+		t.Errorf("expected 5 noisy and 20 quiet lines, got %v", counts)
+	}
+	if summary == nil {
+		t.Fatal("expected a dropped_by_ratelimit summary line")
+	}
+	if summary["module"] != "noisy" || summary["dropped_by_ratelimit"] != float64(15) || summary["level"] != "warn" {
+		t.Errorf("unexpected summary: %v", summary)
+	}
+}
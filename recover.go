@@ -0,0 +1,107 @@
+package zllog
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// ============================================================================
+// panic 恢复
+// ============================================================================
+
+// recoverRepanic Recover 记录日志后是否重新抛出 panic（由 LogConfig.RecoverRepanic 控制）
+var recoverRepanic bool
+
+// Recover 捕获 panic 并以 ERROR 级别记录（附带 panic=true 和 stack_frames 字段）
+// 必须直接通过 defer 调用，否则 recover() 无法捕获到 panic
+// 记录后根据 LogConfig.RecoverRepanic 决定是否重新 panic
+//
+// 用法示例：
+//   go func() {
+//       defer zllog.Recover(ctx, "worker")
+//       // ...
+//   }()
+func Recover(ctx context.Context, module string) {
+	if r := recover(); r != nil {
+		logPanic(ctx, module, r)
+		if recoverRepanic {
+			panic(r)
+		}
+	}
+}
+
+// RecoverWithHandler 捕获 panic 并记录日志，然后调用 handler 处理恢复的值
+// 是否重新 panic 由 handler 决定，不受 LogConfig.RecoverRepanic 影响
+func RecoverWithHandler(ctx context.Context, module string, handler func(recovered interface{})) {
+	if r := recover(); r != nil {
+		logPanic(ctx, module, r)
+		if handler != nil {
+			handler(r)
+		}
+	}
+}
+
+// logPanic 以 ERROR 级别记录 panic 信息和调用栈
+func logPanic(ctx context.Context, module string, recovered interface{}) {
+	err, ok := recovered.(error)
+	if !ok {
+		err = fmt.Errorf("%v", recovered)
+	}
+	Error(ctx, module, "panic recovered", err,
+		Bool("panic", true),
+		Objects("stack_frames", panicStackFrames()))
+}
+
+// maxStackFrames 记录的调用栈最大帧数
+const maxStackFrames = 64
+
+// StackFrame 调用栈中的一帧，以结构化数组输出便于在 Elasticsearch 中按函数或文件检索
+//
+//   "stack_frames": [{"function": "main.handle", "file": "/app/main.go", "line": 42}, ...]
+type StackFrame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// MarshalZerologObject 实现 zerolog.LogObjectMarshaler
+func (f StackFrame) MarshalZerologObject(e *zerolog.Event) {
+	e.Str("function", f.Function).Str("file", f.File).Int("line", f.Line)
+}
+
+// panicStackFrames 返回 panic 发生处的调用栈，第一帧为触发 panic 的函数
+// 跳过 Recover、logPanic 和 runtime 的 panic 处理帧；找不到 panic 帧时从 logPanic 的调用方开始
+func panicStackFrames() []zerolog.LogObjectMarshaler {
+	pcs := make([]uintptr, maxStackFrames)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var stack []zerolog.LogObjectMarshaler
+	for {
+		frame, more := frames.Next()
+		if frame.Function == "runtime.gopanic" {
+			// 之前的帧属于 Recover 的 defer 调用，丢弃
+			stack = stack[:0]
+		} else {
+			stack = append(stack, StackFrame{Function: frame.Function, File: frame.File, Line: frame.Line})
+		}
+		if !more {
+			break
+		}
+	}
+	// 运行时错误（如空指针、越界）经由 runtime.panicmem 等函数触发 panic，这些帧不是业务代码
+	for len(stack) > 1 && isRuntimePanicFrame(stack[0].(StackFrame).Function) {
+		stack = stack[1:]
+	}
+	return stack
+}
+
+// isRuntimePanicFrame 是否为 runtime 中触发 panic 的函数（runtime.panicmem、runtime.goPanicIndex 等）
+func isRuntimePanicFrame(function string) bool {
+	return strings.HasPrefix(function, "runtime.panic") || strings.HasPrefix(function, "runtime.goPanic") ||
+		function == "runtime.sigpanic"
+}
@@ -0,0 +1,125 @@
+package zllog
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestRecover 测试 Recover 捕获 panic 并输出 ERROR 日志
+func TestRecover(t *testing.T) {
+	originalLogger := globalLoggerImpl
+	defer func() {
+		globalLoggerImpl = originalLogger
+	}()
+
+	mock := &MockLogger{}
+	SetLogger(mock)
+
+	func() {
+		defer Recover(context.Background(), "worker")
+		panic("boom")
+	}()
+
+	if mock.getLastCall() != "[ERROR] worker: panic recovered" {
+		t.Errorf("unexpected call: %s", mock.getLastCall())
+	}
+}
+
+// TestRecoverWithHandler 测试 handler 收到 panic 的值
+func TestRecoverWithHandler(t *testing.T) {
+	originalLogger := globalLoggerImpl
+	defer func() {
+		globalLoggerImpl = originalLogger
+	}()
+
+	mock := &MockLogger{}
+	SetLogger(mock)
+
+	var recovered interface{}
+	func() {
+		defer RecoverWithHandler(context.Background(), "worker", func(r interface{}) {
+			recovered = r
+		})
+		panic("boom")
+	}()
+
+	if recovered != "boom" {
+		t.Errorf("handler got %v", recovered)
+	}
+	if mock.getCallCount() != 1 {
+		t.Errorf("expected 1 log call, got %d", mock.getCallCount())
+	}
+}
+
+// TestRecoverRepanic 测试开启 RecoverRepanic 后重新抛出 panic
+func TestRecoverRepanic(t *testing.T) {
+	originalLogger := globalLoggerImpl
+	defer func() {
+		globalLoggerImpl = originalLogger
+		recoverRepanic = false
+	}()
+
+	SetLogger(&MockLogger{})
+	recoverRepanic = true
+
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("expected re-panic with boom, got %v", r)
+		}
+	}()
+	func() {
+		defer Recover(context.Background(), "worker")
+		panic("boom")
+	}()
+}
+
+// panickingWorker 测试用的触发 panic 的函数
+func panickingWorker() {
+	panic("boom")
+}
+
+// nilDeref 测试用的触发运行时错误的函数
+func nilDeref() int {
+	var p *int
+	return *p
+}
+
+// TestRecoverStackFrames 测试 panic 调用栈以结构化数组输出，第一帧为触发 panic 的函数
+func TestRecoverStackFrames(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{})
+	defer SwapLogger(logger)()
+
+	func() {
+		defer Recover(context.Background(), "worker")
+		panickingWorker()
+	}()
+	func() {
+		defer Recover(context.Background(), "worker")
+		nilDeref()
+	}()
+
+	lines := decodeLines(t, buf)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %v", lines)
+	}
+	for i, want := range []string{"panickingWorker", "nilDeref"} {
+		frames, ok := lines[i]["stack_frames"].([]interface{})
+		if !ok || len(frames) < 2 {
+			t.Fatalf("expected stack_frames array, got %v", lines[i]["stack_frames"])
+		}
+		first := frames[0].(map[string]interface{})
+		if fn, _ := first["function"].(string); !strings.HasSuffix(fn, "."+want) {
+			t.Errorf("expected first frame in %s, got %v", want, first)
+		}
+		if file, _ := first["file"].(string); !strings.HasSuffix(file, "recover_test.go") {
+			t.Errorf("expected frame file recover_test.go, got %v", first["file"])
+		}
+		if line, _ := first["line"].(float64); line <= 0 {
+			t.Errorf("expected positive line number, got %v", first["line"])
+		}
+		if lines[i]["stack"] != nil {
+			t.Errorf("expected no raw stack string, got %v", lines[i]["stack"])
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package zllog
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ============================================================================
+// 日志回放（重新解析 JSON 日志文件并通过指定 Logger 输出）
+// ============================================================================
+
+// maxReplayLineSize 回放时单行日志的最大长度
+const maxReplayLineSize = 4 * 1024 * 1024
+
+// replayRegenerated 回放时不作为字段输出的 key：由目标 Logger 重新生成，或已还原为方法参数
+var replayRegenerated = map[string]bool{
+	"level": true, "message": true, "module": true, "trace_id": true, "parent_trace_id": true,
+	"error": true, "error_code": true, "request_id": true, "cost_ms": true,
+	"service": true, "env": true, "host": true, "caller": true, "time": true,
+}
+
+// ReplaySkippedError 回放时跳过了无法解析的行（其余行已正常回放）
+type ReplaySkippedError struct {
+	Replayed int // 成功回放的行数
+	Skipped  int // 跳过的行数（不是 JSON 对象）
+}
+
+func (e *ReplaySkippedError) Error() string {
+	return fmt.Sprintf("zllog replay: skipped %d malformed lines (%d replayed)", e.Skipped, e.Replayed)
+}
+
+// ReplayFile 读取 JSON 日志文件（如 app.log），逐行还原 level/module/message/字段后通过 logger 重新输出
+// 用于切换日志投递目标时补发历史日志：
+//   - trace_id、parent_trace_id、request_id、cost_ms、error、error_code 还原为原值，原始时间以 original_time 字段输出
+//   - FATAL 和 PANIC 级别的日志不会退出进程或 panic（Logger 实现了 FatalNoExitLogger 时以 FATAL 输出，否则以 ERROR 输出）
+//   - 无法解析的行被跳过，全部回放后返回 *ReplaySkippedError（可通过 errors.As 获取跳过的行数）
+//
+// 用法示例：
+//   target := remote.NewRemoteLogger(remote.Config{...})
+//   err := zllog.ReplayFile("./logs/app-2025-01-27.log", target)
+func ReplayFile(path string, logger Logger) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return Replay(f, logger)
+}
+
+// Replay 与 ReplayFile 相同，从 r 中读取日志
+func Replay(r io.Reader, logger Logger) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxReplayLineSize)
+
+	var replayed, skipped int
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if !replayLine(logger, line) {
+			skipped++
+			continue
+		}
+		replayed++
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if skipped > 0 {
+		return &ReplaySkippedError{Replayed: replayed, Skipped: skipped}
+	}
+	return nil
+}
+
+// replayLine 还原一行日志并输出，不是 JSON 对象时返回 false
+func replayLine(logger Logger, line []byte) bool {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	var m map[string]interface{}
+	if err := dec.Decode(&m); err != nil || m == nil {
+		return false
+	}
+
+	str := func(key string) string {
+		s, _ := m[key].(string)
+		return s
+	}
+	ctx := context.Background()
+	if traceID := str("trace_id"); traceID != "" {
+		ctx = withTraceID(ctx, traceID)
+	}
+	ctx = WithParentTrace(ctx, str("parent_trace_id"))
+	module, message := str("module"), str("message")
+	requestID, errorCode := str("request_id"), str("error_code")
+	var costMs int64
+	if n, ok := m["cost_ms"].(json.Number); ok {
+		costMs, _ = n.Int64()
+	}
+	var err error
+	if s := str("error"); s != "" {
+		err = errors.New(s)
+	}
+
+	// 其余字段按 key 排序输出，保证回放结果稳定
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		if !replayRegenerated[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	fields := make([]Field, 0, len(keys)+1)
+	if t := str("time"); t != "" {
+		fields = append(fields, String("original_time", t))
+	}
+	for _, k := range keys {
+		fields = append(fields, Any(k, m[k]))
+	}
+
+	withRequest := requestID != "" || costMs != 0
+	switch level := strings.ToLower(str("level")); level {
+	case "trace":
+		if tl, ok := logger.(TraceLogger); ok {
+			tl.Trace(ctx, module, message, fields...)
+		} else {
+			logger.Debug(ctx, module, message, fields...)
+		}
+	case "debug":
+		logger.Debug(ctx, module, message, fields...)
+	case "warn":
+		logger.Warn(ctx, module, message, fields...)
+	case "error", "fatal", "panic":
+		if level == "fatal" {
+			if fl, ok := logger.(FatalNoExitLogger); ok {
+				fl.FatalNoExit(ctx, module, message, err, fields...)
+				return true
+			}
+		}
+		if level != "error" {
+			fields = append(fields, String("original_level", level))
+		}
+		switch {
+		case errorCode != "":
+			logger.ErrorWithCode(WithRequestID(ctx, requestID), module, message, errorCode, err, fields...)
+		case withRequest:
+			logger.ErrorWithRequest(ctx, module, message, requestID, err, costMs, fields...)
+		default:
+			logger.Error(ctx, module, message, err, fields...)
+		}
+	default:
+		if withRequest {
+			logger.InfoWithRequest(ctx, module, message, requestID, costMs, fields...)
+		} else {
+			logger.Info(ctx, module, message, fields...)
+		}
+	}
+	return true
+}
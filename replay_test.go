@@ -0,0 +1,63 @@
+package zllog
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestReplayFile 测试回放 JSON 日志文件，跳过无法解析的行
+func TestReplayFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	content := `{"level":"info","time":"2025-01-27T10:00:00Z","service":"svc","trace_id":"4bf92f3577b34da6a3ce929d0e0e4736","module":"order","message":"created","order_id":"o-1","count":3}
+not json
+{"level":"error","module":"pay","message":"charge failed","error":"timeout","error_code":"PAY_001","request_id":"req-1"}
+
+{"level":"fatal","module":"main","message":"shutdown"}
+{"level":"info","module":"api","message":"done","request_id":"req-2","cost_ms":15}
+[1,2]
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var entries []Entry
+	logger := NewEntryLogger(func(ctx context.Context, e Entry) {
+		entries = append(entries, e)
+	})
+
+	err := ReplayFile(path, logger)
+	var skippedErr *ReplaySkippedError
+	if !errors.As(err, &skippedErr) || skippedErr.Skipped != 2 || skippedErr.Replayed != 4 {
+		t.Fatalf("expected 2 skipped and 4 replayed, got %v", err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 entries, got %d", len(entries))
+	}
+
+	first := entries[0]
+	fields := FieldsToMap(first.Fields)
+	if first.Level != "info" || first.Module != "order" || first.Message != "created" ||
+		first.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("unexpected first entry: %+v", first)
+	}
+	if fields["order_id"] != "o-1" || fields["count"] == nil || fields["original_time"] != "2025-01-27T10:00:00Z" {
+		t.Errorf("unexpected first entry fields: %v", fields)
+	}
+	if _, ok := fields["service"]; ok {
+		t.Errorf("service should be regenerated, not replayed as field: %v", fields)
+	}
+
+	second := entries[1]
+	if second.Level != "error" || second.Error != "timeout" || second.ErrorCode != "PAY_001" || second.RequestID != "req-1" {
+		t.Errorf("unexpected error entry: %+v", second)
+	}
+	if entries[2].Level != "fatal" || entries[2].Message != "shutdown" {
+		t.Errorf("unexpected fatal entry: %+v", entries[2])
+	}
+	if entries[3].RequestID != "req-2" || entries[3].CostMs != 15 {
+		t.Errorf("unexpected request entry: %+v", entries[3])
+	}
+}
@@ -0,0 +1,135 @@
+package zllog
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// ============================================================================
+// 日志采样
+// ============================================================================
+
+// sampleBuckets 采样比例的精度（万分之一）
+const sampleBuckets = 10000
+
+// TraceSampler 按 trace_id 一致性采样
+// 对 trace_id 做哈希后按比例决定保留或丢弃，同一个 trace 的所有日志结果一致，
+// 便于按 trace 完整地排查问题（而不是每条日志独立采样导致链路残缺）
+type TraceSampler struct {
+	// Rate 保留的 trace 比例（0~1）
+	Rate float64
+
+	// SampleUntraced 没有 trace_id 的日志是否也按 Rate 随机采样
+	// 默认 false：没有 trace_id 的日志全部保留
+	SampleUntraced bool
+}
+
+// NewTraceSampler 创建按 trace_id 采样的采样器
+func NewTraceSampler(rate float64) *TraceSampler {
+	return &TraceSampler{Rate: rate}
+}
+
+// Keep 判断该 trace_id 的日志是否保留
+func (s *TraceSampler) Keep(traceID string) bool {
+	threshold := uint64(s.Rate * sampleBuckets)
+	if traceID == "" {
+		if !s.SampleUntraced {
+			return true
+		}
+		return uint64(rand.Intn(sampleBuckets)) < threshold
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(traceID))
+	return h.Sum64()%sampleBuckets < threshold
+}
+
+// ============================================================================
+// 突发采样（每个周期内先保留 Initial 条，之后每 Thereafter 条保留 1 条）
+// ============================================================================
+
+// 采样原因（sample_reason 字段的取值）
+const (
+	sampleReasonInitial    = "initial"    // 周期内的前 Initial 条
+	sampleReasonThereafter = "thereafter" // 超过 Initial 后按 Thereafter 比例保留
+)
+
+// burstSamplerBuckets 计数桶数量，同一条日志按哈希落入固定的桶
+const burstSamplerBuckets = 4096
+
+// SamplingConfig 突发采样配置，同一条日志（级别 + module + 消息相同）在每个周期内：
+// 前 Initial 条全部保留，之后每 Thereafter 条保留 1 条，其余丢弃
+// 用于抑制循环中的刷屏日志，保留的日志带 sample_reason 字段说明保留原因
+type SamplingConfig struct {
+	Initial    int           // 每个周期内先完整保留的条数（0 表示不启用突发采样）
+	Thereafter int           // 超过 Initial 后每 Thereafter 条保留 1 条（0 表示全部丢弃）
+	Tick       time.Duration // 计数周期（默认 1 秒）
+}
+
+// burstCounter 一个桶在当前周期内的计数
+type burstCounter struct {
+	resetAt int64 // 当前周期的结束时间（UnixNano）
+	count   uint64
+}
+
+// inc 计数加一，周期结束时从头开始计数
+func (c *burstCounter) inc(now time.Time, tick time.Duration) uint64 {
+	tn := now.UnixNano()
+	resetAt := atomic.LoadInt64(&c.resetAt)
+	if resetAt > tn {
+		return atomic.AddUint64(&c.count, 1)
+	}
+	atomic.StoreUint64(&c.count, 1)
+	newResetAt := tn + tick.Nanoseconds()
+	if !atomic.CompareAndSwapInt64(&c.resetAt, resetAt, newResetAt) {
+		// 其他 goroutine 已经开始了新周期
+		return atomic.AddUint64(&c.count, 1)
+	}
+	return 1
+}
+
+// burstSampler 突发采样器
+type burstSampler struct {
+	initial    uint64
+	thereafter uint64
+	tick       time.Duration
+	counters   [burstSamplerBuckets]burstCounter
+}
+
+// newBurstSampler 创建突发采样器，未启用时返回 nil
+func newBurstSampler(config SamplingConfig) *burstSampler {
+	if config.Initial <= 0 {
+		return nil
+	}
+	tick := config.Tick
+	if tick <= 0 {
+		tick = time.Second
+	}
+	thereafter := config.Thereafter
+	if thereafter < 0 {
+		thereafter = 0
+	}
+	return &burstSampler{initial: uint64(config.Initial), thereafter: uint64(thereafter), tick: tick}
+}
+
+// sample 判断该日志是否保留，保留时返回保留原因
+func (s *burstSampler) sample(level zerolog.Level, module, message string) (bool, string) {
+	h := fnv.New32a()
+	h.Write([]byte{byte(level)})
+	h.Write([]byte(module))
+	h.Write([]byte{0})
+	h.Write([]byte(message))
+
+	n := s.counters[h.Sum32()%burstSamplerBuckets].inc(time.Now(), s.tick)
+	if n <= s.initial {
+		return true, sampleReasonInitial
+	}
+	if s.thereafter > 0 && (n-s.initial)%s.thereafter == 0 {
+		return true, sampleReasonThereafter
+	}
+	return false, ""
+}
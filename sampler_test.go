@@ -0,0 +1,173 @@
+package zllog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// testTraceKey 测试用的 trace_id context 键
+type testTraceKey struct{}
+
+// testTraceIDProvider 从 context 中读取测试用 trace_id
+type testTraceIDProvider struct{}
+
+func (testTraceIDProvider) GetTraceID(ctx context.Context) string {
+	traceID, _ := ctx.Value(testTraceKey{}).(string)
+	return traceID
+}
+
+func (testTraceIDProvider) Name() string {
+	return "test"
+}
+
+// withTestTraceProvider 在测试期间注册测试用 TraceIDProvider
+func withTestTraceProvider(t *testing.T) {
+	original := GetTraceIDProvider()
+	RegisterTraceIDProvider(testTraceIDProvider{})
+	t.Cleanup(func() {
+		RegisterTraceIDProvider(original)
+	})
+}
+
+// TestTraceSampler 测试同一 trace 的日志一起保留或丢弃
+func TestTraceSampler(t *testing.T) {
+	withTestTraceProvider(t)
+	logger, buf := newTestLogger(t, &LogConfig{TraceSampleRate: 0.5})
+
+	const traces, linesPerTrace = 100, 5
+	for i := 0; i < traces; i++ {
+		ctx := context.WithValue(context.Background(), testTraceKey{}, fmt.Sprintf("trace-%d", i))
+		for j := 0; j < linesPerTrace; j++ {
+			logger.Info(ctx, "test", "sampled line")
+		}
+	}
+
+	counts := make(map[string]int)
+	for _, line := range decodeLines(t, buf) {
+		counts[line["trace_id"].(string)]++
+	}
+	for traceID, n := range counts {
+		if n != linesPerTrace {
+			t.Errorf("trace %s kept %d of %d lines", traceID, n, linesPerTrace)
+		}
+	}
+	if len(counts) == 0 || len(counts) == traces {
+		t.Errorf("expected some traces to be dropped, kept %d of %d", len(counts), traces)
+	}
+}
+
+// TestTraceSamplerUntraced 测试没有 trace_id 的日志默认全部保留
+func TestTraceSamplerUntraced(t *testing.T) {
+	sampler := NewTraceSampler(0.01)
+	for i := 0; i < 100; i++ {
+		if !sampler.Keep("") {
+			t.Fatal("untraced lines should always pass")
+		}
+	}
+}
+
+// TestBurstSamplingReason 测试突发采样的保留原因：前 Initial 条为 initial，之后每 Thereafter 条为 thereafter
+func TestBurstSamplingReason(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{Sampling: SamplingConfig{Initial: 2, Thereafter: 3, Tick: time.Minute}})
+
+	ctx := context.Background()
+	for i := 0; i < 10; i++ {
+		logger.Info(ctx, "worker", "retrying")
+	}
+	// 不同消息单独计数
+	logger.Info(ctx, "worker", "other")
+
+	lines := decodeLines(t, buf)
+	var reasons []string
+	for _, line := range lines {
+		reasons = append(reasons, fmt.Sprint(line["sample_reason"]))
+	}
+	// 第 1、2 条为 initial，第 5、8 条为 thereafter
+	want := []string{"initial", "initial", "thereafter", "thereafter", "initial"}
+	if fmt.Sprint(reasons) != fmt.Sprint(want) {
+		t.Errorf("expected reasons %v, got %v", want, reasons)
+	}
+}
+
+// TestBurstSamplingDisabled 测试未启用突发采样时不输出 sample_reason
+func TestBurstSamplingDisabled(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{})
+
+	for i := 0; i < 3; i++ {
+		logger.Info(context.Background(), "worker", "retrying")
+	}
+	lines := decodeLines(t, buf)
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(lines))
+	}
+	if _, ok := lines[0]["sample_reason"]; ok {
+		t.Errorf("unexpected sample_reason without sampling: %v", lines[0])
+	}
+}
+
+// TestLevelSampling 测试按级别配置突发采样：DEBUG 被采样，INFO 使用 Sampling，ERROR 默认不采样
+func TestLevelSampling(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{
+		Sampling:      SamplingConfig{Initial: 5, Tick: time.Minute},
+		LevelSampling: map[string]SamplingConfig{"debug": {Initial: 1, Thereafter: 10, Tick: time.Minute}},
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 20; i++ {
+		logger.Debug(ctx, "worker", "polling")
+		logger.Info(ctx, "worker", "polled")
+		logger.Error(ctx, "worker", "poll failed", errors.New("timeout"))
+	}
+	counts := map[string]int{}
+	for _, line := range decodeLines(t, buf) {
+		counts[fmt.Sprint(line["level"])]++
+		if line["level"] == "error" && line["sample_reason"] != nil {
+			t.Errorf("error lines should not carry sample_reason: %v", line)
+		}
+	}
+	// DEBUG 第 1 条为 initial，第 11 条为 thereafter；INFO 按 Sampling 只保留前 5 条
+	if counts["debug"] != 2 || counts["info"] != 5 || counts["error"] != 20 {
+		t.Errorf("expected 2 debug, 5 info and 20 error lines, got %v", counts)
+	}
+}
+
+// TestTraceSamplingSkipsErrors 测试按 trace 采样丢弃的 trace 中 ERROR 日志仍然保留
+func TestTraceSamplingSkipsErrors(t *testing.T) {
+	withTestTraceProvider(t)
+	logger, buf := newTestLogger(t, &LogConfig{TraceSampleRate: 0.5})
+
+	for i := 0; i < 20; i++ {
+		ctx := context.WithValue(context.Background(), testTraceKey{}, fmt.Sprintf("trace-%d", i))
+		logger.Info(ctx, "worker", "polled")
+		logger.Error(ctx, "worker", "poll failed", nil)
+	}
+	counts := map[string]int{}
+	for _, line := range decodeLines(t, buf) {
+		counts[fmt.Sprint(line["level"])]++
+	}
+	if counts["error"] != 20 || counts["info"] == 20 {
+		t.Errorf("expected all errors kept and some info sampled, got %v", counts)
+	}
+}
+
+// TestLevelSamplingErrorOptIn 测试在 LevelSampling 中显式配置 ERROR 后按配置采样
+func TestLevelSamplingErrorOptIn(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{
+		LevelSampling: map[string]SamplingConfig{"ERROR": {Initial: 3, Tick: time.Minute}, "info": {}},
+	})
+
+	for i := 0; i < 10; i++ {
+		logger.Error(context.Background(), "worker", "poll failed", nil)
+		logger.Info(context.Background(), "worker", "polled")
+	}
+	counts := map[string]int{}
+	for _, line := range decodeLines(t, buf) {
+		counts[fmt.Sprint(line["level"])]++
+	}
+	if counts["error"] != 3 || counts["info"] != 10 {
+		t.Errorf("expected 3 error and 10 info lines, got %v", counts)
+	}
+}
@@ -0,0 +1,153 @@
+package zllog
+
+import "context"
+
+// ============================================================================
+// ScopedLogger - 绑定 module 的日志对象
+// ============================================================================
+
+// moduleSeparator 层级 module 名称的分隔符
+const moduleSeparator = "."
+
+// Named 拼接层级 module 名称，如 Named("api.payment", "refund") => "api.payment.refund"
+// parent 或 child 为空时直接返回另一个
+func Named(parent, child string) string {
+	if parent == "" {
+		return child
+	}
+	if child == "" {
+		return parent
+	}
+	return parent + moduleSeparator + child
+}
+
+// ScopedLogger 绑定了 module 的日志对象，调用时无需重复传入 module
+// 所有方法都委托给包级日志函数，因此同样适用于通过 SetLogger 设置的自定义实现
+//
+// 用法示例：
+//   var log = zllog.NewScopedLogger("api").Named("payment")
+//   log.Info(ctx, "refund accepted")  // module=api.payment
+type ScopedLogger struct {
+	module string
+	err    error // WithError 绑定的错误
+}
+
+// NewScopedLogger 创建绑定 module 的日志对象
+func NewScopedLogger(module string) *ScopedLogger {
+	return &ScopedLogger{module: module}
+}
+
+// Module 返回完整的 module 名称
+func (s *ScopedLogger) Module() string {
+	return s.module
+}
+
+// Named 创建子 module 的日志对象，module 以 "." 分隔追加
+func (s *ScopedLogger) Named(child string) *ScopedLogger {
+	return &ScopedLogger{module: Named(s.module, child), err: s.err}
+}
+
+// WithError 返回绑定了 err 的日志对象，不影响原对象
+// 之后的 Error、ErrorWithCode、Errorf、Fatal、Panic、ErrorWithRequest 调用传入 nil 时使用绑定的 err，
+// 传入非 nil 时以传入的为准；LogErr 和 LogErrWithCode 不受影响
+func (s *ScopedLogger) WithError(err error) *ScopedLogger {
+	return &ScopedLogger{module: s.module, err: err}
+}
+
+// errOr 传入的 err 为 nil 时返回绑定的错误
+func (s *ScopedLogger) errOr(err error) error {
+	if err == nil {
+		return s.err
+	}
+	return err
+}
+
+// Enabled 判断该 module 下 level 级别的日志是否可能输出（见 EnabledFor）
+func (s *ScopedLogger) Enabled(ctx context.Context, level string) bool {
+	return EnabledFor(ctx, s.module, level)
+}
+
+// Trace logs a message at TRACE level
+func (s *ScopedLogger) Trace(ctx context.Context, message string, fields ...Field) {
+	Trace(ctx, s.module, message, fields...)
+}
+
+// Debug logs a message at DEBUG level
+func (s *ScopedLogger) Debug(ctx context.Context, message string, fields ...Field) {
+	Debug(ctx, s.module, message, fields...)
+}
+
+// Info logs a message at INFO level
+func (s *ScopedLogger) Info(ctx context.Context, message string, fields ...Field) {
+	Info(ctx, s.module, message, fields...)
+}
+
+// Warn logs a message at WARN level
+func (s *ScopedLogger) Warn(ctx context.Context, message string, fields ...Field) {
+	Warn(ctx, s.module, message, fields...)
+}
+
+// Error logs a message at ERROR level with error info
+func (s *ScopedLogger) Error(ctx context.Context, message string, err error, fields ...Field) {
+	Error(ctx, s.module, message, s.errOr(err), fields...)
+}
+
+// ErrorWithCode logs a message at ERROR level with error code
+func (s *ScopedLogger) ErrorWithCode(ctx context.Context, message, errorCode string, err error, fields ...Field) {
+	ErrorWithCode(ctx, s.module, message, errorCode, s.errOr(err), fields...)
+}
+
+// LogErr 输出 ERROR 日志并原样返回 err，err 为 nil 时不输出日志
+func (s *ScopedLogger) LogErr(ctx context.Context, message string, err error, fields ...Field) error {
+	return LogErr(ctx, s.module, message, err, fields...)
+}
+
+// LogErrWithCode 与 LogErr 相同，附带错误码
+func (s *ScopedLogger) LogErrWithCode(ctx context.Context, message, errorCode string, err error, fields ...Field) error {
+	return LogErrWithCode(ctx, s.module, message, errorCode, err, fields...)
+}
+
+// Fatal logs a message at FATAL level and exits
+func (s *ScopedLogger) Fatal(ctx context.Context, message string, err error, fields ...Field) {
+	Fatal(ctx, s.module, message, s.errOr(err), fields...)
+}
+
+// Panic logs a message at PANIC level and then panics
+func (s *ScopedLogger) Panic(ctx context.Context, message string, err error, fields ...Field) {
+	Panic(ctx, s.module, message, s.errOr(err), fields...)
+}
+
+// InfoWithRequest INFO日志 + request_id + cost_ms
+func (s *ScopedLogger) InfoWithRequest(ctx context.Context, message, requestID string, costMs int64, fields ...Field) {
+	InfoWithRequest(ctx, s.module, message, requestID, costMs, fields...)
+}
+
+// ErrorWithRequest ERROR日志 + request_id + cost_ms
+func (s *ScopedLogger) ErrorWithRequest(ctx context.Context, message, requestID string, err error, costMs int64, fields ...Field) {
+	ErrorWithRequest(ctx, s.module, message, requestID, s.errOr(err), costMs, fields...)
+}
+
+// Tracef logs a formatted message at TRACE level
+func (s *ScopedLogger) Tracef(ctx context.Context, format string, args ...interface{}) {
+	Tracef(ctx, s.module, format, args...)
+}
+
+// Debugf logs a formatted message at DEBUG level
+func (s *ScopedLogger) Debugf(ctx context.Context, format string, args ...interface{}) {
+	Debugf(ctx, s.module, format, args...)
+}
+
+// Infof logs a formatted message at INFO level
+func (s *ScopedLogger) Infof(ctx context.Context, format string, args ...interface{}) {
+	Infof(ctx, s.module, format, args...)
+}
+
+// Warnf logs a formatted message at WARN level
+func (s *ScopedLogger) Warnf(ctx context.Context, format string, args ...interface{}) {
+	Warnf(ctx, s.module, format, args...)
+}
+
+// Errorf logs a formatted message at ERROR level with error info
+func (s *ScopedLogger) Errorf(ctx context.Context, format string, err error, args ...interface{}) {
+	Errorf(ctx, s.module, format, s.errOr(err), args...)
+}
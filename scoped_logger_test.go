@@ -0,0 +1,68 @@
+package zllog
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// TestNamed 测试层级 module 名称拼接
+func TestNamed(t *testing.T) {
+	cases := []struct {
+		parent, child, want string
+	}{
+		{"a", "b", "a.b"},
+		{"a.b", "c", "a.b.c"},
+		{"", "b", "b"},
+		{"a", "", "a"},
+	}
+	for _, c := range cases {
+		if got := Named(c.parent, c.child); got != c.want {
+			t.Errorf("Named(%q, %q) = %q, want %q", c.parent, c.child, got, c.want)
+		}
+	}
+}
+
+// TestScopedLoggerNamed 测试嵌套 Named 后 module 字段为完整路径
+func TestScopedLoggerNamed(t *testing.T) {
+	originalLogger := globalLoggerImpl
+	defer func() {
+		globalLoggerImpl = originalLogger
+	}()
+
+	mock := &MockLogger{}
+	SetLogger(mock)
+
+	logger := NewScopedLogger("a").Named("b").Named("c")
+	if logger.Module() != "a.b.c" {
+		t.Fatalf("expected module a.b.c, got %s", logger.Module())
+	}
+
+	logger.Info(context.Background(), "nested")
+	if mock.getLastCall() != "[INFO] a.b.c: nested" {
+		t.Errorf("unexpected call: %s", mock.getLastCall())
+	}
+}
+
+// TestScopedLoggerWithError 测试 ScopedLogger 绑定的 error 出现在多行 ERROR 日志中
+func TestScopedLoggerWithError(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{})
+	defer SwapLogger(logger)()
+
+	log := NewScopedLogger("sync").WithError(errors.New("upstream unavailable")).Named("batch")
+	ctx := context.Background()
+	log.Error(ctx, "item 1 skipped", nil)
+	log.ErrorWithRequest(ctx, "item 2 skipped", "req-1", nil, 12)
+	log.Error(ctx, "item 3 skipped", errors.New("item corrupted"))
+
+	lines := decodeLines(t, buf)
+	want := []string{"upstream unavailable", "upstream unavailable", "item corrupted"}
+	for i, line := range lines {
+		if line["error"] != want[i] || line["module"] != "sync.batch" {
+			t.Errorf("line %d: expected error=%s module=sync.batch, got %v", i, want[i], line)
+		}
+	}
+	if NewScopedLogger("sync").LogErr(ctx, "not logged", nil) != nil || len(decodeLines(t, buf)) != 3 {
+		t.Error("expected LogErr with nil err to log nothing")
+	}
+}
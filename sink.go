@@ -0,0 +1,157 @@
+package zllog
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// ============================================================================
+// Sink - 结构化日志回调（旁路输出到自定义函数）
+// ============================================================================
+
+// defaultSinkBufferSize 异步 sink 的默认缓冲区大小
+const defaultSinkBufferSize = 1024
+
+// SinkOption sink 的可选配置
+type SinkOption func(*sink)
+
+// SinkAsync 在后台 goroutine 中调用 sink，bufferSize 为缓冲的条目数（<=0 时使用默认值 1024）
+// 缓冲区满时丢弃新条目（计入 Stats().Dropped），不会阻塞日志调用
+func SinkAsync(bufferSize int) SinkOption {
+	return func(s *sink) {
+		if bufferSize <= 0 {
+			bufferSize = defaultSinkBufferSize
+		}
+		s.ch = make(chan Entry, bufferSize)
+	}
+}
+
+// sink 一个已注册的回调
+type sink struct {
+	fn   func(Entry)
+	ch   chan Entry // 异步模式的缓冲区（同步模式为 nil）
+	done chan struct{}
+}
+
+// dispatch 将条目交给 sink
+func (s *sink) dispatch(e Entry) {
+	if s.ch == nil {
+		s.fn(e)
+		return
+	}
+	select {
+	case s.ch <- e:
+	default:
+		countDropped()
+	}
+}
+
+// run 异步模式的后台 goroutine
+func (s *sink) run() {
+	defer close(s.done)
+	for e := range s.ch {
+		s.fn(e)
+	}
+}
+
+var (
+	sinksMu sync.Mutex
+	sinks   atomic.Value // []*sink，写时复制，日志热路径无锁读取
+)
+
+// AddSink 注册一个结构化日志回调，ZerologLogger 每输出一行日志后都会以 Entry 调用 fn
+// 默认在日志调用的 goroutine 中同步调用（fn 应尽量快），传入 SinkAsync 改为后台异步调用
+// 返回的函数用于注销 sink，异步模式下会等待缓冲区中的条目处理完毕
+//
+// 用法示例：
+//   remove := zllog.AddSink(func(e zllog.Entry) {
+//       if e.Level == "error" {
+//           errorCounter.WithLabelValues(e.Module).Inc()
+//       }
+//   }, zllog.SinkAsync(0))
+//   defer remove()
+func AddSink(fn func(Entry), opts ...SinkOption) (remove func()) {
+	s := &sink{fn: fn}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.ch != nil {
+		s.done = make(chan struct{})
+		go s.run()
+	}
+
+	sinksMu.Lock()
+	current := loadSinks()
+	next := make([]*sink, 0, len(current)+1)
+	next = append(next, current...)
+	sinks.Store(append(next, s))
+	sinksMu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { removeSink(s) })
+	}
+}
+
+// removeSink 注销 sink 并等待异步缓冲区处理完毕
+func removeSink(s *sink) {
+	sinksMu.Lock()
+	current := loadSinks()
+	next := make([]*sink, 0, len(current))
+	for _, item := range current {
+		if item != s {
+			next = append(next, item)
+		}
+	}
+	sinks.Store(next)
+	sinksMu.Unlock()
+
+	if s.ch != nil {
+		close(s.ch)
+		<-s.done
+	}
+}
+
+// loadSinks 返回当前注册的 sink
+func loadSinks() []*sink {
+	current, _ := sinks.Load().([]*sink)
+	return current
+}
+
+// hasSinks 是否注册了 sink（未注册时跳过 Entry 的构建）
+func hasSinks() bool {
+	return len(loadSinks()) > 0
+}
+
+// dispatchSinks 将一行日志交给所有 sink
+// fields 会被复制，调用方可以在返回后复用切片
+func dispatchSinks(e logEntry, traceID string, fields []Field) {
+	current := loadSinks()
+	if len(current) == 0 {
+		return
+	}
+
+	entry := Entry{
+		Time:          Now(),
+		Level:         e.level.String(),
+		Service:       serviceName,
+		TraceID:       traceID,
+		ParentTraceID: e.parentTraceID,
+		Module:        e.module,
+		Message:       e.message,
+		ErrorCode:     e.errorCode,
+		RequestID:     e.requestID,
+		CostMs:        e.costMs,
+	}
+	if e.err != nil {
+		entry.Error = e.err.Error()
+	}
+	if len(fields) > 0 {
+		entry.Fields = append([]Field(nil), fields...)
+	}
+
+	// 遍历的是快照，sink 中调用 AddSink/remove 不会死锁
+	for _, s := range current {
+		s.dispatch(entry)
+	}
+}
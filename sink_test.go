@@ -0,0 +1,71 @@
+package zllog
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// TestAddSink 测试 sink 收到与输出一致的级别、字段和 trace_id
+func TestAddSink(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{})
+
+	var entries []Entry
+	remove := AddSink(func(e Entry) {
+		entries = append(entries, e)
+	})
+
+	ctx := WithFields(context.Background(), String("tenant", "t1"))
+	logger.Warn(ctx, "order", "stock low", Int("remaining", 3))
+	logger.ErrorWithCode(context.Background(), "order", "payment failed", "PAY_001", errors.New("timeout"))
+	remove()
+	logger.Info(context.Background(), "order", "after remove")
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	warn := entries[0]
+	if warn.Level != "warn" || warn.Module != "order" || warn.Message != "stock low" {
+		t.Errorf("unexpected entry: %+v", warn)
+	}
+	fields := FieldsToMap(warn.Fields)
+	if fields["tenant"] != "t1" || fields["remaining"] != 3 {
+		t.Errorf("unexpected fields: %v", fields)
+	}
+	lines := decodeLines(t, buf)
+	if len(lines) != 3 || lines[0]["trace_id"] != warn.TraceID {
+		t.Errorf("expected sink trace_id %q to match output, got %v", warn.TraceID, lines[0]["trace_id"])
+	}
+
+	errEntry := entries[1]
+	if errEntry.Level != "error" || errEntry.Error != "timeout" || errEntry.ErrorCode != "PAY_001" {
+		t.Errorf("unexpected error entry: %+v", errEntry)
+	}
+}
+
+// TestAddSinkAsync 测试异步 sink 在注销时处理完缓冲区中的条目
+func TestAddSinkAsync(t *testing.T) {
+	logger, _ := newTestLogger(t, &LogConfig{})
+
+	var (
+		mu       sync.Mutex
+		messages []string
+	)
+	remove := AddSink(func(e Entry) {
+		mu.Lock()
+		messages = append(messages, e.Message)
+		mu.Unlock()
+	}, SinkAsync(16))
+
+	for i := 0; i < 5; i++ {
+		logger.Info(context.Background(), "test", "async")
+	}
+	remove()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(messages) != 5 {
+		t.Errorf("expected 5 entries after remove, got %d", len(messages))
+	}
+}
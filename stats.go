@@ -0,0 +1,88 @@
+package zllog
+
+import (
+	"strings"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+)
+
+// ============================================================================
+// 日志统计（按级别计数）
+// ============================================================================
+
+// LogStats 日志统计快照
+type LogStats struct {
+	Levels  map[string]uint64 // 各级别实际输出的日志条数（自定义 Logger 为调用次数），key 为大写级别名（DEBUG/INFO/...）
+	Dropped uint64            // 被丢弃的日志条数（如异步缓冲区溢出）
+	Sampled uint64            // 被采样过滤掉的日志条数
+}
+
+// levelCounters 按级别计数，下标为 zerolog.Level+1（TraceLevel=-1 到 PanicLevel=5）
+var (
+	levelCounters  [7]uint64
+	droppedCounter uint64
+	sampledCounter uint64
+)
+
+// countLevel 记录一条日志（ZerologLogger 在决定输出后按最终级别调用）
+func countLevel(level zerolog.Level) {
+	if i := int(level) + 1; i >= 0 && i < len(levelCounters) {
+		atomic.AddUint64(&levelCounters[i], 1)
+	}
+}
+
+// levelCountingLogger 输出时自行统计级别的 Logger，包级日志函数不再重复计数
+type levelCountingLogger interface {
+	countsLevels() bool
+}
+
+// countLevelFor 在包级日志函数中为不自行统计的 Logger（SetLogger 设置的自定义实现）记录一条日志
+func countLevelFor(logger Logger, level zerolog.Level) {
+	if !loggerCountsLevels(logger) {
+		countLevel(level)
+	}
+}
+
+// loggerCountsLevels Logger 是否在输出时自行统计级别
+func loggerCountsLevels(logger Logger) bool {
+	c, ok := logger.(levelCountingLogger)
+	return ok && c.countsLevels()
+}
+
+// countDropped 记录一条被丢弃的日志
+func countDropped() {
+	atomic.AddUint64(&droppedCounter, 1)
+}
+
+// countSampled 记录一条被采样过滤的日志
+func countSampled() {
+	atomic.AddUint64(&sampledCounter, 1)
+}
+
+// Stats 返回当前的日志统计快照
+// 默认的 ZerologLogger 在决定输出后按最终级别计数（ErrorCodeLevels 调整后的级别），
+// 被级别、限流、采样过滤的日志不计入；通过 SetLogger 设置的自定义实现在包级日志函数（Info、Error 等）中按调用计数，
+// 直接调用自定义 Logger 实例的方法不计入统计
+func Stats() LogStats {
+	stats := LogStats{
+		Levels:  make(map[string]uint64, len(levelCounters)),
+		Dropped: atomic.LoadUint64(&droppedCounter) + DroppedCount(),
+		Sampled: atomic.LoadUint64(&sampledCounter),
+	}
+	for i := range levelCounters {
+		if n := atomic.LoadUint64(&levelCounters[i]); n > 0 {
+			stats.Levels[strings.ToUpper(zerolog.Level(i-1).String())] = n
+		}
+	}
+	return stats
+}
+
+// resetStats 清空统计计数（用于测试）
+func resetStats() {
+	for i := range levelCounters {
+		atomic.StoreUint64(&levelCounters[i], 0)
+	}
+	atomic.StoreUint64(&droppedCounter, 0)
+	atomic.StoreUint64(&sampledCounter, 0)
+}
@@ -0,0 +1,107 @@
+package zllog
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ============================================================================
+// PeriodicSummary - 定期输出汇总日志
+// ============================================================================
+
+// PeriodicSummary 按固定间隔输出一条 INFO 汇总日志，代替逐条输出的高频日志
+//
+// 用法示例：
+//   summary := zllog.NewPeriodicSummary("consumer", 10*time.Second)
+//   defer summary.Close()
+//   for msg := range messages {
+//       summary.Inc("processed", 1)
+//       if err := handle(msg); err != nil {
+//           summary.Inc("errors", 1)
+//       }
+//   }
+//   // 每 10 秒输出：module=consumer processed=10432 errors=12 interval=10000
+type PeriodicSummary struct {
+	module   string
+	interval time.Duration
+
+	mu       sync.Mutex
+	counters map[string]int64
+
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+}
+
+// NewPeriodicSummary 创建汇总日志并启动后台 goroutine
+func NewPeriodicSummary(module string, interval time.Duration) *PeriodicSummary {
+	s := &PeriodicSummary{
+		module:   module,
+		interval: interval,
+		counters: make(map[string]int64),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Inc 累加计数器
+func (s *PeriodicSummary) Inc(field string, n int64) {
+	s.mu.Lock()
+	s.counters[field] += n
+	s.mu.Unlock()
+}
+
+// Close 停止后台 goroutine 并输出剩余的计数
+func (s *PeriodicSummary) Close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		<-s.stopped
+		s.flush()
+	})
+}
+
+// run 后台定时输出
+func (s *PeriodicSummary) run() {
+	defer close(s.stopped)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.flush()
+		}
+	}
+}
+
+// flush 输出当前周期的计数并清零，没有计数时不输出
+func (s *PeriodicSummary) flush() {
+	s.mu.Lock()
+	counters := s.counters
+	s.counters = make(map[string]int64, len(counters))
+	s.mu.Unlock()
+
+	if len(counters) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(counters))
+	for k := range counters {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fields := make([]Field, 0, len(keys)+1)
+	for _, k := range keys {
+		fields = append(fields, Int64(k, counters[k]))
+	}
+	fields = append(fields, Dur("interval", s.interval))
+	Info(context.Background(), s.module, "periodic summary", fields...)
+}
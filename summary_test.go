@@ -0,0 +1,48 @@
+package zllog
+
+import (
+	"testing"
+	"time"
+)
+
+// TestPeriodicSummary 测试按间隔输出汇总，Close 时输出剩余计数
+func TestPeriodicSummary(t *testing.T) {
+	originalLogger := globalLoggerImpl
+	defer func() {
+		globalLoggerImpl = originalLogger
+	}()
+
+	logger, out := newSyncTestLogger(t, &LogConfig{})
+	SetLogger(logger)
+
+	summary := NewPeriodicSummary("consumer", 20*time.Millisecond)
+	for i := 0; i < 100; i++ {
+		summary.Inc("processed", 1)
+	}
+	summary.Inc("errors", 3)
+
+	deadline := time.Now().Add(time.Second)
+	for len(decodeLines(t, out.snapshot())) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	summary.Inc("processed", 5)
+	summary.Close()
+	summary.Close()
+
+	lines := decodeLines(t, out.snapshot())
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 summary lines, got %d: %v", len(lines), lines)
+	}
+	first := lines[0]
+	if first["module"] != "consumer" || first["processed"] != float64(100) || first["errors"] != float64(3) {
+		t.Errorf("unexpected first summary: %v", first)
+	}
+	last := lines[1]
+	if last["processed"] != float64(5) {
+		t.Errorf("expected remaining 5 processed on close, got %v", last)
+	}
+	if _, ok := last["errors"]; ok {
+		t.Errorf("counters should reset after each summary: %v", last)
+	}
+}
@@ -0,0 +1,50 @@
+package zllog
+
+import (
+	"context"
+	"time"
+)
+
+// ============================================================================
+// Timer - 计算请求耗时（cost_ms）
+// ============================================================================
+
+// Timer 记录开始时间，用于计算 cost_ms（默认基于单调时钟，不受系统时间调整影响；可通过 SetClock 替换时钟）
+//
+// 用法示例：
+//   t := zllog.NewTimer()
+//   // ... 处理请求 ...
+//   t.LogInfo(ctx, "api", "request done", requestID, zllog.String("path", r.URL.Path))
+type Timer struct {
+	start time.Time
+}
+
+// NewTimer 创建从当前时间开始计时的 Timer
+func NewTimer() Timer {
+	return Timer{start: Now()}
+}
+
+// Start 返回开始时间
+func (t Timer) Start() time.Time {
+	return t.start
+}
+
+// Elapsed 返回从开始到现在经过的时间
+func (t Timer) Elapsed() time.Duration {
+	return Since(t.start)
+}
+
+// ElapsedMs 返回从开始到现在经过的毫秒数，可直接作为 InfoWithRequest 的 costMs
+func (t Timer) ElapsedMs() int64 {
+	return t.Elapsed().Milliseconds()
+}
+
+// LogInfo 计算耗时并以 INFO 级别记录（request_id + cost_ms）
+func (t Timer) LogInfo(ctx context.Context, module, message, requestID string, fields ...Field) {
+	InfoWithRequest(ctx, module, message, requestID, t.ElapsedMs(), fields...)
+}
+
+// LogError 计算耗时并以 ERROR 级别记录（request_id + cost_ms）
+func (t Timer) LogError(ctx context.Context, module, message, requestID string, err error, fields ...Field) {
+	ErrorWithRequest(ctx, module, message, requestID, err, t.ElapsedMs(), fields...)
+}
@@ -0,0 +1,47 @@
+package zllog
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestTimerElapsed 测试耗时单调递增且与 sleep 的时间大致相符
+func TestTimerElapsed(t *testing.T) {
+	timer := NewTimer()
+	first := timer.Elapsed()
+	time.Sleep(20 * time.Millisecond)
+	second := timer.Elapsed()
+
+	if second < first {
+		t.Errorf("expected monotonic elapsed, got %v then %v", first, second)
+	}
+	if ms := timer.ElapsedMs(); ms < 20 || ms > 1000 {
+		t.Errorf("expected about 20ms, got %dms", ms)
+	}
+}
+
+// TestTimerLogInfo 测试 LogInfo 输出 request_id 和 cost_ms
+func TestTimerLogInfo(t *testing.T) {
+	originalLogger := globalLoggerImpl
+	defer func() {
+		globalLoggerImpl = originalLogger
+	}()
+	logger, buf := newTestLogger(t, &LogConfig{})
+	SetLogger(logger)
+
+	timer := Timer{start: time.Now().Add(-150 * time.Millisecond)}
+	timer.LogInfo(context.Background(), "api", "request done", "req-1", String("path", "/orders"))
+
+	lines := decodeLines(t, buf)
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line, got %d", len(lines))
+	}
+	line := lines[0]
+	if line["request_id"] != "req-1" || line["path"] != "/orders" {
+		t.Errorf("unexpected fields: %v", line)
+	}
+	if cost, _ := line["cost_ms"].(float64); cost < 150 || cost > 1000 {
+		t.Errorf("expected cost_ms about 150, got %v", line["cost_ms"])
+	}
+}
@@ -0,0 +1,65 @@
+package zllog
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestNormalizeTraceID 测试 Provider 返回合法、空和不合法的 trace_id
+func TestNormalizeTraceID(t *testing.T) {
+	withTestTraceProvider(t)
+	withTrace := func(traceID string) context.Context {
+		return context.WithValue(context.Background(), testTraceKey{}, traceID)
+	}
+
+	valid := "4bf92f3577b34da6a3ce929d0e0e4736"
+	if got := GetOrCreateTraceID(withTrace(valid)); got != valid {
+		t.Errorf("expected valid trace_id unchanged, got %s", got)
+	}
+	if got := GetOrCreateTraceID(withTrace(strings.ToUpper(valid))); got != valid {
+		t.Errorf("expected upper-case trace_id lowered, got %s", got)
+	}
+
+	// 空值：生成新的 trace_id
+	if got := GetOrCreateTraceID(withTrace("")); !IsValidTraceID(got) {
+		t.Errorf("expected generated trace_id, got %q", got)
+	}
+
+	// 不合法：哈希为稳定的合法 trace_id
+	for _, malformed := range []string{"abc.123.456", "00000000000000000000000000000000", valid + "0"} {
+		first := GetOrCreateTraceID(withTrace(malformed))
+		second := GetOrCreateTraceID(withTrace(malformed))
+		if !IsValidTraceID(first) || first != second {
+			t.Errorf("%q: expected stable normalized trace_id, got %s and %s", malformed, first, second)
+		}
+	}
+}
+
+// TestStrictTraceID 测试 StrictTraceID 时不合法的 trace_id 被丢弃并提示一次
+func TestStrictTraceID(t *testing.T) {
+	withTestTraceProvider(t)
+	originalLogger := globalLoggerImpl
+	defer func() {
+		globalLoggerImpl = originalLogger
+		strictTraceID = false
+	}()
+	ResetOnce()
+	defer ResetOnce()
+
+	strictTraceID = true
+	logger, buf := newTestLogger(t, &LogConfig{})
+	SetLogger(logger)
+
+	ctx := context.WithValue(context.Background(), testTraceKey{}, "not-a-trace-id")
+	first := GetOrCreateTraceID(ctx)
+	second := GetOrCreateTraceID(ctx)
+	if !IsValidTraceID(first) || !IsValidTraceID(second) || first == second {
+		t.Errorf("expected fresh trace_ids, got %s and %s", first, second)
+	}
+
+	lines := decodeLines(t, buf)
+	if len(lines) != 1 || lines[0]["level"] != "warn" || lines[0]["invalid_trace_id"] != "not-a-trace-id" {
+		t.Errorf("expected a single warning, got %v", lines)
+	}
+}
@@ -0,0 +1,119 @@
+package zllog
+
+import (
+	"encoding/json"
+	"io"
+	"unicode/utf8"
+
+	"github.com/rs/zerolog"
+)
+
+// ============================================================================
+// 消息和字段长度限制
+// ============================================================================
+
+// truncatedSuffix 被截断的消息和字段末尾追加的标记
+const truncatedSuffix = "…(truncated)"
+
+// truncateString 将超过 max 字节的字符串截断为前 max 字节（不拆开多字节字符）并追加截断标记
+// max <= 0 表示不限制；返回值表示是否发生了截断
+func truncateString(s string, max int) (string, bool) {
+	if max <= 0 || len(s) <= max {
+		return s, false
+	}
+	cut := max
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + truncatedSuffix, true
+}
+
+// truncateFields 只保留前 max 个字段
+// 被截掉的元素先清零，切片归还对象池时（putFieldSlice 只清理 len 以内的元素）不会继续持有其中的值
+func truncateFields(fields []Field, max int) []Field {
+	for i := max; i < len(fields); i++ {
+		fields[i] = Field{}
+	}
+	return fields[:max]
+}
+
+// ============================================================================
+// 单行日志大小限制
+// ============================================================================
+
+// lineLimitWriter 超过 max 字节的日志行不写入，改为写入一条摘要
+// 摘要保留 level、time、service、trace_id、module 和（截断后的）message，并附加 line_dropped=true、line_bytes=原始字节数
+type lineLimitWriter struct {
+	out zerolog.LevelWriter
+	max int
+}
+
+// droppedLineSummary 超长日志行的摘要（字段顺序与正常日志一致）
+type droppedLineSummary struct {
+	Level       string `json:"level,omitempty"`
+	Time        string `json:"time,omitempty"`
+	Service     string `json:"service,omitempty"`
+	TraceID     string `json:"trace_id,omitempty"`
+	Module      string `json:"module,omitempty"`
+	Message     string `json:"message,omitempty"`
+	LineDropped bool   `json:"line_dropped"`
+	LineBytes   int    `json:"line_bytes"`
+}
+
+// newLineLimitWriter 创建单行大小限制 writer，max <= 0 时直接返回 out
+func newLineLimitWriter(out io.Writer, max int) io.Writer {
+	if max <= 0 {
+		return out
+	}
+	lw, ok := out.(zerolog.LevelWriter)
+	if !ok {
+		lw = zerolog.LevelWriterAdapter{Writer: out}
+	}
+	return &lineLimitWriter{out: lw, max: max}
+}
+
+func (w *lineLimitWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel 写入日志行，超过限制时写入摘要并计入 Stats().Dropped
+// 返回值始终为 len(p)，避免 zerolog 将摘要替换视为短写
+func (w *lineLimitWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	if len(p) <= w.max {
+		return w.out.WriteLevel(level, p)
+	}
+	countDropped()
+	if _, err := w.out.WriteLevel(level, w.summary(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// summary 生成超长日志行的摘要，message 截断到摘要不超过 max 字节（空间不足时省略 message）
+func (w *lineLimitWriter) summary(p []byte) []byte {
+	var line map[string]interface{}
+	json.Unmarshal(p, &line)
+	str := func(key string) string {
+		s, _ := line[key].(string)
+		return s
+	}
+	summary := droppedLineSummary{
+		Level:       str(zerolog.LevelFieldName),
+		Time:        str(zerolog.TimestampFieldName),
+		Service:     str("service"),
+		TraceID:     str("trace_id"),
+		Module:      str("module"),
+		LineDropped: true,
+		LineBytes:   len(p),
+	}
+	b, _ := json.Marshal(summary)
+	// 预留 ,"message":"" 、截断标记和换行符
+	budget := w.max - len(b) - len(zerolog.MessageFieldName) - len(`,"":""`) - len(truncatedSuffix) - 1
+	if message := str(zerolog.MessageFieldName); message != "" && budget > 0 {
+		summary.Message, _ = truncateString(message, budget)
+		if withMessage, _ := json.Marshal(summary); len(withMessage) < w.max {
+			b = withMessage
+		}
+	}
+	return append(b, '\n')
+}
@@ -2,12 +2,16 @@ package zllog
 
 import (
 	"context"
-	"fmt"
-	"os"
+	"io"
+	"path/filepath"
+	"reflect"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 )
 
@@ -17,8 +21,35 @@ import (
 
 // ZerologLogger 基于 Zerolog 的 Logger 接口实现
 type ZerologLogger struct {
-	logger        *zerolog.Logger
-	enableCaller  bool
+	logger       *zerolog.Logger
+	enableCaller bool
+	enableCtxErr bool
+	enableGID    bool
+	callerSkip   int
+	callerFormat string
+	sampler      *TraceSampler
+	burst        *burstSampler
+	// levelBurst LevelSampling 中配置的级别使用的突发采样器（值为 nil 表示该级别不采样）
+	levelBurst map[zerolog.Level]*burstSampler
+
+	errorCodeLevels map[string]zerolog.Level
+	rateLimiter     *moduleRateLimiter
+	envFilter       *moduleEnvFilter
+	// protectedKeys GlobalFields 的字段名，调用时传入的同名字段会被忽略
+	protectedKeys map[string]struct{}
+
+	maxMessageLength int
+	maxFieldLength   int
+	maxFields        int
+
+	dedupFieldKeys bool
+	dedupKeepFirst bool
+	sortFields     bool
+	// fieldHints 有彩色文本输出时写入 ByteSize 字段的提示，供 consoleWriter 渲染为可读的大小
+	fieldHints bool
+
+	// out logger 的输出（BufferedContext flush 时写入），为 nil 时不支持缓冲
+	out io.Writer
 }
 
 // NewZerologLogger 创建 Zerolog Logger 实例
@@ -29,47 +60,167 @@ func NewZerologLogger(logger *zerolog.Logger) *ZerologLogger {
 	}
 }
 
-// getCaller 获取调用者位置信息（跳过库内部的调用帧）
-// 返回格式：filename:line
-func getCaller() string {
-	// 尝试不同的调用栈深度
-	for skip := 3; skip <= 6; skip++ {
-		pc, file, line, ok := runtime.Caller(skip)
-		if !ok {
-			continue
+// newZerologLoggerWithConfig 根据日志配置创建 Zerolog Logger 实例
+func newZerologLoggerWithConfig(logger *zerolog.Logger, config *LogConfig) *ZerologLogger {
+	l := NewZerologLogger(logger)
+	l.enableCaller = config.EnableCaller
+	l.enableCtxErr = config.EnableCtxErr
+	l.enableGID = config.EnableGoroutineID
+	l.callerSkip = config.CallerSkip
+	l.callerFormat = config.CallerFormat
+	l.maxMessageLength = config.MaxMessageLength
+	l.maxFieldLength = config.MaxFieldLength
+	l.maxFields = config.MaxFields
+	l.dedupFieldKeys = config.DedupFieldKeys
+	l.dedupKeepFirst = config.DedupKeepFirst
+	l.sortFields = config.SortFields
+	l.fieldHints = fieldHintsEnabled(config)
+	l.errorCodeLevels = parseErrorCodeLevels(config.ErrorCodeLevels)
+	l.rateLimiter = newModuleRateLimiter(config.ModuleRateLimits, l.reportRateLimited)
+	l.envFilter = newModuleEnvFilter(config.ModuleEnvAllowlist, config.Env)
+	if len(config.GlobalFields) > 0 && !config.AllowGlobalFieldOverride {
+		l.protectedKeys = make(map[string]struct{}, len(config.GlobalFields))
+		for key := range config.GlobalFields {
+			l.protectedKeys[key] = struct{}{}
 		}
-
-		// 通过 pc 获取函数名
-		fn := runtime.FuncForPC(pc)
-		if fn == nil {
-			continue
+	}
+	if config.TraceSampleRate > 0 && config.TraceSampleRate < 1 {
+		l.sampler = NewTraceSampler(config.TraceSampleRate)
+	}
+	l.burst = newBurstSampler(config.Sampling)
+	if len(config.LevelSampling) > 0 {
+		l.levelBurst = make(map[zerolog.Level]*burstSampler, len(config.LevelSampling))
+		for name, sampling := range config.LevelSampling {
+			if level, err := parseLevel(name); err == nil {
+				l.levelBurst[level] = newBurstSampler(sampling)
+			}
 		}
+	}
+	return l
+}
 
-		// 跳过 zllog 包内部的调用
-		funcName := fn.Name()
-		// 如果函数名包含 "zllog."，说明还在库内部，继续查找
-		if contains(funcName, "zllog.") {
-			continue
+// parseErrorCodeLevels 解析错误码级别映射，错误码统一转为小写（viper 读取配置时 key 会被转为小写）
+// 无法识别的级别会被忽略（对应的错误码仍使用 ERROR）
+func parseErrorCodeLevels(levels map[string]string) map[string]zerolog.Level {
+	if len(levels) == 0 {
+		return nil
+	}
+	parsed := make(map[string]zerolog.Level, len(levels))
+	for code, levelStr := range levels {
+		if level, err := parseLevel(levelStr); err == nil {
+			parsed[strings.ToLower(code)] = level
 		}
+	}
+	return parsed
+}
 
-		// 获取文件名（不包含完整路径）
-		shortFile := file
-		for i := len(file) - 1; i > 0; i-- {
-			if file[i] == '/' || file[i] == '\\' {
-				shortFile = file[i+1:]
-				break
+// errorCodeLevel 返回错误码对应的输出级别，未配置时为 ERROR
+func (l *ZerologLogger) errorCodeLevel(errorCode string) zerolog.Level {
+	if level, ok := l.errorCodeLevels[strings.ToLower(errorCode)]; ok {
+		return level
+	}
+	return zerolog.ErrorLevel
+}
+
+// maxCallerDepth 查找调用者时最多向上遍历的调用帧数
+const maxCallerDepth = 32
+
+// zllogPackage 本包的导入路径，用于识别库内部的调用帧
+var zllogPackage = reflect.TypeOf(ZerologLogger{}).PkgPath()
+
+// callerFrame 获取调用者的调用帧（跳过库内部的调用帧）
+//
+// 从栈顶开始跳过 zllog 包内部（不含 _test.go）和 runtime 包的调用帧，
+// 第一个外部调用帧即为用户代码。skip 在此基础上再向上跳过若干帧，
+// 供在 zllog 外再封装一层日志函数的框架修正调用位置（见 LogConfig.CallerSkip）。
+func callerFrame(skip int) (runtime.Frame, bool) {
+	var pcs [maxCallerDepth]uintptr
+	// 跳过 runtime.Callers 和 callerFrame 自身
+	n := runtime.Callers(2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+
+	external := false
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" {
+			if !external && isInternalFrame(frame) {
+				if !more {
+					break
+				}
+				continue
 			}
+			external = true
+			if skip <= 0 {
+				return frame, true
+			}
+			skip--
+		}
+		if !more {
+			break
 		}
+	}
+
+	return runtime.Frame{}, false
+}
 
-		return fmt.Sprintf("%s:%d", shortFile, line)
+// caller 输出格式（LogConfig.CallerFormat）
+const (
+	CallerFormatShort  = "short"  // 文件名:行号，如 main.go:42（默认）
+	CallerFormatFull   = "full"   // 完整路径:行号，如 /app/cmd/main.go:42
+	CallerFormatObject = "object" // 对象，如 {"file":"/app/cmd/main.go","line":42,"function":"main.run"}
+)
+
+// appendCaller 将调用者位置以 file:line 格式追加到 dst，full 为 false 时只保留文件名
+// 直接写入调用方提供的缓冲区，避免 fmt.Sprintf 的内存分配
+func appendCaller(dst []byte, frame runtime.Frame, full bool) []byte {
+	if full {
+		dst = append(dst, frame.File...)
+	} else {
+		dst = append(dst, filepath.Base(frame.File)...)
+	}
+	dst = append(dst, ':')
+	return strconv.AppendInt(dst, int64(frame.Line), 10)
+}
+
+// addCaller 按 callerFormat 输出 caller 字段
+func (l *ZerologLogger) addCaller(event *zerolog.Event) *zerolog.Event {
+	frame, ok := callerFrame(l.callerSkip)
+	if !ok {
+		frame = runtime.Frame{File: "unknown", Function: "unknown"}
 	}
 
-	return "unknown:0"
+	switch l.callerFormat {
+	case CallerFormatObject:
+		return event.Dict("caller", zerolog.Dict().
+			Str("file", frame.File).
+			Int("line", frame.Line).
+			Str("function", frame.Function))
+	case CallerFormatFull:
+		var buf [256]byte
+		return event.Bytes("caller", appendCaller(buf[:0], frame, true))
+	default:
+		var buf [64]byte
+		return event.Bytes("caller", appendCaller(buf[:0], frame, false))
+	}
 }
 
-// contains 检查字符串是否包含子串
-func contains(s, substr string) bool {
-	return strings.Contains(s, substr)
+// isInternalFrame 判断调用帧是否属于 zllog 包内部或 Go 运行时
+func isInternalFrame(frame runtime.Frame) bool {
+	pkg := funcPackage(frame.Function)
+	if pkg == "runtime" {
+		return true
+	}
+	return pkg == zllogPackage && !strings.HasSuffix(frame.File, "_test.go")
+}
+
+// funcPackage 从完整函数名中提取包路径
+// 如 "github.com/zlxdbj/zllog.(*ZerologLogger).Info" => "github.com/zlxdbj/zllog"
+func funcPackage(funcName string) string {
+	slash := strings.LastIndex(funcName, "/")
+	if dot := strings.Index(funcName[slash+1:], "."); dot >= 0 {
+		return funcName[:slash+1+dot]
+	}
+	return funcName
 }
 
 // addFields 将自定义字段添加到日志事件
@@ -77,6 +228,7 @@ func (l *ZerologLogger) addFields(event *zerolog.Event, fields ...Field) *zerolo
 	for _, field := range fields {
 		switch v := field.Value.(type) {
 		case string:
+			v, _ = truncateString(v, l.maxFieldLength)
 			event = event.Str(field.Key, v)
 		case int:
 			event = event.Int(field.Key, v)
@@ -108,18 +260,32 @@ func (l *ZerologLogger) addFields(event *zerolog.Event, fields ...Field) *zerolo
 			event = event.Time(field.Key, v)
 		case time.Duration:
 			event = event.Dur(field.Key, v)
+		case ByteSize:
+			event = event.Int64(field.Key, int64(v))
 		case error:
 			event = event.Err(v)
 		case []byte:
 			event = event.RawJSON(field.Key, v)
 		case []Field:
-			// 处理 Dict 和 Array 类型
-			if len(v) > 0 {
-				// 判断是 Dict 还是 Array
-				// 这里简化处理，默认使用 Array
-				// 如果需要 Dict，可以使用 zerolog.Dict()
-				event = event.Array(field.Key, zerolog.Arr())
+			// Dict：嵌套对象
+			event = event.Dict(field.Key, l.addFields(zerolog.Dict(), v...))
+		case FieldArray:
+			// Array：只取各字段的值
+			arr := zerolog.Arr()
+			for _, item := range v {
+				if lazy, ok := item.Value.(LazyValue); ok {
+					arr = arr.Interface(lazy())
+					continue
+				}
+				arr = arr.Interface(item.Value)
 			}
+			event = event.Array(field.Key, arr)
+		case ObjectArray:
+			// Objects：对象数组，不经过反射
+			event = event.Array(field.Key, v)
+		case LazyValue:
+			// 延迟求值：此时日志确定会输出
+			event = l.addFields(event, Field{Key: field.Key, Value: v()})
 		default:
 			event = event.Interface(field.Key, v)
 		}
@@ -127,250 +293,402 @@ func (l *ZerologLogger) addFields(event *zerolog.Event, fields ...Field) *zerolo
 	return event
 }
 
-// Debug logs a message at DEBUG level
-func (l *ZerologLogger) Debug(ctx context.Context, module, message string, fields ...Field) {
-	event := l.logger.Debug()
+// addFieldHints 有彩色文本输出时，以提示字段列出值为 ByteSize 的字段名（只看顶层字段）
+func (l *ZerologLogger) addFieldHints(event *zerolog.Event, fields []Field) *zerolog.Event {
+	if !l.fieldHints {
+		return event
+	}
+	var keys []string
+	for _, field := range fields {
+		if _, ok := field.Value.(ByteSize); ok {
+			keys = append(keys, field.Key)
+		}
+	}
+	if len(keys) == 0 {
+		return event
+	}
+	return event.Strs(byteFieldsHintKey, keys)
+}
+
+// addBaggage 以 "baggage.<key>" 字段输出 baggage，按 key 排序保证输出稳定
+func addBaggage(event *zerolog.Event, baggage map[string]string) *zerolog.Event {
+	if len(baggage) == 0 {
+		return event
+	}
+	keys := make([]string, 0, len(baggage))
+	for k := range baggage {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		event = event.Str(baggageFieldPrefix+k, baggage[k])
+	}
+	return event
+}
+
+// logEntry 一次日志调用的参数
+type logEntry struct {
+	level     zerolog.Level
+	module    string
+	message   string
+	err       error
+	errorCode string
+	requestID string
+	costMs    int64
+
+	parentTraceID string
+}
+
+// log 所有日志方法的公共实现：添加 caller、trace_id、module 等公共字段后输出
+// fields 单独传入而不放在 logEntry 中，使变参切片不逃逸到堆上
+func (l *ZerologLogger) log(ctx context.Context, e logEntry, fields []Field) {
+	ctx = contextOrBackground(ctx)
+	// 被 MuteLogging 静默的 context 直接跳过（FATAL 和 PANIC 仍然输出）
+	if e.level < zerolog.FatalLevel && isMuted(ctx) {
+		return
+	}
+	// ModuleEnvAllowlist 不允许在当前环境输出的 module 全部丢弃（可能含敏感信息，FATAL 和 PANIC 也不例外）
+	if l.envFilter != nil && !l.envFilter.Allow(e.module) {
+		return
+	}
+
+	// BufferedContext 中 WARN 以下的日志写入缓冲区，由 flush 决定是否输出
+	logger := l.logger
+	override, overridden := LevelOverrideFromContext(ctx)
+	buffer := l.logBuffer(ctx, e.level)
+	if buffer != nil {
+		buffered := l.logger.Output(bufferWriter{buf: buffer, out: l.out})
+		logger = &buffered
+		// 缓冲的日志只在出错时输出，DEBUG 日志不受全局级别限制
+		if !overridden || override > LevelDebug {
+			override, overridden = LevelDebug, true
+		}
+	}
+
+	// 该级别未启用时直接跳过，跳过 caller 和 trace_id 的计算（context 通过 WithLevelOverride 放宽了门槛时除外）
+	// 级别、限流和采样都在创建事件之前判断，被丢弃的日志不会从 zerolog 的池中取出事件
+	threshold := logger.GetLevel()
+	if global := zerolog.GlobalLevel(); global > threshold {
+		threshold = global
+	}
+	if e.level < threshold && (!overridden || zerolog.Level(override) > e.level) {
+		return
+	}
+
+	// 按 module 限流：超出限额的日志直接丢弃，每个窗口结束后输出一条汇总
+	if l.rateLimiter != nil && !l.rateLimiter.Allow(e.module) {
+		return
+	}
+
+	// 按 trace 采样：同一个 trace 的日志要么全部保留，要么全部丢弃（ERROR 及以上级别不参与）
+	traceID := getTraceID(ctx)
+	if l.sampler != nil && e.level < zerolog.ErrorLevel && !l.sampler.Keep(traceID) {
+		countSampled()
+		return
+	}
+	// 突发采样：同一条日志在周期内超出 Initial 后按比例保留
+	var sampleReason string
+	if burst := l.burstSampler(e.level); burst != nil {
+		var keep bool
+		if keep, sampleReason = burst.sample(e.level, e.module, e.message); !keep {
+			countSampled()
+			return
+		}
+	}
+
+	// 已确定输出，按最终级别计数；缓冲的日志在 flush 输出时计数
+	if buffer == nil {
+		countLevel(e.level)
+	}
+
+	// WithLevel 不会在 FATAL 级别自动退出，退出由 Fatal 方法自行处理
+	event := logger.WithLevel(e.level)
+	if event == nil {
+		// context 放宽了级别门槛（WithLevelOverride）：zerolog 的全局级别无法按事件绕过，
+		// 改用不受级别限制的 Log() 事件并手动写入 level 字段
+		event = logger.Log().Str(zerolog.LevelFieldName, zerolog.LevelFieldMarshalFunc(e.level))
+	}
+
+	if e.err != nil {
+		event = event.Err(e.err)
+		// 结构化错误展开为 err_detail 对象
+		if detail, ok := errorDetail(e.err); ok {
+			event = l.addFields(event, detail)
+		}
+	}
+	// 未显式传入 request_id 时从 context 中读取
+	if e.requestID == "" {
+		e.requestID = RequestIDFromContext(ctx)
+	}
+	if e.requestID != "" {
+		event = event.Str("request_id", e.requestID)
+	}
+	if e.costMs > 0 {
+		event = event.Int64("cost_ms", e.costMs)
+	}
 	if l.enableCaller {
-		event = event.Str("caller", getCaller())
+		event = l.addCaller(event)
+	}
+	if e.errorCode != "" {
+		event = event.Str("error_code", e.errorCode)
+	}
+	if l.enableGID {
+		event = event.Uint64("goroutine_id", goroutineID())
+	}
+	// 注册了 sink 时需要字符串形式的 trace_id 填入 Entry
+	sinking := buffer == nil && hasSinks()
+	if traceID == "" && sinking {
+		traceID = newTraceID()
+	}
+	if traceID != "" {
+		event = event.Str("trace_id", traceID)
+	} else {
+		// 与 newTraceID 格式相同，直接以 hex 写入事件，省去一次字符串分配
+		id := uuid.New()
+		event = event.Hex("trace_id", id[:])
+	}
+	if e.parentTraceID = ParentTraceFromContext(ctx); e.parentTraceID != "" {
+		event = event.Str("parent_trace_id", e.parentTraceID)
+	}
+	event = event.Str("module", e.module)
+	if op := OpFromContext(ctx); op != "" {
+		event = event.Str("op", op)
+	}
+	event = addBaggage(event, getBaggage(ctx))
+	if sampleReason != "" {
+		event = event.Str("sample_reason", sampleReason)
+	}
+
+	// 超长消息截断并标记 truncated=true
+	var truncated bool
+	if e.message, truncated = truncateString(e.message, l.maxMessageLength); truncated {
+		event = event.Bool("truncated", true)
+	}
+
+	// context 已取消或超时时附加 ctx_err，便于排查卡住的长任务
+	if l.enableCtxErr {
+		if err := ctx.Err(); err != nil {
+			event = event.Str("ctx_err", err.Error())
+		}
 	}
-	event = event.Str("trace_id", GetOrCreateTraceID(ctx))
-	event = event.Str("module", module)
+
+	// 合并 context 中的字段（含 ContextEnricher 提取的字段）、去掉与 GlobalFields 同名的字段、去掉重复的字段、按 key 排序、截掉超出 MaxFields 的字段，
+	// 临时切片来自池中，输出后归还；zerolog 在 addFields 时已将字段序列化到自身缓冲区，不会持有切片
+	if ctxFields := contextFields(ctx); len(ctxFields) > 0 || l.protectedKeys != nil || l.dedupFieldKeys || l.sortFields || l.maxFields > 0 && len(fields) > l.maxFields {
+		merged := getFieldSlice()
+		*merged = appendMergedFields(*merged, ctxFields, fields)
+		if l.protectedKeys != nil {
+			*merged = removeFieldKeys(*merged, l.protectedKeys)
+		}
+		var dupKey string
+		if l.dedupFieldKeys {
+			*merged, dupKey = dedupFieldKeys(*merged, l.dedupKeepFirst)
+		}
+		if l.sortFields {
+			sortFieldsByKey(*merged)
+		}
+		if n := len(*merged) - l.maxFields; l.maxFields > 0 && n > 0 {
+			event = event.Int("fields_truncated", n)
+			*merged = truncateFields(*merged, l.maxFields)
+		}
+		event = l.addFields(event, *merged...)
+		event = l.addFieldHints(event, *merged)
+		event.Msg(e.message)
+		if sinking {
+			dispatchSinks(e, traceID, *merged)
+		}
+		putFieldSlice(merged)
+		// 每个重复的字段名只提示一次（与 WarnOnce 共用去重记录）
+		if dupKey != "" {
+			if _, loaded := onceKeys.LoadOrStore("duplicate_field_key:"+dupKey, struct{}{}); !loaded {
+				l.Warn(ctx, "zllog", "duplicate field key dropped", String("key", dupKey), String("source_module", e.module))
+			}
+		}
+		return
+	}
+
 	event = l.addFields(event, fields...)
-	event.Msg(message)
+	event = l.addFieldHints(event, fields)
+	event.Msg(e.message)
+	if sinking {
+		dispatchSinks(e, traceID, fields)
+	}
+}
+
+// logBuffer 返回该条日志应写入的缓冲区（BufferedContext 中 WARN 以下级别），不需要缓冲时返回 nil
+func (l *ZerologLogger) logBuffer(ctx context.Context, level zerolog.Level) *logBuffer {
+	if l.out == nil || level >= zerolog.WarnLevel {
+		return nil
+	}
+	return logBufferFromContext(ctx)
+}
+
+// countsLevels ZerologLogger 在 log 中按最终输出的级别计数
+func (l *ZerologLogger) countsLevels() bool {
+	return true
+}
+
+// Enabled 实现 LevelEnabler：按与 log 相同的顺序判断，但不消耗限流和突发采样的配额
+func (l *ZerologLogger) Enabled(ctx context.Context, module string, level Level) bool {
+	ctx = contextOrBackground(ctx)
+	zl := level.zerologLevel()
+	if zl < zerolog.FatalLevel && isMuted(ctx) {
+		return false
+	}
+	if l.envFilter != nil && !l.envFilter.Allow(module) {
+		return false
+	}
+
+	threshold := l.logger.GetLevel()
+	if global := zerolog.GlobalLevel(); global > threshold {
+		threshold = global
+	}
+	if zl < threshold {
+		override, overridden := LevelOverrideFromContext(ctx)
+		if l.logBuffer(ctx, zl) != nil && (!overridden || override > LevelDebug) {
+			override, overridden = LevelDebug, true
+		}
+		if !overridden || override > level {
+			return false
+		}
+	}
+
+	// 没有 trace_id 时按 SampleUntraced 随机采样，无法预先判断
+	if l.sampler != nil && zl < zerolog.ErrorLevel {
+		if traceID := getTraceID(ctx); traceID != "" && !l.sampler.Keep(traceID) {
+			return false
+		}
+	}
+	return true
+}
+
+// burstSampler 返回该级别使用的突发采样器：LevelSampling 中配置的优先，
+// 其次 ERROR 以下级别使用 Sampling，ERROR 及以上级别默认不采样
+func (l *ZerologLogger) burstSampler(level zerolog.Level) *burstSampler {
+	if burst, ok := l.levelBurst[level]; ok {
+		return burst
+	}
+	if level >= zerolog.ErrorLevel {
+		return nil
+	}
+	return l.burst
+}
+
+// Trace logs a message at TRACE level
+func (l *ZerologLogger) Trace(ctx context.Context, module, message string, fields ...Field) {
+	l.log(ctx, logEntry{level: zerolog.TraceLevel, module: module, message: message}, fields)
+}
+
+// Debug logs a message at DEBUG level
+func (l *ZerologLogger) Debug(ctx context.Context, module, message string, fields ...Field) {
+	l.log(ctx, logEntry{level: zerolog.DebugLevel, module: module, message: message}, fields)
 }
 
 // Info logs a message at INFO level
 func (l *ZerologLogger) Info(ctx context.Context, module, message string, fields ...Field) {
-	event := l.logger.Info()
-	if l.enableCaller {
-		event = event.Str("caller", getCaller())
-	}
-	event = event.Str("trace_id", GetOrCreateTraceID(ctx))
-	event = event.Str("module", module)
-	event = l.addFields(event, fields...)
-	event.Msg(message)
+	l.log(ctx, logEntry{level: zerolog.InfoLevel, module: module, message: message}, fields)
 }
 
 // Warn logs a message at WARN level
 func (l *ZerologLogger) Warn(ctx context.Context, module, message string, fields ...Field) {
-	event := l.logger.Warn()
-	if l.enableCaller {
-		event = event.Str("caller", getCaller())
-	}
-	event = event.Str("trace_id", GetOrCreateTraceID(ctx))
-	event = event.Str("module", module)
-	event = l.addFields(event, fields...)
-	event.Msg(message)
+	l.log(ctx, logEntry{level: zerolog.WarnLevel, module: module, message: message}, fields)
 }
 
 // Error logs a message at ERROR level with error info
 func (l *ZerologLogger) Error(ctx context.Context, module, message string, err error, fields ...Field) {
-	event := l.logger.Error()
-	if err != nil {
-		event = event.Err(err)
-	}
-	if l.enableCaller {
-		event = event.Str("caller", getCaller())
-	}
-	event = event.Str("trace_id", GetOrCreateTraceID(ctx))
-	event = event.Str("module", module)
-	event = l.addFields(event, fields...)
-	event.Msg(message)
+	l.log(ctx, logEntry{level: zerolog.ErrorLevel, module: module, message: message, err: err}, fields)
 }
 
 // ErrorWithCode logs a message at ERROR level with error code
+// 输出级别可通过 LogConfig.ErrorCodeLevels 按错误码调整（如预期内的校验错误使用 WARN）
 func (l *ZerologLogger) ErrorWithCode(ctx context.Context, module, message, errorCode string, err error, fields ...Field) {
-	event := l.logger.Error()
-	if err != nil {
-		event = event.Err(err)
-	}
-	if l.enableCaller {
-		event = event.Str("caller", getCaller())
-	}
-	event = event.Str("error_code", errorCode)
-	event = event.Str("trace_id", GetOrCreateTraceID(ctx))
-	event = event.Str("module", module)
-	event = l.addFields(event, fields...)
-	event.Msg(message)
+	l.log(ctx, logEntry{level: l.errorCodeLevel(errorCode), module: module, message: message, err: err, errorCode: errorCode}, fields)
+}
+
+// FatalNoExit logs a message at FATAL level without exiting
+func (l *ZerologLogger) FatalNoExit(ctx context.Context, module, message string, err error, fields ...Field) {
+	l.log(ctx, logEntry{level: zerolog.FatalLevel, module: module, message: message, err: err}, fields)
 }
 
 // Fatal logs a message at FATAL level and exits
 func (l *ZerologLogger) Fatal(ctx context.Context, module, message string, err error, fields ...Field) {
-	event := l.logger.Fatal()
-	if err != nil {
-		event = event.Err(err)
-	}
-	if l.enableCaller {
-		event = event.Str("caller", getCaller())
-	}
-	event = event.Str("trace_id", GetOrCreateTraceID(ctx))
-	event = event.Str("module", module)
-	event = l.addFields(event, fields...)
-	event.Msg(message)
-	os.Exit(1)
+	l.log(ctx, logEntry{level: zerolog.FatalLevel, module: module, message: message, err: err}, fields)
+	exitFunc(1)
+}
+
+// Panic logs a message at PANIC level and then panics
+// 与 zerolog 的 Panic() 一致，panic 的值为 message
+func (l *ZerologLogger) Panic(ctx context.Context, module, message string, err error, fields ...Field) {
+	l.log(ctx, logEntry{level: zerolog.PanicLevel, module: module, message: message, err: err}, fields)
+	panic(message)
 }
 
 // InfoWithRequest INFO日志 + request_id + cost_ms
 func (l *ZerologLogger) InfoWithRequest(ctx context.Context, module, message, requestID string, costMs int64, fields ...Field) {
-	event := l.logger.Info()
-	if requestID != "" {
-		event = event.Str("request_id", requestID)
-	}
-	if costMs > 0 {
-		event = event.Int64("cost_ms", costMs)
-	}
-	if l.enableCaller {
-		event = event.Str("caller", getCaller())
-	}
-	event = event.Str("trace_id", GetOrCreateTraceID(ctx))
-	event = event.Str("module", module)
-	event = l.addFields(event, fields...)
-	event.Msg(message)
+	l.log(ctx, logEntry{level: zerolog.InfoLevel, module: module, message: message, requestID: requestID, costMs: costMs}, fields)
 }
 
 // ErrorWithRequest ERROR日志 + request_id + cost_ms
 func (l *ZerologLogger) ErrorWithRequest(ctx context.Context, module, message, requestID string, err error, costMs int64, fields ...Field) {
-	event := l.logger.Error()
-	if err != nil {
-		event = event.Err(err)
-	}
-	if requestID != "" {
-		event = event.Str("request_id", requestID)
-	}
-	if costMs > 0 {
-		event = event.Int64("cost_ms", costMs)
-	}
-	if l.enableCaller {
-		event = event.Str("caller", getCaller())
-	}
-	event = event.Str("trace_id", GetOrCreateTraceID(ctx))
-	event = event.Str("module", module)
-	event = l.addFields(event, fields...)
-	event.Msg(message)
+	l.log(ctx, logEntry{level: zerolog.ErrorLevel, module: module, message: message, err: err, requestID: requestID, costMs: costMs}, fields)
 }
 
 // ============================================================================
 // 格式化日志方法实现
 // ============================================================================
 
+// Tracef logs a formatted message at TRACE level
+func (l *ZerologLogger) Tracef(ctx context.Context, module, format string, args ...interface{}) {
+	message, fields := formatMessage(format, args)
+	l.log(ctx, logEntry{level: zerolog.TraceLevel, module: module, message: message}, fields)
+}
+
 // Debugf logs a formatted message at DEBUG level
 func (l *ZerologLogger) Debugf(ctx context.Context, module, format string, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	event := l.logger.Debug()
-	if l.enableCaller {
-		event = event.Str("caller", getCaller())
-	}
-	event = event.Str("trace_id", GetOrCreateTraceID(ctx))
-	event = event.Str("module", module)
-	event.Msg(message)
+	message, fields := formatMessage(format, args)
+	l.log(ctx, logEntry{level: zerolog.DebugLevel, module: module, message: message}, fields)
 }
 
 // Infof logs a formatted message at INFO level
 func (l *ZerologLogger) Infof(ctx context.Context, module, format string, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	event := l.logger.Info()
-	if l.enableCaller {
-		event = event.Str("caller", getCaller())
-	}
-	event = event.Str("trace_id", GetOrCreateTraceID(ctx))
-	event = event.Str("module", module)
-	event.Msg(message)
+	message, fields := formatMessage(format, args)
+	l.log(ctx, logEntry{level: zerolog.InfoLevel, module: module, message: message}, fields)
 }
 
 // Warnf logs a formatted message at WARN level
 func (l *ZerologLogger) Warnf(ctx context.Context, module, format string, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	event := l.logger.Warn()
-	if l.enableCaller {
-		event = event.Str("caller", getCaller())
-	}
-	event = event.Str("trace_id", GetOrCreateTraceID(ctx))
-	event = event.Str("module", module)
-	event.Msg(message)
+	message, fields := formatMessage(format, args)
+	l.log(ctx, logEntry{level: zerolog.WarnLevel, module: module, message: message}, fields)
 }
 
 // Errorf logs a formatted message at ERROR level with error info
 func (l *ZerologLogger) Errorf(ctx context.Context, module, format string, err error, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	event := l.logger.Error()
-	if err != nil {
-		event = event.Err(err)
-	}
-	if l.enableCaller {
-		event = event.Str("caller", getCaller())
-	}
-	event = event.Str("trace_id", GetOrCreateTraceID(ctx))
-	event = event.Str("module", module)
-	event.Msg(message)
+	message, fields := formatMessage(format, args)
+	l.log(ctx, logEntry{level: zerolog.ErrorLevel, module: module, message: message, err: err}, fields)
 }
 
 // ErrorWithCodef logs a formatted message at ERROR level with error code
+// 输出级别同样受 LogConfig.ErrorCodeLevels 控制
 func (l *ZerologLogger) ErrorWithCodef(ctx context.Context, module, format string, errorCode string, err error, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	event := l.logger.Error()
-	if err != nil {
-		event = event.Err(err)
-	}
-	if l.enableCaller {
-		event = event.Str("caller", getCaller())
-	}
-	event = event.Str("error_code", errorCode)
-	event = event.Str("trace_id", GetOrCreateTraceID(ctx))
-	event = event.Str("module", module)
-	event.Msg(message)
+	message, fields := formatMessage(format, args)
+	l.log(ctx, logEntry{level: l.errorCodeLevel(errorCode), module: module, message: message, err: err, errorCode: errorCode}, fields)
 }
 
 // Fatalf logs a formatted message at FATAL level and exits
 func (l *ZerologLogger) Fatalf(ctx context.Context, module, format string, err error, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	event := l.logger.Fatal()
-	if err != nil {
-		event = event.Err(err)
-	}
-	if l.enableCaller {
-		event = event.Str("caller", getCaller())
-	}
-	event = event.Str("trace_id", GetOrCreateTraceID(ctx))
-	event = event.Str("module", module)
-	event.Msg(message)
-	os.Exit(1)
+	message, fields := formatMessage(format, args)
+	l.log(ctx, logEntry{level: zerolog.FatalLevel, module: module, message: message, err: err}, fields)
+	exitFunc(1)
 }
 
 // InfoWithRequestf INFO日志 + request_id + cost_ms (formatted)
 func (l *ZerologLogger) InfoWithRequestf(ctx context.Context, module, format string, requestID string, costMs int64, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	event := l.logger.Info()
-	if requestID != "" {
-		event = event.Str("request_id", requestID)
-	}
-	if costMs > 0 {
-		event = event.Int64("cost_ms", costMs)
-	}
-	if l.enableCaller {
-		event = event.Str("caller", getCaller())
-	}
-	event = event.Str("trace_id", GetOrCreateTraceID(ctx))
-	event = event.Str("module", module)
-	event.Msg(message)
+	message, fields := formatMessage(format, args)
+	l.log(ctx, logEntry{level: zerolog.InfoLevel, module: module, message: message, requestID: requestID, costMs: costMs}, fields)
 }
 
 // ErrorWithRequestf ERROR日志 + request_id + cost_ms (formatted)
 func (l *ZerologLogger) ErrorWithRequestf(ctx context.Context, module, format string, requestID string, err error, costMs int64, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	event := l.logger.Error()
-	if err != nil {
-		event = event.Err(err)
-	}
-	if requestID != "" {
-		event = event.Str("request_id", requestID)
-	}
-	if costMs > 0 {
-		event = event.Int64("cost_ms", costMs)
-	}
-	if l.enableCaller {
-		event = event.Str("caller", getCaller())
-	}
-	event = event.Str("trace_id", GetOrCreateTraceID(ctx))
-	event = event.Str("module", module)
-	event.Msg(message)
+	message, fields := formatMessage(format, args)
+	l.log(ctx, logEntry{level: zerolog.ErrorLevel, module: module, message: message, err: err, requestID: requestID, costMs: costMs}, fields)
 }
@@ -0,0 +1,759 @@
+package zllog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// newTestLogger 创建输出到内存缓冲区的 ZerologLogger
+func newTestLogger(t *testing.T, config *LogConfig) (*ZerologLogger, *bytes.Buffer) {
+	t.Helper()
+
+	// InitLoggerWithConfig 会修改全局级别，测试期间放开到最低级别
+	originalLevel := zerolog.GlobalLevel()
+	zerolog.SetGlobalLevel(zerolog.TraceLevel)
+	t.Cleanup(func() {
+		zerolog.SetGlobalLevel(originalLevel)
+	})
+
+	buf := &bytes.Buffer{}
+	logger := zerolog.New(buf).Level(zerolog.TraceLevel)
+	return newZerologLoggerWithConfig(&logger, config), buf
+}
+
+// syncBuffer 并发安全的缓冲区（用于后台 goroutine 输出日志的测试）
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// snapshot 返回当前内容的副本
+func (b *syncBuffer) snapshot() *bytes.Buffer {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return bytes.NewBuffer(append([]byte(nil), b.buf.Bytes()...))
+}
+
+// newSyncTestLogger 创建输出到并发安全缓冲区的 ZerologLogger
+func newSyncTestLogger(t *testing.T, config *LogConfig) (*ZerologLogger, *syncBuffer) {
+	t.Helper()
+
+	originalLevel := zerolog.GlobalLevel()
+	zerolog.SetGlobalLevel(zerolog.TraceLevel)
+	t.Cleanup(func() {
+		zerolog.SetGlobalLevel(originalLevel)
+	})
+
+	out := &syncBuffer{}
+	logger := zerolog.New(out).Level(zerolog.TraceLevel)
+	return newZerologLoggerWithConfig(&logger, config), out
+}
+
+// decodeLines 将缓冲区中的 JSON 日志逐行解析
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &m); err != nil {
+			t.Fatalf("invalid JSON log line %q: %v", line, err)
+		}
+		lines = append(lines, m)
+	}
+	return lines
+}
+
+// TestCtxErrField 测试 context 取消后附加 ctx_err 字段
+func TestCtxErrField(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{EnableCtxErr: true})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	logger.Info(ctx, "batch", "still running")
+	cancel()
+	logger.Info(ctx, "batch", "still running")
+
+	lines := decodeLines(t, buf)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+	if _, ok := lines[0]["ctx_err"]; ok {
+		t.Errorf("ctx_err should be absent before cancel: %v", lines[0])
+	}
+	if lines[1]["ctx_err"] != context.Canceled.Error() {
+		t.Errorf("expected ctx_err=%q, got %v", context.Canceled.Error(), lines[1]["ctx_err"])
+	}
+}
+
+// TestCtxErrFieldDisabled 测试默认不附加 ctx_err 字段
+func TestCtxErrFieldDisabled(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	logger.Info(ctx, "batch", "canceled")
+
+	if _, ok := decodeLines(t, buf)[0]["ctx_err"]; ok {
+		t.Error("ctx_err should not be attached when disabled")
+	}
+}
+
+// logViaWrapper 模拟业务方在 zllog 外封装的一层日志函数
+func logViaWrapper(logger *ZerologLogger, message string) {
+	logger.Info(context.Background(), "wrapper", message)
+}
+
+// TestCallerSkip 测试 CallerSkip 跳过封装函数，caller 指向封装函数的调用方
+func TestCallerSkip(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{EnableCaller: true, CallerSkip: 1})
+
+	_, file, line, _ := runtime.Caller(0)
+	logViaWrapper(logger, "via wrapper")
+
+	want := fmt.Sprintf("%s:%d", filepath.Base(file), line+1)
+	if got := decodeLines(t, buf)[0]["caller"]; got != want {
+		t.Errorf("expected caller %s, got %v", want, got)
+	}
+}
+
+// TestCallerDefault 测试默认 caller 指向直接调用日志方法的位置
+func TestCallerDefault(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{EnableCaller: true})
+
+	_, file, line, _ := runtime.Caller(0)
+	logger.Info(context.Background(), "test", "direct")
+
+	want := fmt.Sprintf("%s:%d", filepath.Base(file), line+1)
+	if got := decodeLines(t, buf)[0]["caller"]; got != want {
+		t.Errorf("expected caller %s, got %v", want, got)
+	}
+}
+
+// TestCallerEnabledByDefault 测试 DefaultConfig 默认记录 caller，EnableCaller 为 false 时不记录
+func TestCallerEnabledByDefault(t *testing.T) {
+	logger, buf := newTestLogger(t, DefaultConfig("test"))
+	logger.Info(context.Background(), "test", "default")
+	if _, ok := decodeLines(t, buf)[0]["caller"]; !ok {
+		t.Error("expected caller with DefaultConfig")
+	}
+
+	logger, buf = newTestLogger(t, &LogConfig{EnableCaller: false})
+	logger.Info(context.Background(), "test", "disabled")
+	if _, ok := decodeLines(t, buf)[0]["caller"]; ok {
+		t.Error("expected no caller when EnableCaller is false")
+	}
+}
+
+// TestTraceLevelFiltering 测试 TRACE 与 DEBUG 之间的级别过滤
+func TestTraceLevelFiltering(t *testing.T) {
+	level, err := parseLevel("TRACE")
+	if err != nil || level != zerolog.TraceLevel {
+		t.Fatalf("parseLevel(TRACE) = %v, %v", level, err)
+	}
+
+	logger, buf := newTestLogger(t, &LogConfig{})
+	ctx := context.Background()
+
+	logger.Trace(ctx, "test", "trace message")
+	logger.Debug(ctx, "test", "debug message")
+	if lines := decodeLines(t, buf); len(lines) != 2 || lines[0]["level"] != "trace" {
+		t.Fatalf("expected trace and debug lines, got %v", lines)
+	}
+
+	buf.Reset()
+	debugLogger := logger.logger.Level(zerolog.DebugLevel)
+	logger.logger = &debugLogger
+	logger.Tracef(ctx, "test", "trace %d", 1)
+	logger.Debugf(ctx, "test", "debug %d", 2)
+	lines := decodeLines(t, buf)
+	if len(lines) != 1 || lines[0]["level"] != "debug" {
+		t.Errorf("expected only the debug line at DEBUG level, got %v", lines)
+	}
+}
+
+// TestPanic 测试 Panic 先输出 PANIC 日志再抛出可被 recover 的 panic
+func TestPanic(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{})
+
+	recovered := func() (r interface{}) {
+		defer func() {
+			r = recover()
+		}()
+		logger.Panic(context.Background(), "test", "unrecoverable state", fmt.Errorf("bad input"))
+		return nil
+	}()
+
+	if recovered != "unrecoverable state" {
+		t.Errorf("expected panic with message, got %v", recovered)
+	}
+	lines := decodeLines(t, buf)
+	if len(lines) != 1 || lines[0]["level"] != "panic" || lines[0]["error"] != "bad input" {
+		t.Errorf("expected a panic line before panicking, got %v", lines)
+	}
+}
+
+// TestContextFields 测试合并 context 字段，且池化切片不会在调用之间泄漏字段
+func TestContextFields(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{})
+
+	ctx := WithFields(context.Background(), String("user_id", "u-1"), String("tenant", "t-1"))
+	logger.Info(ctx, "api", "first", String("tenant", "t-2"), Int("attempt", 1))
+	logger.Info(ctx, "api", "second")
+	logger.Info(context.Background(), "api", "third")
+
+	lines := decodeLines(t, buf)
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(lines))
+	}
+	if lines[0]["user_id"] != "u-1" || lines[0]["tenant"] != "t-2" || lines[0]["attempt"] != float64(1) {
+		t.Errorf("explicit fields should override context fields: %v", lines[0])
+	}
+	if lines[1]["tenant"] != "t-1" {
+		t.Errorf("expected context tenant, got %v", lines[1]["tenant"])
+	}
+	if _, ok := lines[1]["attempt"]; ok {
+		t.Errorf("field leaked from previous call: %v", lines[1])
+	}
+	for _, key := range []string{"user_id", "tenant", "attempt"} {
+		if _, ok := lines[2][key]; ok {
+			t.Errorf("field %s leaked into call without context fields: %v", key, lines[2])
+		}
+	}
+	if strings.Count(buf.String(), `"tenant"`) != 2 {
+		t.Errorf("duplicate tenant keys in output: %s", buf.String())
+	}
+}
+
+// testBaggageProvider 从 context 中读取测试用的 baggage
+type testBaggageProvider struct{}
+
+func (testBaggageProvider) GetBaggage(ctx context.Context) map[string]string {
+	baggage, _ := ctx.Value(testBaggageKey{}).(map[string]string)
+	return baggage
+}
+
+// testBaggageKey 测试用的 baggage context 键
+type testBaggageKey struct{}
+
+// TestBaggageFields 测试 baggage 以带前缀的字段输出
+func TestBaggageFields(t *testing.T) {
+	original := GetBaggageProvider()
+	RegisterBaggageProvider(testBaggageProvider{})
+	t.Cleanup(func() {
+		RegisterBaggageProvider(original)
+	})
+
+	logger, buf := newTestLogger(t, &LogConfig{})
+	ctx := context.WithValue(context.Background(), testBaggageKey{},
+		map[string]string{"tenant": "acme", "module": "checkout"})
+	logger.Info(ctx, "api", "with baggage")
+	logger.Info(context.Background(), "api", "without baggage")
+
+	lines := decodeLines(t, buf)
+	if lines[0]["baggage.tenant"] != "acme" || lines[0]["baggage.module"] != "checkout" {
+		t.Errorf("expected baggage fields, got %v", lines[0])
+	}
+	if lines[0]["module"] != "api" {
+		t.Errorf("baggage must not override module, got %v", lines[0]["module"])
+	}
+	if _, ok := lines[1]["baggage.tenant"]; ok {
+		t.Errorf("unexpected baggage field: %v", lines[1])
+	}
+}
+
+// TestCallerFormat 测试 short、full、object 三种 caller 格式
+func TestCallerFormat(t *testing.T) {
+	_, file, _, _ := runtime.Caller(0)
+
+	tests := []struct {
+		format string
+		check  func(t *testing.T, caller interface{}, line int)
+	}{
+		{CallerFormatShort, func(t *testing.T, caller interface{}, line int) {
+			if want := fmt.Sprintf("%s:%d", filepath.Base(file), line); caller != want {
+				t.Errorf("expected %s, got %v", want, caller)
+			}
+		}},
+		{CallerFormatFull, func(t *testing.T, caller interface{}, line int) {
+			if want := fmt.Sprintf("%s:%d", file, line); caller != want {
+				t.Errorf("expected %s, got %v", want, caller)
+			}
+		}},
+		{CallerFormatObject, func(t *testing.T, caller interface{}, line int) {
+			obj, _ := caller.(map[string]interface{})
+			if obj["file"] != file || obj["line"] != float64(line) {
+				t.Errorf("unexpected caller object %v", caller)
+			}
+			if fn, _ := obj["function"].(string); !strings.HasSuffix(fn, "TestCallerFormat") {
+				t.Errorf("expected function TestCallerFormat, got %v", obj["function"])
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		logger, buf := newTestLogger(t, &LogConfig{EnableCaller: true, CallerFormat: tt.format})
+
+		_, _, line, _ := runtime.Caller(0)
+		logger.Info(context.Background(), "test", tt.format)
+
+		tt.check(t, decodeLines(t, buf)[0]["caller"], line+1)
+	}
+}
+
+// TestGoroutineID 测试不同 goroutine 输出不同的 goroutine_id
+func TestGoroutineID(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{EnableGoroutineID: true})
+
+	logger.Info(context.Background(), "test", "main goroutine")
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		logger.Info(context.Background(), "test", "other goroutine")
+	}()
+	<-done
+
+	lines := decodeLines(t, buf)
+	first, _ := lines[0]["goroutine_id"].(float64)
+	second, _ := lines[1]["goroutine_id"].(float64)
+	if first == 0 || second == 0 || first == second {
+		t.Errorf("expected distinct non-zero goroutine ids, got %v and %v", lines[0]["goroutine_id"], lines[1]["goroutine_id"])
+	}
+}
+
+// TestGoroutineIDDisabled 测试默认不附加 goroutine_id
+func TestGoroutineIDDisabled(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{})
+	logger.Info(context.Background(), "test", "no goroutine id")
+
+	if _, ok := decodeLines(t, buf)[0]["goroutine_id"]; ok {
+		t.Error("goroutine_id should not be attached when disabled")
+	}
+}
+
+// TestErrorCodeLevels 测试按错误码调整 ErrorWithCode 的输出级别
+func TestErrorCodeLevels(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{
+		ErrorCodeLevels: map[string]string{"VALIDATION_001": "WARN", "BAD_CODE": "NOPE"},
+	})
+	ctx := context.Background()
+
+	logger.ErrorWithCode(ctx, "api", "invalid input", "VALIDATION_001", fmt.Errorf("name required"))
+	logger.ErrorWithCodef(ctx, "api", "invalid %s", "validation_001", nil, "input")
+	logger.ErrorWithCode(ctx, "payment", "charge failed", "PAY_001", fmt.Errorf("timeout"))
+	logger.ErrorWithCode(ctx, "api", "unknown level", "BAD_CODE", nil)
+
+	want := []string{"warn", "warn", "error", "error"}
+	lines := decodeLines(t, buf)
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d lines, got %d", len(want), len(lines))
+	}
+	for i, line := range lines {
+		if line["level"] != want[i] {
+			t.Errorf("line %d (%s): expected level %s, got %v", i, line["error_code"], want[i], line["level"])
+		}
+	}
+}
+
+// TestGlobalFields 测试 GlobalFields 出现在每条日志中且默认不被同名字段覆盖
+func TestGlobalFields(t *testing.T) {
+	originalLevel := zerolog.GlobalLevel()
+	zerolog.SetGlobalLevel(zerolog.TraceLevel)
+	t.Cleanup(func() {
+		zerolog.SetGlobalLevel(originalLevel)
+	})
+
+	global := map[string]interface{}{"region": "cn-east", "cluster": "c1", "version": "1.2.3"}
+	for _, allowOverride := range []bool{false, true} {
+		config := &LogConfig{Env: "test", GlobalFields: global, AllowGlobalFieldOverride: allowOverride}
+		buf := &bytes.Buffer{}
+		base := newBaseLogger(buf, zerolog.TraceLevel, config)
+		logger := newZerologLoggerWithConfig(&base, config)
+
+		ctx := WithFields(context.Background(), String("version", "ctx"))
+		logger.Info(ctx, "api", "first", String("region", "us-west"))
+		logger.Warn(context.Background(), "api", "second")
+
+		lines := decodeLines(t, buf)
+		for _, line := range lines {
+			if line["cluster"] != "c1" || line["env"] != "test" {
+				t.Errorf("missing global fields: %v", line)
+			}
+		}
+
+		if allowOverride {
+			// 同名字段会重复出现，解析时以后出现的值为准
+			if lines[0]["region"] != "us-west" {
+				t.Errorf("expected override with AllowGlobalFieldOverride, got %v", lines[0]["region"])
+			}
+			continue
+		}
+		if lines[0]["region"] != "cn-east" || lines[0]["version"] != "1.2.3" {
+			t.Errorf("global fields must not be overridden: %v", lines[0])
+		}
+		if strings.Count(buf.String(), `"region"`) != 2 {
+			t.Errorf("expected one region key per line: %s", buf.String())
+		}
+	}
+}
+
+// TestSchemaVersion 测试配置 SchemaVersion 后每行日志带 schema_version 字段，且覆盖 GlobalFields 中的同名字段
+func TestSchemaVersion(t *testing.T) {
+	config := &LogConfig{ServiceName: "svc", Env: "test", SchemaVersion: "2",
+		GlobalFields: map[string]interface{}{"schema_version": "1", "region": "cn-east"}}
+	effective := newEffectiveConfig(config)
+	if config.GlobalFields["schema_version"] != "1" {
+		t.Errorf("caller's config should not be modified: %v", config.GlobalFields)
+	}
+
+	buf := &bytes.Buffer{}
+	base := newBaseLogger(buf, zerolog.TraceLevel, &effective)
+	logger := newZerologLoggerWithConfig(&base, &effective)
+	logger.Info(context.Background(), "api", "first")
+	logger.Error(context.Background(), "api", "second", nil, String("schema_version", "ignored"))
+
+	lines := decodeLines(t, buf)
+	for _, line := range lines {
+		if line["schema_version"] != "2" || line["region"] != "cn-east" {
+			t.Errorf("expected schema_version=2 on every line, got %v", line)
+		}
+	}
+	if strings.Count(buf.String(), "\n") != 2 {
+		t.Errorf("expected one JSON object per line, got %q", buf.String())
+	}
+
+	if effective := newEffectiveConfig(&LogConfig{ServiceName: "svc", Env: "test"}); effective.GlobalFields["schema_version"] != nil {
+		t.Errorf("expected no schema_version when not configured, got %v", effective.GlobalFields)
+	}
+}
+
+// TestMaxMessageLength 测试超长消息被截断并附加 truncated=true
+func TestMaxMessageLength(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{MaxMessageLength: 10})
+
+	logger.Info(context.Background(), "test", "short")
+	logger.Info(context.Background(), "test", strings.Repeat("x", 50))
+	// 截断位置落在多字节字符中间时向前退到字符边界
+	logger.Info(context.Background(), "test", "abcdefghi中文")
+
+	lines := decodeLines(t, buf)
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(lines))
+	}
+	if lines[0]["message"] != "short" || lines[0]["truncated"] != nil {
+		t.Errorf("unexpected short line: %v", lines[0])
+	}
+	if lines[1]["message"] != "xxxxxxxxxx…(truncated)" || lines[1]["truncated"] != true {
+		t.Errorf("unexpected truncated line: %v", lines[1])
+	}
+	if lines[2]["message"] != "abcdefghi…(truncated)" {
+		t.Errorf("expected cut at rune boundary, got %v", lines[2]["message"])
+	}
+}
+
+// TestMaxFieldLength 测试超长字符串字段被截断（包括嵌套的 Dict）
+func TestMaxFieldLength(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{MaxFieldLength: 4})
+
+	logger.Info(context.Background(), "test", "payload",
+		String("body", "0123456789"),
+		String("id", "ok"),
+		Int("size", 123456),
+		Dict("req", String("raw", "abcdefgh")))
+
+	lines := decodeLines(t, buf)
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line, got %d", len(lines))
+	}
+	line := lines[0]
+	if line["body"] != "0123…(truncated)" || line["id"] != "ok" || line["size"] != float64(123456) {
+		t.Errorf("unexpected fields: %v", line)
+	}
+	if req, _ := line["req"].(map[string]interface{}); req["raw"] != "abcd…(truncated)" {
+		t.Errorf("expected nested field truncated, got %v", line["req"])
+	}
+	if line["message"] != "payload" || line["truncated"] != nil {
+		t.Errorf("message should not be affected by MaxFieldLength: %v", line)
+	}
+}
+
+// TestMaxFields 测试超出 MaxFields 的字段被丢弃并附加 fields_truncated
+func TestMaxFields(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{MaxFields: 2})
+
+	logger.Info(context.Background(), "test", "ok", String("a", "1"), String("b", "2"))
+	logger.Info(context.Background(), "test", "many", String("a", "1"), String("b", "2"), String("c", "3"), String("d", "4"))
+	// context 中的字段同样计入字段数
+	ctx := WithFields(context.Background(), String("tenant", "t1"))
+	logger.Info(ctx, "test", "ctx", String("a", "1"), String("b", "2"))
+
+	lines := decodeLines(t, buf)
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(lines))
+	}
+	if lines[0]["fields_truncated"] != nil || lines[0]["b"] != "2" {
+		t.Errorf("unexpected line within limit: %v", lines[0])
+	}
+	if lines[1]["fields_truncated"] != float64(2) || lines[1]["b"] != "2" || lines[1]["c"] != nil || lines[1]["d"] != nil {
+		t.Errorf("expected c and d dropped, got %v", lines[1])
+	}
+	if lines[2]["fields_truncated"] != float64(1) || lines[2]["tenant"] != "t1" || lines[2]["b"] != nil {
+		t.Errorf("expected context fields counted, got %v", lines[2])
+	}
+}
+
+// TestMaxLineBytes 测试超长日志行被替换为摘要
+func TestMaxLineBytes(t *testing.T) {
+	before := Stats().Dropped
+	buf := &bytes.Buffer{}
+	base := zerolog.New(newLineLimitWriter(buf, 200)).Level(zerolog.TraceLevel)
+	logger := newZerologLoggerWithConfig(&base, &LogConfig{})
+
+	ctx := withTraceID(context.Background(), "4bf92f3577b34da6a3ce929d0e0e4736")
+	logger.Info(ctx, "test", "small")
+	logger.Info(ctx, "test", strings.Repeat("m", 150), String("blob", strings.Repeat("x", 500)))
+
+	raw := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	for _, line := range raw {
+		if len(line) > 200 {
+			t.Errorf("line exceeds limit: %d bytes", len(line))
+		}
+	}
+	lines := decodeLines(t, buf)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+	if lines[0]["message"] != "small" || lines[0]["line_dropped"] != nil {
+		t.Errorf("unexpected small line: %v", lines[0])
+	}
+	summary := lines[1]
+	if summary["line_dropped"] != true || summary["blob"] != nil {
+		t.Errorf("expected summary line, got %v", summary)
+	}
+	if n, _ := summary["line_bytes"].(float64); n <= 500 {
+		t.Errorf("expected original line size, got %v", summary["line_bytes"])
+	}
+	if summary["level"] != "info" || summary["module"] != "test" || summary["trace_id"] != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("summary should keep level/module/trace_id: %v", summary)
+	}
+	if msg, _ := summary["message"].(string); !strings.HasPrefix(msg, "mmm") || !strings.HasSuffix(msg, truncatedSuffix) {
+		t.Errorf("expected truncated message in summary, got %v", summary["message"])
+	}
+	if got := Stats().Dropped - before; got != 1 {
+		t.Errorf("expected 1 dropped line, got %d", got)
+	}
+}
+
+// TestSortFields 测试 SortFields 时字段按 key 排序，传入顺序不同的相同字段输出完全一致
+func TestSortFields(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{SortFields: true})
+
+	traced := withTraceID(context.Background(), "4bf92f3577b34da6a3ce929d0e0e4736")
+	first := WithFields(traced, String("tenant", "t1"), String("app", "shop"))
+	second := WithFields(traced, String("app", "shop"), String("tenant", "t1"))
+	logger.Info(first, "order", "created", Int("qty", 1), String("user_id", "u1"), String("b", "x"))
+	logger.Info(second, "order", "created", String("b", "x"), String("user_id", "u1"), Int("qty", 1))
+
+	raw := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(raw) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(raw))
+	}
+	if raw[0] != raw[1] {
+		t.Errorf("expected identical lines:\n%s\n%s", raw[0], raw[1])
+	}
+	keys := []string{`"app"`, `"b"`, `"qty"`, `"tenant"`, `"user_id"`, `"message"`}
+	last := -1
+	for _, key := range keys {
+		i := strings.Index(raw[0], key)
+		if i <= last {
+			t.Errorf("expected %s after previous keys in %s", key, raw[0])
+		}
+		last = i
+	}
+}
+
+// TestDedupFieldKeys 测试同名字段只输出一次（默认保留最后一个值），并提示一次重复的字段名
+func TestDedupFieldKeys(t *testing.T) {
+	ResetOnce()
+	defer ResetOnce()
+	logger, buf := newTestLogger(t, &LogConfig{DedupFieldKeys: true})
+
+	ctx := WithFields(context.Background(), String("tenant", "t1"))
+	logger.Info(ctx, "order", "created", String("user_id", "u1"), Int("qty", 1), String("user_id", "u2"))
+	logger.Info(ctx, "order", "again", String("user_id", "u3"), String("user_id", "u4"))
+
+	raw := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(raw) != 3 {
+		t.Fatalf("expected 2 lines and 1 warning, got %d: %q", len(raw), buf.String())
+	}
+	if n := strings.Count(raw[0], `"user_id"`); n != 1 {
+		t.Errorf("expected a single user_id key, got %d in %s", n, raw[0])
+	}
+	lines := decodeLines(t, buf)
+	if lines[0]["user_id"] != "u2" || lines[0]["qty"] != float64(1) {
+		t.Errorf("expected last value to win, got %v", lines[0])
+	}
+	if lines[1]["level"] != "warn" || lines[1]["key"] != "user_id" {
+		t.Errorf("expected duplicate key warning, got %v", lines[1])
+	}
+	if lines[2]["user_id"] != "u4" {
+		t.Errorf("expected last value to win, got %v", lines[2])
+	}
+
+	first, firstBuf := newTestLogger(t, &LogConfig{DedupFieldKeys: true, DedupKeepFirst: true})
+	first.Info(context.Background(), "order", "created", String("user_id", "u1"), String("user_id", "u2"))
+	if got := decodeLines(t, firstBuf)[0]["user_id"]; got != "u1" {
+		t.Errorf("expected first value to win, got %v", got)
+	}
+}
+
+// TestFormattedWithFields 测试格式化方法末尾的 Field 作为结构化字段输出
+func TestFormattedWithFields(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{})
+
+	ctx := context.Background()
+	logger.Infof(ctx, "order", "created %d items for %s", 3, "bob", String("user_id", "u1"), Int("shop", 7))
+	logger.Errorf(ctx, "order", "failed after %d retries", errors.New("timeout"), 2, String("order_id", "o1"))
+	// 中间的 Field 仍按格式参数处理
+	logger.Infof(ctx, "order", "field %v then %d", String("k", "v"), 1)
+	logger.Infof(ctx, "order", "no args")
+
+	lines := decodeLines(t, buf)
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines, got %d", len(lines))
+	}
+	if lines[0]["message"] != "created 3 items for bob" || lines[0]["user_id"] != "u1" || lines[0]["shop"] != float64(7) {
+		t.Errorf("unexpected line: %v", lines[0])
+	}
+	if lines[1]["message"] != "failed after 2 retries" || lines[1]["order_id"] != "o1" || lines[1]["error"] != "timeout" {
+		t.Errorf("unexpected line: %v", lines[1])
+	}
+	if lines[2]["message"] != "field {k v} then 1" || lines[2]["k"] != nil {
+		t.Errorf("unexpected line: %v", lines[2])
+	}
+	if lines[3]["message"] != "no args" {
+		t.Errorf("unexpected line: %v", lines[3])
+	}
+}
+
+// TestTimeLayout 测试 TimeLayout 按字段指定的格式输出，不受全局时间格式影响
+func TestTimeLayout(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{})
+
+	birthday := time.Date(1990, 5, 17, 8, 30, 0, 0, time.UTC)
+	logger.Info(context.Background(), "user", "profile",
+		Time("created_at", birthday), TimeLayout("birthday", birthday, "2006-01-02"))
+
+	lines := decodeLines(t, buf)
+	if lines[0]["birthday"] != "1990-05-17" {
+		t.Errorf("expected date-only birthday, got %v", lines[0]["birthday"])
+	}
+	if lines[0]["created_at"] == lines[0]["birthday"] {
+		t.Errorf("expected global time format to differ from custom layout, got %v", lines[0]["created_at"])
+	}
+}
+
+// TestGeoPoint 测试经纬度字段输出为嵌套的 {lat, lng} 对象
+func TestGeoPoint(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{})
+
+	logger.Info(context.Background(), "location", "reported", GeoPoint("location", 31.2304, -121.4737))
+
+	lines := decodeLines(t, buf)
+	point, ok := lines[0]["location"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected nested location object, got %v", lines[0]["location"])
+	}
+	if len(point) != 2 || point["lat"] != 31.2304 || point["lng"] != -121.4737 {
+		t.Errorf("unexpected location: %v", point)
+	}
+}
+
+// testOrder 实现 zerolog.LogObjectMarshaler 的测试对象
+type testOrder struct {
+	id  string
+	qty int
+}
+
+func (o testOrder) MarshalZerologObject(e *zerolog.Event) {
+	e.Str("id", o.id).Int("qty", o.qty)
+}
+
+// TestObjects 测试对象数组字段输出为 JSON 对象数组，EntryLogger 的 JSON 编码结果一致
+func TestObjects(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{})
+
+	orders := []zerolog.LogObjectMarshaler{testOrder{id: "o1", qty: 1}, testOrder{id: "o2", qty: 3}}
+	logger.Info(context.Background(), "order", "batch created", Objects("orders", orders))
+
+	want := `"orders":[{"id":"o1","qty":1},{"id":"o2","qty":3}]`
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("expected %s in %s", want, buf.String())
+	}
+
+	encoded := EncodeJSON(Entry{Fields: []Field{Objects("orders", orders)}})
+	if !strings.Contains(string(encoded), want) {
+		t.Errorf("expected %s in entry JSON %s", want, encoded)
+	}
+}
+
+// TestValidationErrors 测试字段级校验错误输出为嵌套的 {字段名: 错误信息} 对象
+func TestValidationErrors(t *testing.T) {
+	logger, buf := newTestLogger(t, &LogConfig{})
+
+	errs := map[string]string{"email": "invalid format", "age": "must be >= 18"}
+	logger.Warn(context.Background(), "api", "validation failed", ValidationErrors("validation_errors", errs))
+	logger.Warn(context.Background(), "api", "validation failed", ValidationErrors("validation_errors", nil))
+
+	lines := decodeLines(t, buf)
+	got, ok := lines[0]["validation_errors"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected nested validation_errors object, got %v", lines[0]["validation_errors"])
+	}
+	if len(got) != 2 || got["email"] != "invalid format" || got["age"] != "must be >= 18" {
+		t.Errorf("unexpected validation_errors: %v", got)
+	}
+	if !strings.Contains(buf.String(), `"validation_errors":{"age":"must be >= 18","email":"invalid format"}`) {
+		t.Errorf("expected fields sorted by name, got %s", buf.String())
+	}
+	if empty, ok := lines[1]["validation_errors"].(map[string]interface{}); !ok || len(empty) != 0 {
+		t.Errorf("expected empty object for no errors, got %v", lines[1]["validation_errors"])
+	}
+}
+
+// TestInitLog 测试初始化信息带 event=logger_initialized，SuppressInitLog 时不输出
+func TestInitLog(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := zerolog.New(buf)
+
+	logInitialized(&logger, &LogConfig{LogLevel: "INFO", LogDir: "./logs"})
+	lines := decodeLines(t, buf)
+	if len(lines) != 1 || lines[0]["event"] != "logger_initialized" || lines[0]["config"] == nil {
+		t.Fatalf("unexpected init log: %v", lines)
+	}
+
+	buf.Reset()
+	logInitialized(&logger, &LogConfig{LogLevel: "INFO", SuppressInitLog: true})
+	if buf.Len() != 0 {
+		t.Errorf("expected no init log when suppressed, got %q", buf.String())
+	}
+}